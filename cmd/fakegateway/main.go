@@ -0,0 +1,163 @@
+// Command fakegateway is a local stand-in for a payment gateway. It signs
+// charge events with PAYMENT_WEBHOOK_SECRET_FAKE and posts them to the API
+// webhook, and keeps every event so it can be sent again to test redelivery.
+//
+// Run the API with PAYMENT_FAKE_OUTCOME=pending so charges wait for an event,
+// pay an order through POST /orders/:id/pay and use the returned reference:
+//
+//	go run ./cmd/fakegateway -addr :9090 -webhook http://localhost:8080/webhooks/payments/fake
+//
+//	curl -X POST localhost:9090/charges/<reference>/settlement
+//	curl -X POST localhost:9090/charges/<reference>/expire
+//	curl -X POST localhost:9090/charges/<reference>/refund
+//	curl -X POST localhost:9090/events/<event_id>/replay
+//	curl localhost:9090/events
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/payment"
+	"github.com/joho/godotenv"
+)
+
+type gateway struct {
+	webhook string
+	secret  string
+	client  *http.Client
+
+	mu     sync.Mutex
+	events map[string][]byte
+	order  []string
+}
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	webhook := flag.String("webhook", "http://localhost:8080/webhooks/payments/fake", "webhook url of the API")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("Failed to Load env, using process environment")
+	}
+
+	secret := payment.WebhookSecret("fake")
+	if secret == "" {
+		log.Println("PAYMENT_WEBHOOK_SECRET_FAKE is not set")
+		os.Exit(1)
+	}
+
+	g := &gateway{
+		webhook: *webhook,
+		secret:  secret,
+		client:  &http.Client{},
+		events:  map[string][]byte{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /charges/{reference}/{status}", g.sendStatus)
+	mux.HandleFunc("POST /events/{id}/replay", g.replay)
+	mux.HandleFunc("GET /events", g.list)
+
+	log.Println("fake gateway listening on", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+// sendStatus creates a new event for the charge and delivers it.
+func (g *gateway) sendStatus(w http.ResponseWriter, r *http.Request) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ev := payment.FakeEvent{
+		EventId:           "evt_" + hex.EncodeToString(id),
+		OrderId:           r.URL.Query().Get("order_id"),
+		Reference:         r.PathValue("reference"),
+		TransactionStatus: r.PathValue("status"),
+	}
+
+	body, err := json.Marshal(ev)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	g.mu.Lock()
+	g.events[ev.EventId] = body
+	g.order = append(g.order, ev.EventId)
+	g.mu.Unlock()
+
+	g.deliver(w, ev.EventId, body)
+}
+
+// replay sends a stored event again with the same event id, which the API
+// should acknowledge without applying it twice.
+func (g *gateway) replay(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	g.mu.Lock()
+	body, ok := g.events[id]
+	g.mu.Unlock()
+
+	if !ok {
+		http.Error(w, "event not found", http.StatusNotFound)
+		return
+	}
+
+	g.deliver(w, id, body)
+}
+
+func (g *gateway) list(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	events := []json.RawMessage{}
+	for _, id := range g.order {
+		events = append(events, g.events[id])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(events)
+}
+
+func (g *gateway) deliver(w http.ResponseWriter, id string, body []byte) {
+	req, err := http.NewRequest(http.MethodPost, g.webhook, bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(payment.SignatureHeader, payment.Sign(g.secret, body))
+
+	res, err := g.client.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer res.Body.Close()
+
+	resBody, _ := io.ReadAll(res.Body)
+	log.Printf("event %s -> %d %s", id, res.StatusCode, resBody)
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"event_id":%q,"webhook_status":%d,"webhook_response":%s}`+"\n", id, res.StatusCode, jsonOrString(resBody))
+}
+
+func jsonOrString(b []byte) string {
+	if json.Valid(b) {
+		return string(b)
+	}
+	s, _ := json.Marshal(string(b))
+	return string(s)
+}
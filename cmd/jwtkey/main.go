@@ -0,0 +1,66 @@
+// Command jwtkey writes a new access token signing key to the JWT keys
+// directory as <kid>.pem and prints its kid. The key is picked up as a
+// verification key on the next start and signs tokens once JWT_SIGNING_KID
+// names it. See "Rotating signing keys" in the README.
+//
+//	go run ./cmd/jwtkey
+//	go run ./cmd/jwtkey -alg RS256 -dir keys
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func main() {
+	alg := flag.String("alg", "EdDSA", "signing algorithm, EdDSA or RS256")
+	dir := flag.String("dir", "keys", "directory JWT_KEYS_DIR points at")
+	kid := flag.String("kid", time.Now().Format("20060102-150405"), "key id, used as file name")
+	flag.Parse()
+
+	var key crypto.Signer
+	var err error
+	switch *alg {
+	case "EdDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		log.Fatalf("unsupported -alg %q, use EdDSA or RS256", *alg)
+	}
+	if err != nil {
+		log.Fatalln("Failed to generate key:", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		log.Fatalln("Failed to encode key:", err)
+	}
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		log.Fatalln("Failed to create key directory:", err)
+	}
+
+	file := filepath.Join(*dir, *kid+".pem")
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		log.Fatalln("Failed to create key file:", err)
+	}
+	defer f.Close()
+
+	if err := pem.Encode(f, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		log.Fatalln("Failed to write key file:", err)
+	}
+
+	fmt.Println(*kid)
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"log"
 	"os"
+	// The runtime image has no zoneinfo, STORE_TIMEZONE needs the embedded copy.
+	_ "time/tzdata"
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/config"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/router"
+	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
@@ -27,6 +30,11 @@ func main() {
 		}
 	}
 
+	if err := jwtutil.LoadKeys(os.Getenv("JWT_KEYS_DIR"), os.Getenv("JWT_SIGNING_KID")); err != nil {
+		log.Println("Failed to Load JWT keys:", err)
+		return
+	}
+
 	db, err := config.InitDB()
 	if err != nil {
 		log.Println("Failed to Connect to Database")
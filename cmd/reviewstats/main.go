@@ -0,0 +1,36 @@
+// Command reviewstats rebuilds the rating_avg and review_count columns of
+// products from the reviews table. The review service keeps them up to date,
+// so a rebuild is only needed to backfill or after editing reviews by hand.
+//
+//	go run ./cmd/reviewstats
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/config"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Failed to Load env, using process environment")
+	}
+
+	db, err := config.InitDB()
+	if err != nil {
+		log.Fatalln("Failed to Connect to Database:", err)
+	}
+	defer db.Close()
+
+	start := time.Now()
+	updated, err := repository.NewReviewRepository().RebuildProductRatings(context.Background(), db)
+	if err != nil {
+		log.Fatalln("Failed to rebuild product ratings:", err)
+	}
+	fmt.Printf("products updated: %d in %s\n", updated, time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS public.orders
+    DROP CONSTRAINT IF EXISTS orders_voucher_id_fkey,
+    DROP COLUMN IF EXISTS voucher_id,
+    DROP COLUMN IF EXISTS discount;
+
+DROP TABLE IF EXISTS public.vouchers;
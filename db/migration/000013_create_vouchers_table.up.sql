@@ -0,0 +1,39 @@
+CREATE TABLE public.vouchers (
+    id integer NOT NULL,
+    code character varying(20) NOT NULL,
+    name character varying(255) DEFAULT '',
+    description text DEFAULT '',
+    discount double precision DEFAULT 0,
+    min_order double precision DEFAULT 0,
+    start_date date,
+    end_date date,
+    usage_limit integer DEFAULT 0,
+    usage_count integer DEFAULT 0,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone,
+    deleted_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.vouchers_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.vouchers_id_seq OWNED BY public.vouchers.id;
+
+ALTER TABLE ONLY public.vouchers ALTER COLUMN id SET DEFAULT nextval('public.vouchers_id_seq'::regclass);
+
+ALTER TABLE ONLY public.vouchers
+    ADD CONSTRAINT vouchers_pkey PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX vouchers_code_key ON public.vouchers (code) WHERE deleted_at IS NULL;
+
+ALTER TABLE public.orders
+    ADD COLUMN voucher_id integer,
+    ADD COLUMN discount double precision DEFAULT 0;
+
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_voucher_id_fkey FOREIGN KEY (voucher_id) REFERENCES public.vouchers(id);
@@ -0,0 +1,43 @@
+CREATE TABLE public.cart_items (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    menu_id integer NOT NULL,
+    product_size_id integer NOT NULL,
+    product_type_id integer NOT NULL,
+    qty integer DEFAULT 1 NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.cart_items_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.cart_items_id_seq OWNED BY public.cart_items.id;
+
+ALTER TABLE ONLY public.cart_items ALTER COLUMN id SET DEFAULT nextval('public.cart_items_id_seq'::regclass);
+
+ALTER TABLE ONLY public.cart_items
+    ADD CONSTRAINT cart_items_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.cart_items
+    ADD CONSTRAINT cart_items_qty_check CHECK (qty > 0);
+
+ALTER TABLE ONLY public.cart_items
+    ADD CONSTRAINT cart_items_user_menu_size_type_key UNIQUE (user_id, menu_id, product_size_id, product_type_id);
+
+ALTER TABLE ONLY public.cart_items
+    ADD CONSTRAINT cart_items_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id);
+
+ALTER TABLE ONLY public.cart_items
+    ADD CONSTRAINT cart_items_menu_id_fkey FOREIGN KEY (menu_id) REFERENCES public.menus(id);
+
+ALTER TABLE ONLY public.cart_items
+    ADD CONSTRAINT cart_items_product_size_id_fkey FOREIGN KEY (product_size_id) REFERENCES public.product_size(id);
+
+ALTER TABLE ONLY public.cart_items
+    ADD CONSTRAINT cart_items_product_type_id_fkey FOREIGN KEY (product_type_id) REFERENCES public.product_type(id);
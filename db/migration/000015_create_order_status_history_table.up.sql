@@ -0,0 +1,35 @@
+CREATE TABLE public.order_status_history (
+    id integer NOT NULL,
+    order_id uuid NOT NULL,
+    status character varying(255) NOT NULL,
+    actor_id integer,
+    note text,
+    created_at timestamp without time zone DEFAULT now()
+);
+
+CREATE SEQUENCE public.order_status_history_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.order_status_history_id_seq OWNED BY public.order_status_history.id;
+
+ALTER TABLE ONLY public.order_status_history ALTER COLUMN id SET DEFAULT nextval('public.order_status_history_id_seq'::regclass);
+
+ALTER TABLE ONLY public.order_status_history
+    ADD CONSTRAINT order_status_history_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.order_status_history
+    ADD CONSTRAINT order_status_history_order_id_fkey FOREIGN KEY (order_id) REFERENCES public.orders(id);
+
+ALTER TABLE ONLY public.order_status_history
+    ADD CONSTRAINT order_status_history_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES public.users(id);
+
+CREATE INDEX order_status_history_order_id_idx ON public.order_status_history (order_id, created_at);
+
+INSERT INTO public.order_status_history (order_id, status, note, created_at)
+SELECT id, COALESCE(status, 'pending'), 'Imported from existing order', COALESCE(updated_at, created_at, now())
+FROM public.orders;
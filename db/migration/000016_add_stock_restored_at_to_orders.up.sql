@@ -0,0 +1,6 @@
+ALTER TABLE ONLY public.orders
+    ADD COLUMN stock_restored_at timestamp without time zone;
+
+UPDATE public.orders
+SET stock_restored_at = COALESCE(updated_at, created_at, now())
+WHERE status = 'cancelled';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS public.payment_attempts;
+
+ALTER TABLE IF EXISTS public.payments
+    DROP COLUMN IF EXISTS provider,
+    DROP COLUMN IF EXISTS is_active;
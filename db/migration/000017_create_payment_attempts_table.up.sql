@@ -0,0 +1,40 @@
+ALTER TABLE public.payments
+    ADD COLUMN provider character varying(50) DEFAULT 'manual'::character varying NOT NULL,
+    ADD COLUMN is_active boolean DEFAULT true NOT NULL;
+
+CREATE TABLE public.payment_attempts (
+    id integer NOT NULL,
+    order_id uuid NOT NULL,
+    payment_id integer NOT NULL,
+    provider character varying(50) NOT NULL,
+    reference character varying(255),
+    amount double precision NOT NULL,
+    status character varying(50) DEFAULT 'pending'::character varying NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.payment_attempts_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.payment_attempts_id_seq OWNED BY public.payment_attempts.id;
+
+ALTER TABLE ONLY public.payment_attempts ALTER COLUMN id SET DEFAULT nextval('public.payment_attempts_id_seq'::regclass);
+
+ALTER TABLE ONLY public.payment_attempts
+    ADD CONSTRAINT payment_attempts_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.payment_attempts
+    ADD CONSTRAINT payment_attempts_order_id_fkey FOREIGN KEY (order_id) REFERENCES public.orders(id);
+
+ALTER TABLE ONLY public.payment_attempts
+    ADD CONSTRAINT payment_attempts_payment_id_fkey FOREIGN KEY (payment_id) REFERENCES public.payments(id);
+
+CREATE INDEX payment_attempts_order_id_idx ON public.payment_attempts (order_id);
+
+CREATE UNIQUE INDEX payment_attempts_provider_reference_key ON public.payment_attempts (provider, reference) WHERE reference IS NOT NULL;
@@ -0,0 +1,27 @@
+CREATE TABLE public.payment_events (
+    id integer NOT NULL,
+    provider character varying(50) NOT NULL,
+    event_id character varying(255) NOT NULL,
+    reference character varying(255),
+    status character varying(50),
+    payload jsonb,
+    created_at timestamp without time zone DEFAULT now()
+);
+
+CREATE SEQUENCE public.payment_events_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.payment_events_id_seq OWNED BY public.payment_events.id;
+
+ALTER TABLE ONLY public.payment_events ALTER COLUMN id SET DEFAULT nextval('public.payment_events_id_seq'::regclass);
+
+ALTER TABLE ONLY public.payment_events
+    ADD CONSTRAINT payment_events_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.payment_events
+    ADD CONSTRAINT payment_events_provider_event_id_key UNIQUE (provider, event_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.product_categories_product_id_idx;
+
+DROP INDEX IF EXISTS public.categories_name_key;
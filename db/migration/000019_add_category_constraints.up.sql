@@ -0,0 +1,3 @@
+CREATE UNIQUE INDEX categories_name_key ON public.categories (lower(name)) WHERE deleted_at IS NULL;
+
+CREATE INDEX product_categories_product_id_idx ON public.product_categories (product_id) WHERE deleted_at IS NULL;
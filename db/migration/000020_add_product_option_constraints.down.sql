@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.product_type_name_key;
+
+DROP INDEX IF EXISTS public.product_size_name_key;
@@ -0,0 +1,3 @@
+CREATE UNIQUE INDEX product_size_name_key ON public.product_size (lower(name)) WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX product_type_name_key ON public.product_type (lower(name)) WHERE deleted_at IS NULL;
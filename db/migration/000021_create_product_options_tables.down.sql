@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.product_type_options;
+
+DROP TABLE IF EXISTS public.product_size_options;
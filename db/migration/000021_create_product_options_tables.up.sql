@@ -0,0 +1,71 @@
+CREATE TABLE public.product_size_options (
+    id integer NOT NULL,
+    product_id integer NOT NULL,
+    product_size_id integer NOT NULL,
+    price integer,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.product_size_options_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.product_size_options_id_seq OWNED BY public.product_size_options.id;
+
+ALTER TABLE ONLY public.product_size_options ALTER COLUMN id SET DEFAULT nextval('public.product_size_options_id_seq'::regclass);
+
+ALTER TABLE ONLY public.product_size_options
+    ADD CONSTRAINT product_size_options_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.product_size_options
+    ADD CONSTRAINT product_size_options_price_check CHECK (price >= 0);
+
+ALTER TABLE ONLY public.product_size_options
+    ADD CONSTRAINT product_size_options_product_size_key UNIQUE (product_id, product_size_id);
+
+ALTER TABLE ONLY public.product_size_options
+    ADD CONSTRAINT product_size_options_product_id_fkey FOREIGN KEY (product_id) REFERENCES public.products(id);
+
+ALTER TABLE ONLY public.product_size_options
+    ADD CONSTRAINT product_size_options_product_size_id_fkey FOREIGN KEY (product_size_id) REFERENCES public.product_size(id);
+
+CREATE TABLE public.product_type_options (
+    id integer NOT NULL,
+    product_id integer NOT NULL,
+    product_type_id integer NOT NULL,
+    price integer,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.product_type_options_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.product_type_options_id_seq OWNED BY public.product_type_options.id;
+
+ALTER TABLE ONLY public.product_type_options ALTER COLUMN id SET DEFAULT nextval('public.product_type_options_id_seq'::regclass);
+
+ALTER TABLE ONLY public.product_type_options
+    ADD CONSTRAINT product_type_options_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.product_type_options
+    ADD CONSTRAINT product_type_options_price_check CHECK (price >= 0);
+
+ALTER TABLE ONLY public.product_type_options
+    ADD CONSTRAINT product_type_options_product_type_key UNIQUE (product_id, product_type_id);
+
+ALTER TABLE ONLY public.product_type_options
+    ADD CONSTRAINT product_type_options_product_id_fkey FOREIGN KEY (product_id) REFERENCES public.products(id);
+
+ALTER TABLE ONLY public.product_type_options
+    ADD CONSTRAINT product_type_options_product_type_id_fkey FOREIGN KEY (product_type_id) REFERENCES public.product_type(id);
@@ -0,0 +1,28 @@
+ALTER TABLE public.product_type_options
+    ALTER COLUMN price TYPE integer;
+
+ALTER TABLE public.product_size_options
+    ALTER COLUMN price TYPE integer;
+
+ALTER TABLE public.product_type
+    ALTER COLUMN price TYPE integer;
+
+ALTER TABLE public.product_size
+    ALTER COLUMN price TYPE integer;
+
+ALTER TABLE public.payment_attempts
+    ALTER COLUMN amount TYPE double precision;
+
+ALTER TABLE public.vouchers
+    ALTER COLUMN min_order TYPE double precision;
+
+ALTER TABLE public.dt_order
+    ALTER COLUMN subtotal TYPE double precision;
+
+ALTER TABLE public.orders
+    ALTER COLUMN tax TYPE double precision,
+    ALTER COLUMN total TYPE double precision,
+    ALTER COLUMN discount TYPE double precision;
+
+ALTER TABLE public.products
+    ALTER COLUMN price TYPE double precision;
@@ -0,0 +1,28 @@
+ALTER TABLE public.products
+    ALTER COLUMN price TYPE bigint USING round(price)::bigint;
+
+ALTER TABLE public.orders
+    ALTER COLUMN tax TYPE bigint USING round(tax)::bigint,
+    ALTER COLUMN total TYPE bigint USING round(total)::bigint,
+    ALTER COLUMN discount TYPE bigint USING round(discount)::bigint;
+
+ALTER TABLE public.dt_order
+    ALTER COLUMN subtotal TYPE bigint USING round(subtotal)::bigint;
+
+ALTER TABLE public.vouchers
+    ALTER COLUMN min_order TYPE bigint USING round(min_order)::bigint;
+
+ALTER TABLE public.payment_attempts
+    ALTER COLUMN amount TYPE bigint USING round(amount)::bigint;
+
+ALTER TABLE public.product_size
+    ALTER COLUMN price TYPE bigint;
+
+ALTER TABLE public.product_type
+    ALTER COLUMN price TYPE bigint;
+
+ALTER TABLE public.product_size_options
+    ALTER COLUMN price TYPE bigint;
+
+ALTER TABLE public.product_type_options
+    ALTER COLUMN price TYPE bigint;
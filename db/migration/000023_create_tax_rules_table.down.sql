@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.order_taxes;
+
+DROP TABLE IF EXISTS public.tax_rules;
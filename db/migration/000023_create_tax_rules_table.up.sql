@@ -0,0 +1,84 @@
+CREATE TABLE public.tax_rules (
+    id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    kind character varying(20) DEFAULT 'tax'::character varying NOT NULL,
+    rate numeric(5,2) NOT NULL,
+    shipping character varying(255),
+    is_inclusive boolean DEFAULT false NOT NULL,
+    effective_from date DEFAULT CURRENT_DATE NOT NULL,
+    effective_to date,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone,
+    deleted_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.tax_rules_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.tax_rules_id_seq OWNED BY public.tax_rules.id;
+
+ALTER TABLE ONLY public.tax_rules ALTER COLUMN id SET DEFAULT nextval('public.tax_rules_id_seq'::regclass);
+
+ALTER TABLE ONLY public.tax_rules
+    ADD CONSTRAINT tax_rules_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.tax_rules
+    ADD CONSTRAINT tax_rules_kind_check CHECK (kind IN ('tax', 'service'));
+
+ALTER TABLE ONLY public.tax_rules
+    ADD CONSTRAINT tax_rules_rate_check CHECK (rate >= 0 AND rate <= 100);
+
+ALTER TABLE ONLY public.tax_rules
+    ADD CONSTRAINT tax_rules_effective_check CHECK (effective_to IS NULL OR effective_to >= effective_from);
+
+CREATE TABLE public.order_taxes (
+    id integer NOT NULL,
+    order_id uuid NOT NULL,
+    tax_rule_id integer,
+    name character varying(100) NOT NULL,
+    kind character varying(20) NOT NULL,
+    rate numeric(5,2) NOT NULL,
+    is_inclusive boolean NOT NULL,
+    base bigint NOT NULL,
+    amount bigint NOT NULL,
+    created_at timestamp without time zone DEFAULT now()
+);
+
+CREATE SEQUENCE public.order_taxes_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.order_taxes_id_seq OWNED BY public.order_taxes.id;
+
+ALTER TABLE ONLY public.order_taxes ALTER COLUMN id SET DEFAULT nextval('public.order_taxes_id_seq'::regclass);
+
+ALTER TABLE ONLY public.order_taxes
+    ADD CONSTRAINT order_taxes_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.order_taxes
+    ADD CONSTRAINT order_taxes_order_id_fkey FOREIGN KEY (order_id) REFERENCES public.orders(id);
+
+ALTER TABLE ONLY public.order_taxes
+    ADD CONSTRAINT order_taxes_tax_rule_id_fkey FOREIGN KEY (tax_rule_id) REFERENCES public.tax_rules(id);
+
+CREATE INDEX order_taxes_order_id_idx ON public.order_taxes (order_id);
+
+-- The flat 10% tax that used to be hardcoded becomes the first rule, and
+-- existing orders get it as their breakdown.
+INSERT INTO public.tax_rules (name, kind, rate, effective_from)
+VALUES ('Tax', 'tax', 10, DATE '2000-01-01');
+
+INSERT INTO public.order_taxes (order_id, tax_rule_id, name, kind, rate, is_inclusive, base, amount, created_at)
+SELECT o.id, r.id, r.name, r.kind, r.rate, r.is_inclusive, o.total - o.tax, o.tax, o.created_at
+FROM public.orders o
+CROSS JOIN public.tax_rules r
+WHERE o.tax > 0;
@@ -0,0 +1,6 @@
+ALTER TABLE ONLY public.menus
+    DROP CONSTRAINT IF EXISTS menus_discount_check;
+
+ALTER TABLE ONLY public.menus
+    ALTER COLUMN discount DROP NOT NULL,
+    ALTER COLUMN discount DROP DEFAULT;
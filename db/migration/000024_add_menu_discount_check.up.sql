@@ -0,0 +1,12 @@
+-- Menu discounts are percentages, the same as voucher discounts. Orders used
+-- to read them as fractions, so values below 1 were stored that way.
+UPDATE public.menus SET discount = 0 WHERE discount IS NULL;
+
+UPDATE public.menus SET discount = discount * 100 WHERE discount > 0 AND discount < 1;
+
+ALTER TABLE ONLY public.menus
+    ALTER COLUMN discount SET DEFAULT 0,
+    ALTER COLUMN discount SET NOT NULL;
+
+ALTER TABLE ONLY public.menus
+    ADD CONSTRAINT menus_discount_check CHECK (discount >= 0 AND discount <= 100);
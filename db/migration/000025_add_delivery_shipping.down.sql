@@ -0,0 +1,26 @@
+ALTER TABLE ONLY public.orders
+    DROP CONSTRAINT IF EXISTS orders_address_id_fkey,
+    DROP COLUMN IF EXISTS delivery_zone,
+    DROP COLUMN IF EXISTS delivery_address,
+    DROP COLUMN IF EXISTS delivery_phone,
+    DROP COLUMN IF EXISTS delivery_recipient,
+    DROP COLUMN IF EXISTS delivery_label,
+    DROP COLUMN IF EXISTS address_id,
+    DROP COLUMN IF EXISTS delivery_fee;
+
+ALTER TABLE ONLY public.tax_rules
+    DROP CONSTRAINT IF EXISTS tax_rules_shipping_check;
+
+ALTER TABLE ONLY public.tax_rules
+    ALTER COLUMN shipping TYPE character varying(255);
+
+ALTER TABLE ONLY public.orders
+    DROP CONSTRAINT IF EXISTS orders_shipping_check;
+
+ALTER TABLE ONLY public.orders
+    ALTER COLUMN shipping TYPE character varying(255),
+    ALTER COLUMN shipping DROP NOT NULL;
+
+DROP TABLE IF EXISTS public.user_addresses;
+
+DROP TABLE IF EXISTS public.delivery_zones;
@@ -0,0 +1,124 @@
+CREATE TABLE public.delivery_zones (
+    id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    fee bigint DEFAULT 0 NOT NULL,
+    is_active boolean DEFAULT true NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone,
+    deleted_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.delivery_zones_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.delivery_zones_id_seq OWNED BY public.delivery_zones.id;
+
+ALTER TABLE ONLY public.delivery_zones ALTER COLUMN id SET DEFAULT nextval('public.delivery_zones_id_seq'::regclass);
+
+ALTER TABLE ONLY public.delivery_zones
+    ADD CONSTRAINT delivery_zones_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.delivery_zones
+    ADD CONSTRAINT delivery_zones_fee_check CHECK (fee >= 0);
+
+CREATE UNIQUE INDEX delivery_zones_name_key ON public.delivery_zones (lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TABLE public.user_addresses (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    zone_id integer,
+    label character varying(50) NOT NULL,
+    recipient character varying(255) NOT NULL,
+    phone character varying(40) DEFAULT '' NOT NULL,
+    address text NOT NULL,
+    is_default boolean DEFAULT false NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone,
+    deleted_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.user_addresses_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.user_addresses_id_seq OWNED BY public.user_addresses.id;
+
+ALTER TABLE ONLY public.user_addresses ALTER COLUMN id SET DEFAULT nextval('public.user_addresses_id_seq'::regclass);
+
+ALTER TABLE ONLY public.user_addresses
+    ADD CONSTRAINT user_addresses_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.user_addresses
+    ADD CONSTRAINT user_addresses_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id);
+
+ALTER TABLE ONLY public.user_addresses
+    ADD CONSTRAINT user_addresses_zone_id_fkey FOREIGN KEY (zone_id) REFERENCES public.delivery_zones(id);
+
+CREATE INDEX user_addresses_user_id_idx ON public.user_addresses (user_id) WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX user_addresses_default_key ON public.user_addresses (user_id) WHERE is_default AND deleted_at IS NULL;
+
+-- The profile address becomes the first entry of every address book.
+INSERT INTO public.user_addresses (user_id, label, recipient, phone, address, is_default)
+SELECT id, 'Home', COALESCE(fullname, ''), COALESCE(phone, ''), address, true
+FROM public.users
+WHERE COALESCE(address, '') <> '' AND deleted_at IS NULL;
+
+-- Shipping used to be free text. Known spellings are mapped onto the three
+-- modes, anything else was served in the store.
+UPDATE public.orders
+SET shipping = CASE
+    WHEN shipping ILIKE '%deliver%' THEN 'delivery'
+    WHEN shipping ILIKE '%pick%' OR shipping ILIKE '%take%' THEN 'pickup'
+    ELSE 'dine in'
+END;
+
+ALTER TABLE ONLY public.orders
+    ALTER COLUMN shipping SET NOT NULL,
+    ALTER COLUMN shipping TYPE character varying(20);
+
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_shipping_check CHECK (shipping IN ('dine in', 'pickup', 'delivery'));
+
+UPDATE public.tax_rules
+SET shipping = CASE
+    WHEN shipping ILIKE '%deliver%' THEN 'delivery'
+    WHEN shipping ILIKE '%pick%' OR shipping ILIKE '%take%' THEN 'pickup'
+    ELSE 'dine in'
+END
+WHERE shipping IS NOT NULL;
+
+ALTER TABLE ONLY public.tax_rules
+    ALTER COLUMN shipping TYPE character varying(20);
+
+ALTER TABLE ONLY public.tax_rules
+    ADD CONSTRAINT tax_rules_shipping_check CHECK (shipping IN ('dine in', 'pickup', 'delivery'));
+
+-- Delivery orders keep a copy of the address they were sent to.
+ALTER TABLE ONLY public.orders
+    ADD COLUMN delivery_fee bigint DEFAULT 0 NOT NULL,
+    ADD COLUMN address_id integer,
+    ADD COLUMN delivery_label character varying(50),
+    ADD COLUMN delivery_recipient character varying(255),
+    ADD COLUMN delivery_phone character varying(40),
+    ADD COLUMN delivery_address text,
+    ADD COLUMN delivery_zone character varying(100);
+
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_address_id_fkey FOREIGN KEY (address_id) REFERENCES public.user_addresses(id);
+
+UPDATE public.orders o
+SET delivery_recipient = u.fullname,
+    delivery_phone = u.phone,
+    delivery_address = u.address
+FROM public.users u
+WHERE u.id = o.user_id AND o.shipping = 'delivery';
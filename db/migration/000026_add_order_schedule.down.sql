@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS public.orders_scheduled_for_idx;
+
+ALTER TABLE ONLY public.orders
+    DROP COLUMN IF EXISTS scheduled_for;
+
+DROP TABLE IF EXISTS public.opening_hours;
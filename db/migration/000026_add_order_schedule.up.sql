@@ -0,0 +1,35 @@
+-- One row per weekday, 0 is Sunday. Times are store local time.
+CREATE TABLE public.opening_hours (
+    weekday smallint NOT NULL,
+    opens_at time without time zone NOT NULL,
+    closes_at time without time zone NOT NULL,
+    slot_minutes integer DEFAULT 30 NOT NULL,
+    slot_capacity integer DEFAULT 10 NOT NULL,
+    is_open boolean DEFAULT true NOT NULL,
+    updated_at timestamp without time zone
+);
+
+ALTER TABLE ONLY public.opening_hours
+    ADD CONSTRAINT opening_hours_pkey PRIMARY KEY (weekday);
+
+ALTER TABLE ONLY public.opening_hours
+    ADD CONSTRAINT opening_hours_weekday_check CHECK (weekday BETWEEN 0 AND 6);
+
+ALTER TABLE ONLY public.opening_hours
+    ADD CONSTRAINT opening_hours_period_check CHECK (closes_at > opens_at);
+
+ALTER TABLE ONLY public.opening_hours
+    ADD CONSTRAINT opening_hours_slot_minutes_check CHECK (slot_minutes > 0);
+
+ALTER TABLE ONLY public.opening_hours
+    ADD CONSTRAINT opening_hours_slot_capacity_check CHECK (slot_capacity >= 0);
+
+INSERT INTO public.opening_hours (weekday, opens_at, closes_at)
+SELECT d, '08:00', '22:00'
+FROM generate_series(0, 6) AS d;
+
+-- Store local time the order should be ready, NULL for orders placed for now.
+ALTER TABLE ONLY public.orders
+    ADD COLUMN scheduled_for timestamp without time zone;
+
+CREATE INDEX orders_scheduled_for_idx ON public.orders (scheduled_for) WHERE scheduled_for IS NOT NULL;
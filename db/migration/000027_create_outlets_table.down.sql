@@ -0,0 +1,28 @@
+DROP TABLE IF EXISTS public.outlet_admins;
+
+-- Only the hours of the first outlet fit the single store table again.
+DELETE FROM public.opening_hours WHERE outlet_id <> (SELECT MIN(id) FROM public.outlets);
+
+ALTER TABLE ONLY public.opening_hours
+    DROP CONSTRAINT IF EXISTS opening_hours_outlet_id_fkey,
+    DROP CONSTRAINT IF EXISTS opening_hours_pkey;
+
+ALTER TABLE ONLY public.opening_hours
+    DROP COLUMN IF EXISTS outlet_id;
+
+ALTER TABLE ONLY public.opening_hours
+    ADD CONSTRAINT opening_hours_pkey PRIMARY KEY (weekday);
+
+DROP INDEX IF EXISTS public.orders_outlet_id_idx;
+
+ALTER TABLE ONLY public.orders
+    DROP CONSTRAINT IF EXISTS orders_outlet_id_fkey,
+    DROP COLUMN IF EXISTS outlet_id;
+
+DROP INDEX IF EXISTS public.menus_outlet_id_idx;
+
+ALTER TABLE ONLY public.menus
+    DROP CONSTRAINT IF EXISTS menus_outlet_id_fkey,
+    DROP COLUMN IF EXISTS outlet_id;
+
+DROP TABLE IF EXISTS public.outlets;
@@ -0,0 +1,92 @@
+CREATE TABLE public.outlets (
+    id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    address text DEFAULT '' NOT NULL,
+    phone character varying(40) DEFAULT '' NOT NULL,
+    is_active boolean DEFAULT true NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone,
+    deleted_at timestamp without time zone
+);
+
+CREATE SEQUENCE public.outlets_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.outlets_id_seq OWNED BY public.outlets.id;
+
+ALTER TABLE ONLY public.outlets ALTER COLUMN id SET DEFAULT nextval('public.outlets_id_seq'::regclass);
+
+ALTER TABLE ONLY public.outlets
+    ADD CONSTRAINT outlets_pkey PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX outlets_name_key ON public.outlets (lower(name)) WHERE deleted_at IS NULL;
+
+-- Menus, orders and opening hours from before outlets existed all belong to
+-- the store that was running then.
+INSERT INTO public.outlets (name) VALUES ('Main Outlet');
+
+ALTER TABLE ONLY public.menus
+    ADD COLUMN outlet_id integer;
+
+UPDATE public.menus SET outlet_id = (SELECT MIN(id) FROM public.outlets);
+
+ALTER TABLE ONLY public.menus
+    ALTER COLUMN outlet_id SET NOT NULL;
+
+ALTER TABLE ONLY public.menus
+    ADD CONSTRAINT menus_outlet_id_fkey FOREIGN KEY (outlet_id) REFERENCES public.outlets(id);
+
+CREATE INDEX menus_outlet_id_idx ON public.menus (outlet_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE ONLY public.orders
+    ADD COLUMN outlet_id integer;
+
+UPDATE public.orders SET outlet_id = (SELECT MIN(id) FROM public.outlets);
+
+ALTER TABLE ONLY public.orders
+    ALTER COLUMN outlet_id SET NOT NULL;
+
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_outlet_id_fkey FOREIGN KEY (outlet_id) REFERENCES public.outlets(id);
+
+CREATE INDEX orders_outlet_id_idx ON public.orders (outlet_id);
+
+-- Every outlet keeps its own week of opening hours and slot capacity.
+ALTER TABLE ONLY public.opening_hours
+    ADD COLUMN outlet_id integer;
+
+UPDATE public.opening_hours SET outlet_id = (SELECT MIN(id) FROM public.outlets);
+
+ALTER TABLE ONLY public.opening_hours
+    ALTER COLUMN outlet_id SET NOT NULL;
+
+ALTER TABLE ONLY public.opening_hours
+    DROP CONSTRAINT opening_hours_pkey;
+
+ALTER TABLE ONLY public.opening_hours
+    ADD CONSTRAINT opening_hours_pkey PRIMARY KEY (outlet_id, weekday);
+
+ALTER TABLE ONLY public.opening_hours
+    ADD CONSTRAINT opening_hours_outlet_id_fkey FOREIGN KEY (outlet_id) REFERENCES public.outlets(id);
+
+-- Admins listed here only manage their outlets. Admins without any row
+-- manage every outlet.
+CREATE TABLE public.outlet_admins (
+    user_id integer NOT NULL,
+    outlet_id integer NOT NULL,
+    created_at timestamp without time zone DEFAULT now()
+);
+
+ALTER TABLE ONLY public.outlet_admins
+    ADD CONSTRAINT outlet_admins_pkey PRIMARY KEY (user_id, outlet_id);
+
+ALTER TABLE ONLY public.outlet_admins
+    ADD CONSTRAINT outlet_admins_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id);
+
+ALTER TABLE ONLY public.outlet_admins
+    ADD CONSTRAINT outlet_admins_outlet_id_fkey FOREIGN KEY (outlet_id) REFERENCES public.outlets(id);
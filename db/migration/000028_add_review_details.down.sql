@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS public.reviews_dt_orderid_key;
+
+ALTER TABLE ONLY public.reviews
+    DROP CONSTRAINT IF EXISTS reviews_hidden_by_fkey,
+    DROP CONSTRAINT IF EXISTS reviews_user_id_fkey,
+    DROP CONSTRAINT IF EXISTS reviews_rating_check;
+
+ALTER TABLE ONLY public.reviews
+    ALTER COLUMN rating DROP NOT NULL,
+    ALTER COLUMN dt_orderid DROP NOT NULL;
+
+ALTER TABLE ONLY public.reviews
+    DROP COLUMN IF EXISTS hidden_reason,
+    DROP COLUMN IF EXISTS hidden_by,
+    DROP COLUMN IF EXISTS hidden_at,
+    DROP COLUMN IF EXISTS photo,
+    DROP COLUMN IF EXISTS comment,
+    DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,42 @@
+ALTER TABLE ONLY public.reviews
+    ADD COLUMN user_id integer,
+    ADD COLUMN comment text DEFAULT '' NOT NULL,
+    ADD COLUMN photo character varying(255),
+    ADD COLUMN hidden_at timestamp without time zone,
+    ADD COLUMN hidden_by integer,
+    ADD COLUMN hidden_reason character varying(255);
+
+UPDATE public.reviews r
+SET user_id = o.user_id
+FROM public.dt_order d
+JOIN public.orders o ON o.id = d.order_id
+WHERE d.id = r.dt_orderid;
+
+-- Reviews from before the checks existed may be out of range or repeat an
+-- item. Ratings are clamped and only the newest review of an item is kept.
+UPDATE public.reviews SET rating = LEAST(GREATEST(COALESCE(rating, 1), 1), 5);
+
+UPDATE public.reviews r
+SET deleted_at = NOW()
+WHERE r.deleted_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM public.reviews n
+    WHERE n.dt_orderid = r.dt_orderid
+      AND n.deleted_at IS NULL
+      AND n.id > r.id
+  );
+
+ALTER TABLE ONLY public.reviews
+    ALTER COLUMN rating SET NOT NULL,
+    ALTER COLUMN dt_orderid SET NOT NULL;
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_rating_check CHECK (rating BETWEEN 1 AND 5);
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id);
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_hidden_by_fkey FOREIGN KEY (hidden_by) REFERENCES public.users(id);
+
+CREATE UNIQUE INDEX reviews_dt_orderid_key ON public.reviews (dt_orderid) WHERE deleted_at IS NULL;
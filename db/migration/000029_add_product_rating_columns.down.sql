@@ -0,0 +1,4 @@
+ALTER TABLE ONLY public.products
+    DROP COLUMN IF EXISTS review_count,
+    DROP COLUMN IF EXISTS rating_total,
+    DROP COLUMN IF EXISTS rating_avg;
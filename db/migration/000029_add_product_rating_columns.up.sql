@@ -0,0 +1,21 @@
+-- Ratings of visible reviews per product, kept up to date by the review
+-- service so listings do not aggregate the reviews table on every query.
+-- rating_total is the sum of the ratings, rating_avg follows from it.
+ALTER TABLE ONLY public.products
+    ADD COLUMN rating_avg double precision DEFAULT 0 NOT NULL,
+    ADD COLUMN rating_total integer DEFAULT 0 NOT NULL,
+    ADD COLUMN review_count integer DEFAULT 0 NOT NULL;
+
+UPDATE public.products p
+SET rating_total = s.rating_total,
+    review_count = s.review_count,
+    rating_avg = s.rating_total::double precision / s.review_count
+FROM (
+    SELECT m.product_id, SUM(r.rating) AS rating_total, COUNT(r.id) AS review_count
+    FROM public.reviews r
+    JOIN public.dt_order d ON d.id = r.dt_orderid
+    JOIN public.menus m ON m.id = d.menu_id
+    WHERE r.deleted_at IS NULL AND r.hidden_at IS NULL
+    GROUP BY m.product_id
+) s
+WHERE s.product_id = p.id;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS public.products_name_trgm_idx;
+
+DROP INDEX IF EXISTS public.products_search_vector_idx;
+
+ALTER TABLE ONLY public.products
+    DROP COLUMN IF EXISTS search_vector;
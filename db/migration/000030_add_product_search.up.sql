@@ -0,0 +1,22 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Name, category names and description of the product, weighted A, B and C.
+-- The application rebuilds it whenever one of them changes.
+ALTER TABLE ONLY public.products
+    ADD COLUMN search_vector tsvector DEFAULT ''::tsvector NOT NULL;
+
+UPDATE public.products p
+SET search_vector =
+    setweight(to_tsvector('simple', COALESCE(p.name, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE((
+        SELECT STRING_AGG(c.name, ' ')
+        FROM public.product_categories pc
+        JOIN public.categories c ON c.id = pc.category_id AND c.deleted_at IS NULL
+        WHERE pc.product_id = p.id AND pc.deleted_at IS NULL
+    ), '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(p.description, '')), 'C');
+
+CREATE INDEX products_search_vector_idx ON public.products USING gin (search_vector);
+
+-- Serves the typo tolerant name matching of search and autocomplete.
+CREATE INDEX products_name_trgm_idx ON public.products USING gin (name gin_trgm_ops);
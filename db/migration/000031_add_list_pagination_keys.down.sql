@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS public.orders_user_id_created_at_idx;
+
+DROP INDEX IF EXISTS public.orders_created_at_id_idx;
+
+ALTER TABLE ONLY public.products
+    ALTER COLUMN created_at DROP NOT NULL;
+
+ALTER TABLE ONLY public.orders
+    ALTER COLUMN created_at DROP NOT NULL;
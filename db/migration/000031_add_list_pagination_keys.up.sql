@@ -0,0 +1,14 @@
+-- Cursor pagination seeks on created_at, which therefore can no longer be
+-- NULL. Rows without one sort as the oldest.
+UPDATE public.orders SET created_at = '1970-01-01' WHERE created_at IS NULL;
+UPDATE public.products SET created_at = '1970-01-01' WHERE created_at IS NULL;
+
+ALTER TABLE ONLY public.orders
+    ALTER COLUMN created_at SET NOT NULL;
+
+ALTER TABLE ONLY public.products
+    ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX orders_created_at_id_idx ON public.orders (created_at DESC, id DESC);
+
+CREATE INDEX orders_user_id_created_at_idx ON public.orders (user_id, created_at DESC, id DESC);
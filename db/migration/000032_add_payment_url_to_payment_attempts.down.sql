@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS public.payment_attempts_order_id_created_at_idx;
+
+ALTER TABLE IF EXISTS public.payment_attempts
+    DROP COLUMN IF EXISTS payment_url;
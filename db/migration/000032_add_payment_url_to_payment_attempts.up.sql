@@ -0,0 +1,4 @@
+ALTER TABLE ONLY public.payment_attempts
+    ADD COLUMN payment_url text;
+
+CREATE INDEX payment_attempts_order_id_created_at_idx ON public.payment_attempts (order_id, created_at DESC, id DESC);
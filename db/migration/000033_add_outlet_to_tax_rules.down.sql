@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS public.tax_rules_outlet_id_idx;
+
+ALTER TABLE IF EXISTS public.tax_rules
+    DROP CONSTRAINT IF EXISTS tax_rules_outlet_id_fkey;
+
+ALTER TABLE IF EXISTS public.tax_rules
+    DROP COLUMN IF EXISTS outlet_id;
@@ -0,0 +1,9 @@
+-- Rules without an outlet apply at every outlet, so existing rules keep
+-- applying everywhere.
+ALTER TABLE ONLY public.tax_rules
+    ADD COLUMN outlet_id integer;
+
+ALTER TABLE ONLY public.tax_rules
+    ADD CONSTRAINT tax_rules_outlet_id_fkey FOREIGN KEY (outlet_id) REFERENCES public.outlets(id);
+
+CREATE INDEX tax_rules_outlet_id_idx ON public.tax_rules (outlet_id) WHERE deleted_at IS NULL;
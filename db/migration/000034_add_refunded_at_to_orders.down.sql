@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS public.orders
+    DROP COLUMN IF EXISTS refunded_at;
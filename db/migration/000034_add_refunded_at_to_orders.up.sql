@@ -0,0 +1,4 @@
+-- A refund can arrive after the kitchen started on the order, when it can no
+-- longer be cancelled, so it is tracked next to the status.
+ALTER TABLE ONLY public.orders
+    ADD COLUMN refunded_at timestamp without time zone;
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS public.users
+    DROP COLUMN IF EXISTS all_outlets;
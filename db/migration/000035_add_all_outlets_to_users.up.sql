@@ -0,0 +1,9 @@
+ALTER TABLE ONLY public.users
+    ADD COLUMN all_outlets boolean DEFAULT false NOT NULL;
+
+-- Admins without outlet assignments managed every outlet until now. They keep
+-- that access explicitly, admins created from here on start without any.
+UPDATE public.users u
+SET all_outlets = true
+WHERE u.role = 'admin'
+    AND NOT EXISTS (SELECT 1 FROM public.outlet_admins oa WHERE oa.user_id = u.id);
@@ -0,0 +1,6 @@
+-- Password resets look users up by the lowercased email. Active users may not
+-- share an email that only differs in case, so a reset never matches two
+-- accounts. The index also serves those lookups.
+CREATE UNIQUE INDEX users_email_lower_key
+    ON public.users (LOWER(email))
+    WHERE deleted_at IS NULL;
@@ -17,6 +17,8 @@ var (
 	// OTP errors
 	ErrOTPNotFound = errors.New("Invalid or expired OTP")
 	ErrOTPExpired  = errors.New("OTP has expired")
+	ErrOTPCooldown = errors.New("Please wait before requesting another OTP")
+	ErrOTPLocked   = errors.New("Too many invalid OTP attempts, please try again later")
 
 	// Password/Hash errors
 	ErrEmptyPassword       = errors.New("Password cannot be empty")
@@ -25,12 +27,15 @@ var (
 	ErrIncompatibleVersion = errors.New("Incompatible Argon2 version")
 
 	// JWT errors
-	ErrSecretNotFound     = errors.New("JWT secret not found in environment")
+	ErrKeysDirNotFound    = errors.New("JWT keys directory not found in environment")
+	ErrSigningKeyNotFound = errors.New("JWT signing key not found")
+	ErrKeyUnsupported     = errors.New("JWT key must be an RSA key of at least 2048 bits or an Ed25519 key")
 	ErrIssuerNotFound     = errors.New("JWT issuer not found in environment")
 	ErrInvalidIssuer      = errors.New("Invalid token issuer")
 	ErrTokenInvalid       = errors.New("Invalid token")
 	ErrTokenExpired       = errors.New("Token has expired")
 	ErrTokenClaimsInvalid = errors.New("Invalid token claims")
+	ErrTokenKeyUnknown    = errors.New("Token was signed with an unknown key")
 
 	// Menu errors
 	ErrMenuNotFound = errors.New("Menu not found")
@@ -38,10 +43,149 @@ var (
 	ErrUpdateMenu   = errors.New("Failed to update menu")
 	ErrDeleteMenu   = errors.New("Failed to delete menu")
 
+	// Voucher errors
+	ErrVoucherNotFound      = errors.New("Voucher not found")
+	ErrVoucherExists        = errors.New("Voucher code already exists")
+	ErrGetVoucher           = errors.New("Failed to retrieve voucher")
+	ErrCreateVoucher        = errors.New("Failed to create voucher")
+	ErrUpdateVoucher        = errors.New("Failed to update voucher")
+	ErrDeleteVoucher        = errors.New("Failed to delete voucher")
+	ErrVoucherInvalidPeriod = errors.New("Voucher end date cannot be before start date")
+	ErrVoucherNotActive     = errors.New("Voucher is not active or has expired")
+	ErrVoucherMinOrder      = errors.New("Order total does not reach the voucher minimum spend")
+	ErrVoucherUsageLimit    = errors.New("Voucher usage limit has been reached")
+
+	// Tax rule errors
+	ErrTaxRuleNotFound      = errors.New("Tax rule not found")
+	ErrGetTaxRule           = errors.New("Failed to retrieve tax rule")
+	ErrCreateTaxRule        = errors.New("Failed to create tax rule")
+	ErrUpdateTaxRule        = errors.New("Failed to update tax rule")
+	ErrDeleteTaxRule        = errors.New("Failed to delete tax rule")
+	ErrTaxRuleInvalidPeriod = errors.New("Tax rule end date cannot be before start date")
+	ErrCreateOrderTax       = errors.New("Failed to record order tax")
+	ErrGetOrderTax          = errors.New("Failed to retrieve order tax")
+
+	// Delivery zone errors
+	ErrDeliveryZoneNotFound = errors.New("Delivery zone not found")
+	ErrDeliveryZoneExists   = errors.New("Delivery zone already exists")
+	ErrDeliveryZoneInactive = errors.New("Delivery zone is not active")
+	ErrGetDeliveryZone      = errors.New("Failed to retrieve delivery zone")
+	ErrCreateDeliveryZone   = errors.New("Failed to create delivery zone")
+	ErrUpdateDeliveryZone   = errors.New("Failed to update delivery zone")
+	ErrDeleteDeliveryZone   = errors.New("Failed to delete delivery zone")
+
+	// Address errors
+	ErrAddressNotFound       = errors.New("Address not found")
+	ErrGetAddress            = errors.New("Failed to retrieve address")
+	ErrCreateAddress         = errors.New("Failed to create address")
+	ErrUpdateAddress         = errors.New("Failed to update address")
+	ErrDeleteAddress         = errors.New("Failed to delete address")
+	ErrAddressNotDeliverable = errors.New("Address is outside our delivery zones")
+
+	// Schedule errors
+	ErrOpeningHoursNotFound = errors.New("Opening hours not found")
+	ErrGetOpeningHours      = errors.New("Failed to retrieve opening hours")
+	ErrUpdateOpeningHours   = errors.New("Failed to update opening hours")
+	ErrOpeningHoursInvalid  = errors.New("Closing time must be after opening time")
+	ErrScheduleInPast       = errors.New("Scheduled time must be in the future")
+	ErrStoreClosed          = errors.New("The store is closed on that day")
+	ErrInvalidSlot          = errors.New("Scheduled time does not match a slot")
+	ErrSlotFull             = errors.New("The selected slot is full")
+	ErrGetSlots             = errors.New("Failed to retrieve slots")
+
+	// Outlet errors
+	ErrOutletNotFound     = errors.New("Outlet not found")
+	ErrOutletExists       = errors.New("Outlet already exists")
+	ErrGetOutlet          = errors.New("Failed to retrieve outlet")
+	ErrCreateOutlet       = errors.New("Failed to create outlet")
+	ErrUpdateOutlet       = errors.New("Failed to update outlet")
+	ErrDeleteOutlet       = errors.New("Failed to delete outlet")
+	ErrOutletInactive     = errors.New("Outlet is not active")
+	ErrOutletForbidden    = errors.New("Outlet is outside of your scope")
+	ErrOutletAdminInvalid = errors.New("Outlet admins must be existing admin users")
+	ErrRevokeOwnOutlets   = errors.New("You cannot revoke your own access to every outlet")
+	ErrMixedOutlets       = errors.New("All items of an order must come from the same outlet")
+
+	// Review errors
+	ErrReviewNotFound    = errors.New("Review not found")
+	ErrReviewExists      = errors.New("This item has already been reviewed")
+	ErrReviewNotAllowed  = errors.New("Only items of your completed orders can be reviewed")
+	ErrReviewForbidden   = errors.New("Review belongs to another user")
+	ErrOrderItemNotFound = errors.New("Order item not found")
+	ErrGetReview         = errors.New("Failed to retrieve review")
+	ErrCreateReview      = errors.New("Failed to create review")
+	ErrUpdateReview      = errors.New("Failed to update review")
+	ErrDeleteReview      = errors.New("Failed to delete review")
+
+	// Category errors
+	ErrCategoryNotFound = errors.New("Category not found")
+	ErrCategoryExists   = errors.New("Category already exists")
+	ErrGetCategory      = errors.New("Failed to retrieve category")
+	ErrCreateCategory   = errors.New("Failed to create category")
+	ErrUpdateCategory   = errors.New("Failed to update category")
+	ErrDeleteCategory   = errors.New("Failed to delete category")
+
+	// Product size and type errors
+	ErrProductSizeNotFound = errors.New("Product size not found")
+	ErrProductSizeExists   = errors.New("Product size already exists")
+	ErrCreateProductSize   = errors.New("Failed to create product size")
+	ErrUpdateProductSize   = errors.New("Failed to update product size")
+	ErrDeleteProductSize   = errors.New("Failed to delete product size")
+	ErrProductTypeNotFound = errors.New("Product type not found")
+	ErrProductTypeExists   = errors.New("Product type already exists")
+	ErrCreateProductType   = errors.New("Failed to create product type")
+	ErrUpdateProductType   = errors.New("Failed to update product type")
+	ErrDeleteProductType   = errors.New("Failed to delete product type")
+
+	// Product option errors
+	ErrProductNotFound         = errors.New("Product not found")
+	ErrProductSizeNotAvailable = errors.New("Product size is not available for this menu")
+	ErrProductTypeNotAvailable = errors.New("Product type is not available for this menu")
+	ErrGetProductOptions       = errors.New("Failed to retrieve product options")
+	ErrUpdateProductOptions    = errors.New("Failed to update product options")
+
+	// Cart errors
+	ErrCartEmpty        = errors.New("Cart is empty")
+	ErrCartItemNotFound = errors.New("Cart item not found")
+	ErrCartInvalidItem  = errors.New("Invalid product size or type")
+	ErrGetCart          = errors.New("Failed to retrieve cart")
+	ErrUpdateCart       = errors.New("Failed to update cart")
+
+	// Order errors
+	ErrOrderNotFound           = errors.New("Order not found")
+	ErrInvalidOrderStatus      = errors.New("Order status is not valid")
+	ErrInvalidStatusTransition = errors.New("Order cannot move to the requested status")
+	ErrUpdateOrderStatus       = errors.New("Failed to update order status")
+	ErrGetOrderHistory         = errors.New("Failed to retrieve order status history")
+	ErrRestoreStock            = errors.New("Failed to restore stock for cancelled order")
+	ErrStockInsufficient       = errors.New("Stock Insufficient, Order Can't be Done !!")
+
+	// Payment errors
+	ErrPaymentNotFound         = errors.New("Payment method not found")
+	ErrPaymentExists           = errors.New("Payment method already exists")
+	ErrGetPayment              = errors.New("Failed to retrieve payment method")
+	ErrCreatePayment           = errors.New("Failed to create payment method")
+	ErrUpdatePayment           = errors.New("Failed to update payment method")
+	ErrDeletePayment           = errors.New("Failed to delete payment method")
+	ErrPaymentInactive         = errors.New("Payment method is not active")
+	ErrPaymentProviderNotFound = errors.New("Payment provider not found")
+	ErrChargeNotFound          = errors.New("Charge not found")
+	ErrRefundNotAllowed        = errors.New("Charge cannot be refunded")
+	ErrRefundRequired          = errors.New("Order was paid online, refund it to cancel it")
+	ErrOrderNotPayable         = errors.New("Order is not waiting for payment")
+	ErrCreatePaymentAttempt    = errors.New("Failed to record payment attempt")
+	ErrInvalidSignature        = errors.New("Invalid webhook signature")
+	ErrInvalidWebhookPayload   = errors.New("Invalid webhook payload")
+	ErrProcessWebhook          = errors.New("Failed to process webhook")
+
 	// Session errors
-	ErrSessionExpired = errors.New("Session expired, please login again")
-	ErrInvalidSession = errors.New("Invalid session, please login again")
-	ErrLogoutFailed   = errors.New("Failed to logout")
+	ErrSessionExpired      = errors.New("Session expired, please login again")
+	ErrInvalidSession      = errors.New("Invalid session, please login again")
+	ErrLogoutFailed        = errors.New("Failed to logout")
+	ErrSessionNotFound     = errors.New("Session not found")
+	ErrCreateSession       = errors.New("Failed to create session")
+	ErrRefreshTokenInvalid = errors.New("Invalid refresh token, please login again")
+	ErrRefreshTokenReused  = errors.New("Refresh token was already used, every device of this session has been logged out")
 
 	// Profile errors
 	ErrUpdateProfile    = errors.New("Failed to update profile")
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var cartKey = "cart:"
+
+// CartKey is where the priced cart of the user is cached.
+func CartKey(userID int) string {
+	return fmt.Sprintf("%s:%s%d", os.Getenv("RDB_KEY"), cartKey, userID)
+}
+
+// InvalidateCarts drops every cached cart. Carts are cached with their
+// prices, discounts and taxes, so a write to menus, products, product options
+// or tax rules leaves them stale. Stock is read fresh with every cart. Failures are only logged, a cart
+// left behind expires with its TTL.
+func InvalidateCarts(ctx context.Context, rdb *redis.Client) {
+	pattern := fmt.Sprintf("%s:%s*", os.Getenv("RDB_KEY"), cartKey)
+	iter := rdb.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		if err := rdb.Del(ctx, iter.Val()).Err(); err != nil {
+			log.Printf("failed to delete cache key %s: %v", iter.Val(), err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		log.Printf("error during cache invalidation: %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	hashutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/hash"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	otpKey         = "auth:otp:"
+	otpCooldownKey = "auth:otp-cooldown:"
+	otpLockKey     = "auth:otp-lock:"
+
+	// OTPTTL is how long a forgot password code stays valid.
+	OTPTTL = 5 * time.Minute
+
+	// otpCooldown is how long a user waits before requesting another code.
+	otpCooldown = time.Minute
+
+	// otpMaxAttempts is how many codes may be tried against one OTP before
+	// the email is locked out for otpLockout.
+	otpMaxAttempts = 5
+	otpLockout     = 15 * time.Minute
+)
+
+// Keys hold a hash of the email instead of the email itself, so the
+// addresses do not show up in redis. Callers pass the normalized email.
+func otpRedisKey(prefix, email string) string {
+	return fmt.Sprintf("%s:%s%s", os.Getenv("RDB_KEY"), prefix, hashToken(email))
+}
+
+// ReserveOTP starts the cooldown for the email before a new code is sent. It
+// fails while the email is locked out or the previous code was requested
+// less than a minute ago.
+func ReserveOTP(ctx context.Context, rdb *redis.Client, email string) error {
+	locked, err := rdb.Exists(ctx, otpRedisKey(otpLockKey, email)).Result()
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrInternal
+	}
+	if locked > 0 {
+		return apperror.ErrOTPLocked
+	}
+
+	ok, err := rdb.SetNX(ctx, otpRedisKey(otpCooldownKey, email), 1, otpCooldown).Result()
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrInternal
+	}
+	if !ok {
+		return apperror.ErrOTPCooldown
+	}
+
+	return nil
+}
+
+// CreateOTP returns a new 6 digit code for the email. Only its hash is kept
+// and it replaces any code sent before, together with its failed attempts.
+func CreateOTP(ctx context.Context, rdb *redis.Client, email string) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	hash, err := hashutil.Default().Hash(code)
+	if err != nil {
+		return "", err
+	}
+
+	key := otpRedisKey(otpKey, email)
+	_, err = rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, "hash", hash, "attempts", 0)
+		pipe.Expire(ctx, key, OTPTTL)
+		return nil
+	})
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return "", apperror.ErrInternal
+	}
+
+	return code, nil
+}
+
+// VerifyOTP checks the code sent to the email and uses it up. Every try
+// counts against the code before it is compared. Once otpMaxAttempts tries
+// have failed, the code is dropped and the email is locked out.
+func VerifyOTP(ctx context.Context, rdb *redis.Client, email, code string) error {
+	lockKey := otpRedisKey(otpLockKey, email)
+	locked, err := rdb.Exists(ctx, lockKey).Result()
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrInternal
+	}
+	if locked > 0 {
+		return apperror.ErrOTPLocked
+	}
+
+	key := otpRedisKey(otpKey, email)
+	var hash string
+	var attempts int
+	err = rdb.Watch(ctx, func(tx *redis.Tx) error {
+		otp, err := tx.HGetAll(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if otp["hash"] == "" {
+			return apperror.ErrOTPNotFound
+		}
+
+		hash = otp["hash"]
+		attempts, _ = strconv.Atoi(otp["attempts"])
+		attempts++
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key, "attempts", attempts)
+			return nil
+		})
+		return err
+	}, key)
+
+	if err != nil {
+		if errors.Is(err, apperror.ErrOTPNotFound) {
+			return err
+		}
+		// Another try on the same code got in first. It is turned away
+		// rather than retried, so parallel guesses cannot skip the count.
+		if errors.Is(err, redis.TxFailedErr) {
+			return apperror.ErrOTPNotFound
+		}
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrInternal
+	}
+
+	if attempts > otpMaxAttempts {
+		return lockOTP(ctx, rdb, email)
+	}
+
+	valid, err := hashutil.Default().Verify(code, hash)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		if attempts >= otpMaxAttempts {
+			return lockOTP(ctx, rdb, email)
+		}
+		return apperror.ErrOTPNotFound
+	}
+
+	// Deleting the code decides which of two requests with the right code
+	// gets to use it.
+	deleted, err := rdb.Del(ctx, key).Result()
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrInternal
+	}
+	if deleted == 0 {
+		return apperror.ErrOTPNotFound
+	}
+
+	return nil
+}
+
+func lockOTP(ctx context.Context, rdb *redis.Client, email string) error {
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, otpRedisKey(otpKey, email))
+		pipe.Set(ctx, otpRedisKey(otpLockKey, email), 1, otpLockout)
+		return nil
+	})
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrInternal
+	}
+
+	return apperror.ErrOTPLocked
+}
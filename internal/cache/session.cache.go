@@ -0,0 +1,351 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	sessionKey      = "auth:session:"
+	userSessionsKey = "auth:user-sessions:"
+	accessKey       = "auth:access:"
+
+	// RefreshTTL is how long a session lasts without being refreshed.
+	RefreshTTL = 30 * 24 * time.Hour
+
+	// usedRefreshLimit caps the rotated refresh tokens a session remembers
+	// for reuse detection.
+	usedRefreshLimit = 20
+)
+
+// Session is one logged in device. Its refresh token rotates on every
+// refresh, only the hashes of the current and of rotated tokens are kept.
+type Session struct {
+	ID          string    `json:"id"`
+	UserID      int       `json:"user_id"`
+	Device      string    `json:"device"`
+	IP          string    `json:"ip"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	RefreshHash string    `json:"refresh_hash"`
+	UsedHashes  []string  `json:"used_hashes"`
+	AccessHash  string    `json:"access_hash"`
+}
+
+func sessionRedisKey(id string) string {
+	return fmt.Sprintf("%s:%s%s", os.Getenv("RDB_KEY"), sessionKey, id)
+}
+
+func userSessionsRedisKey(userID int) string {
+	return fmt.Sprintf("%s:%s%d", os.Getenv("RDB_KEY"), userSessionsKey, userID)
+}
+
+func accessRedisKey(hash string) string {
+	return fmt.Sprintf("%s:%s%s", os.Getenv("RDB_KEY"), accessKey, hash)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewSessionID returns a random id for a new session.
+func NewSessionID() (string, error) {
+	return randomString(16)
+}
+
+// NewRefreshToken returns a random refresh token of the session. The token
+// starts with the session id, so a refresh can find its session.
+func NewRefreshToken(sessionID string) (string, error) {
+	secret, err := randomString(32)
+	if err != nil {
+		return "", err
+	}
+	return sessionID + "." + secret, nil
+}
+
+// RefreshTokenSession returns the id of the session a refresh token was
+// issued for.
+func RefreshTokenSession(refreshToken string) (string, error) {
+	id, _, ok := strings.Cut(refreshToken, ".")
+	if !ok || id == "" {
+		return "", apperror.ErrRefreshTokenInvalid
+	}
+	return id, nil
+}
+
+func accessValue(userID int, sessionID string) string {
+	return fmt.Sprintf("%d:%s", userID, sessionID)
+}
+
+// CreateSession stores a new session with its first access and refresh
+// token.
+func CreateSession(ctx context.Context, rdb *redis.Client, s Session, accessToken, refreshToken string) error {
+	now := time.Now()
+	s.CreatedAt = now
+	s.LastUsedAt = now
+	s.ExpiresAt = now.Add(RefreshTTL)
+	s.RefreshHash = hashToken(refreshToken)
+	s.AccessHash = hashToken(accessToken)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrCreateSession
+	}
+
+	_, err = rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionRedisKey(s.ID), data, RefreshTTL)
+		pipe.Set(ctx, accessRedisKey(s.AccessHash), accessValue(s.UserID, s.ID), jwtutil.AccessTokenTTL)
+		pipe.SAdd(ctx, userSessionsRedisKey(s.UserID), s.ID)
+		pipe.Expire(ctx, userSessionsRedisKey(s.UserID), RefreshTTL)
+		return nil
+	})
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrCreateSession
+	}
+
+	return nil
+}
+
+// CheckSession makes sure the access token belongs to the live session the
+// token claims. Access tokens of revoked sessions and tokens replaced by a
+// refresh fail even before they expire.
+func CheckSession(ctx context.Context, rdb *redis.Client, userID int, sessionID, accessToken string) error {
+	value, err := rdb.Get(ctx, accessRedisKey(hashToken(accessToken))).Result()
+
+	if err == redis.Nil {
+		return apperror.ErrSessionExpired
+	}
+
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrInternal
+	}
+
+	if value != accessValue(userID, sessionID) {
+		return apperror.ErrInvalidSession
+	}
+
+	return nil
+}
+
+// GetSession returns a live session.
+func GetSession(ctx context.Context, rdb *redis.Client, sessionID string) (Session, error) {
+	data, err := rdb.Get(ctx, sessionRedisKey(sessionID)).Bytes()
+
+	if err == redis.Nil {
+		return Session{}, apperror.ErrSessionNotFound
+	}
+
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return Session{}, apperror.ErrInternal
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Println(err.Error())
+		return Session{}, apperror.ErrInternal
+	}
+
+	return s, nil
+}
+
+// RotateSession swaps the session's refresh token for newRefresh and its
+// access token for newAccess. Presenting a refresh token that was already
+// rotated means it leaked, so the whole session is revoked.
+func RotateSession(ctx context.Context, rdb *redis.Client, refreshToken, newRefresh, newAccess string) (Session, error) {
+	sessionID, err := RefreshTokenSession(refreshToken)
+	if err != nil {
+		return Session{}, err
+	}
+
+	key := sessionRedisKey(sessionID)
+	presented := hashToken(refreshToken)
+	reused := false
+
+	var rotated Session
+	err = rdb.Watch(ctx, func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return apperror.ErrRefreshTokenInvalid
+		}
+		if err != nil {
+			return err
+		}
+
+		var s Session
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+
+		if s.RefreshHash != presented {
+			for _, used := range s.UsedHashes {
+				if used == presented {
+					reused = true
+					return apperror.ErrRefreshTokenReused
+				}
+			}
+			return apperror.ErrRefreshTokenInvalid
+		}
+
+		oldAccess := s.AccessHash
+		now := time.Now()
+		s.UsedHashes = append(s.UsedHashes, s.RefreshHash)
+		if len(s.UsedHashes) > usedRefreshLimit {
+			s.UsedHashes = s.UsedHashes[len(s.UsedHashes)-usedRefreshLimit:]
+		}
+		s.RefreshHash = hashToken(newRefresh)
+		s.AccessHash = hashToken(newAccess)
+		s.LastUsedAt = now
+		s.ExpiresAt = now.Add(RefreshTTL)
+
+		data, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, RefreshTTL)
+			pipe.Del(ctx, accessRedisKey(oldAccess))
+			pipe.Set(ctx, accessRedisKey(s.AccessHash), accessValue(s.UserID, s.ID), jwtutil.AccessTokenTTL)
+			pipe.Expire(ctx, userSessionsRedisKey(s.UserID), RefreshTTL)
+			return nil
+		})
+		rotated = s
+		return err
+	}, key)
+
+	if reused {
+		if err := revokeSession(ctx, rdb, sessionID); err != nil {
+			log.Println("failed to revoke reused session:", err.Error())
+		}
+		return Session{}, apperror.ErrRefreshTokenReused
+	}
+
+	if err != nil {
+		if errors.Is(err, apperror.ErrRefreshTokenInvalid) {
+			return Session{}, err
+		}
+		// A concurrent refresh of the same token won the race, which
+		// leaves the token presented here rotated.
+		if errors.Is(err, redis.TxFailedErr) {
+			return Session{}, apperror.ErrRefreshTokenInvalid
+		}
+		log.Println("Redis error:", err.Error())
+		return Session{}, apperror.ErrInternal
+	}
+
+	return rotated, nil
+}
+
+// GetSessions lists the live sessions of the user, most recently used first.
+func GetSessions(ctx context.Context, rdb *redis.Client, userID int) ([]Session, error) {
+	ids, err := rdb.SMembers(ctx, userSessionsRedisKey(userID)).Result()
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return nil, apperror.ErrInternal
+	}
+
+	sessions := []Session{}
+	for _, id := range ids {
+		s, err := GetSession(ctx, rdb, id)
+		if errors.Is(err, apperror.ErrSessionNotFound) {
+			rdb.SRem(ctx, userSessionsRedisKey(userID), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+func revokeSession(ctx context.Context, rdb *redis.Client, sessionID string) error {
+	s, err := GetSession(ctx, rdb, sessionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionRedisKey(s.ID))
+		pipe.Del(ctx, accessRedisKey(s.AccessHash))
+		pipe.SRem(ctx, userSessionsRedisKey(s.UserID), s.ID)
+		return nil
+	})
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrLogoutFailed
+	}
+
+	return nil
+}
+
+// RevokeSession logs one of the user's sessions out. Its access token stops
+// working right away and its refresh token can no longer be used.
+func RevokeSession(ctx context.Context, rdb *redis.Client, userID int, sessionID string) error {
+	s, err := GetSession(ctx, rdb, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if s.UserID != userID {
+		return apperror.ErrSessionNotFound
+	}
+
+	return revokeSession(ctx, rdb, sessionID)
+}
+
+// RevokeSessions logs the user out of every session.
+func RevokeSessions(ctx context.Context, rdb *redis.Client, userID int) error {
+	ids, err := rdb.SMembers(ctx, userSessionsRedisKey(userID)).Result()
+	if err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrInternal
+	}
+
+	for _, id := range ids {
+		if err := revokeSession(ctx, rdb, id); err != nil && !errors.Is(err, apperror.ErrSessionNotFound) {
+			return err
+		}
+	}
+
+	if err := rdb.Del(ctx, userSessionsRedisKey(userID)).Err(); err != nil {
+		log.Println("Redis error:", err.Error())
+		return apperror.ErrLogoutFailed
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type AddressController struct {
+	addressService *service.AddressService
+}
+
+func NewAddressController(addressService *service.AddressService) *AddressController {
+	return &AddressController{addressService: addressService}
+}
+
+// GetUserAddresses godoc
+//
+//	@Summary		Get address book
+//	@Description	Get the addresses of the logged in user, default address first
+//	@Tags			Address Book
+//	@Produce		json
+//	@Success		200	{object}	[]dto.UserAddress
+//	@Failure		401	{object}	dto.ResponseError
+//	@Router			/user/addresses [get]
+//	@Security		BearerAuth
+func (ac *AddressController) GetUserAddresses(ctx *gin.Context) {
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, err := ac.addressService.GetUserAddresses(ctx, accessToken.UserID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Addresses retrieved successfully", data)
+}
+
+// CreateUserAddress godoc
+//
+//	@Summary		Add address
+//	@Description	Add an address to the address book. The first address becomes the default
+//	@Tags			Address Book
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.UserAddressRequest	true	"Address data"
+//	@Success		201		{object}	dto.UserAddress
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Router			/user/addresses [post]
+//	@Security		BearerAuth
+func (ac *AddressController) CreateUserAddress(ctx *gin.Context) {
+	var req dto.UserAddressRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		ac.bindError(ctx, err)
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, err := ac.addressService.CreateUserAddress(ctx, req, accessToken.UserID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrDeliveryZoneNotFound) || errors.Is(err, apperror.ErrDeliveryZoneInactive) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Address created successfully", data)
+}
+
+// UpdateUserAddress godoc
+//
+//	@Summary		Update address
+//	@Description	Update an address of the logged in user. Orders already placed keep the address they were sent to
+//	@Tags			Address Book
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Address ID"
+//	@Param			request	body		dto.UpdateUserAddressRequest	true	"Address data"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/user/addresses/{id} [patch]
+//	@Security		BearerAuth
+func (ac *AddressController) UpdateUserAddress(ctx *gin.Context) {
+	var param dto.UserAddressURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid address id")
+		return
+	}
+
+	var req dto.UpdateUserAddressRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		ac.bindError(ctx, err)
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := ac.addressService.UpdateUserAddress(ctx, req, accessToken.UserID, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrAddressNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) ||
+			errors.Is(err, apperror.ErrDeliveryZoneNotFound) ||
+			errors.Is(err, apperror.ErrDeliveryZoneInactive) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Address updated successfully", nil)
+}
+
+// DeleteUserAddress godoc
+//
+//	@Summary		Delete address
+//	@Description	Delete an address of the logged in user. If it was the default, the newest remaining address takes over
+//	@Tags			Address Book
+//	@Produce		json
+//	@Param			id	path		int	true	"Address ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/user/addresses/{id} [delete]
+//	@Security		BearerAuth
+func (ac *AddressController) DeleteUserAddress(ctx *gin.Context) {
+	var param dto.UserAddressURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid address id")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := ac.addressService.DeleteUserAddress(ctx, accessToken.UserID, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrAddressNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Address deleted successfully", nil)
+}
+
+func (ac *AddressController) bindError(ctx *gin.Context, err error) {
+	errStr := err.Error()
+
+	if strings.Contains(errStr, "Label") {
+		response.Error(ctx, http.StatusBadRequest, "Label is required and must be at most 50 characters")
+		return
+	}
+
+	if strings.Contains(errStr, "Recipient") {
+		response.Error(ctx, http.StatusBadRequest, "Recipient is required and must be at most 255 characters")
+		return
+	}
+
+	if strings.Contains(errStr, "Phone") {
+		response.Error(ctx, http.StatusBadRequest, "Phone must be at most 40 characters")
+		return
+	}
+
+	if strings.Contains(errStr, "Address") {
+		response.Error(ctx, http.StatusBadRequest, "Address must be at least 3 characters")
+		return
+	}
+
+	if strings.Contains(errStr, "ZoneId") {
+		response.Error(ctx, http.StatusBadRequest, "Delivery zone is required")
+		return
+	}
+
+	response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
 	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
@@ -75,15 +76,134 @@ func (ac *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
-	token, err := ac.authService.GenerateJWT(ctx, data)
+	tokens, err := ac.authService.StartSession(ctx, data, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
 		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
 
-	ac.authService.WhitelistToken(ctx, data.ID, token)
+	response.Success(ctx, http.StatusOK, "Login successful", tokens)
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh access token
+//	@Description	Trade a refresh token for a new access and refresh token. Every refresh token works once, using one again logs its session out.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.RefreshTokenRequest	true	"Refresh token"
+//	@Success		200		{object}	dto.LoginResponse
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		500		{object}	dto.ResponseError
+//	@Router			/auth/refresh [post]
+func (ac *AuthController) Refresh(ctx *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Refresh token is required")
+		return
+	}
+
+	tokens, err := ac.authService.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRefreshTokenInvalid) || errors.Is(err, apperror.ErrRefreshTokenReused) {
+			response.Error(ctx, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Token refreshed successfully", tokens)
+}
+
+// GetSessions godoc
+//
+//	@Summary		List sessions
+//	@Description	List the devices the user is logged in on, most recently used first
+//	@Tags			Auth
+//	@Produce		json
+//	@Success		200	{object}	[]dto.Session
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		500	{object}	dto.ResponseError
+//	@Router			/auth/sessions [get]
+//	@Security		BearerAuth
+func (ac *AuthController) GetSessions(ctx *gin.Context) {
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, err := ac.authService.GetSessions(ctx, accessToken.UserID, middleware.GetSessionID(ctx))
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Sessions retrieved successfully", data)
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke session
+//	@Description	Log one of your devices out. Its tokens stop working right away.
+//	@Tags			Auth
+//	@Produce		json
+//	@Param			id	path		string	true	"Session ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Failure		500	{object}	dto.ResponseError
+//	@Router			/auth/sessions/{id} [delete]
+//	@Security		BearerAuth
+func (ac *AuthController) RevokeSession(ctx *gin.Context) {
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := ac.authService.RevokeSession(ctx, accessToken.UserID, ctx.Param("id")); err != nil {
+		if errors.Is(err, apperror.ErrSessionNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
 
-	response.Success(ctx, http.StatusOK, "Login successful", dto.JWT{Token: token})
+	response.Success(ctx, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// RevokeSessions godoc
+//
+//	@Summary		Revoke all sessions
+//	@Description	Log every device out, including the one making the request
+//	@Tags			Auth
+//	@Produce		json
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		500	{object}	dto.ResponseError
+//	@Router			/auth/sessions [delete]
+//	@Security		BearerAuth
+func (ac *AuthController) RevokeSessions(ctx *gin.Context) {
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := ac.authService.RevokeSessions(ctx, accessToken.UserID); err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "All sessions revoked successfully", nil)
+}
+
+// GetJWKS godoc
+//
+//	@Summary		Get token verification keys
+//	@Description	The public keys access tokens are signed with, as a JSON Web Key Set. Other services verify a token with the key named by its kid header and can cache the set for five minutes
+//	@Tags			Auth
+//	@Produce		json
+//	@Success		200	{object}	jwt.JWKS
+//	@Router			/.well-known/jwks.json [get]
+func (ac *AuthController) GetJWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, jwtutil.PublicKeys())
 }
 
 // Register godoc
@@ -161,13 +281,14 @@ func (ac *AuthController) Register(ctx *gin.Context) {
 // ForgotPassword godoc
 //
 //	@Summary		Request OTP for forgot password
-//	@Description	Send OTP to registered email
+//	@Description	Send OTP to registered email. A new OTP can be requested once a minute and replaces the previous one.
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		dto.ForgotPasswordRequest	true	"Forgot password request"
 //	@Success		200		{object}	dto.ResponseSuccess
 //	@Failure		400		{object}	dto.ResponseError
+//	@Failure		429		{object}	dto.ResponseError
 //	@Failure		500		{object}	dto.ResponseError
 //	@Router			/auth/forgot-password [post]
 func (ac *AuthController) ForgotPassword(ctx *gin.Context) {
@@ -197,6 +318,11 @@ func (ac *AuthController) ForgotPassword(ctx *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, apperror.ErrOTPCooldown) || errors.Is(err, apperror.ErrOTPLocked) {
+			response.Error(ctx, http.StatusTooManyRequests, err.Error())
+			return
+		}
+
 		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
@@ -207,13 +333,14 @@ func (ac *AuthController) ForgotPassword(ctx *gin.Context) {
 // UpdateForgotPassword godoc
 //
 //	@Summary		Update password using OTP
-//	@Description	Verify OTP and update user password
+//	@Description	Verify the OTP sent to the email and update user password. Too many wrong codes lock the email out for a while. Every session of the user is logged out after the update.
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		dto.UpdateForgotPasswordRequest	true	"Update forgot password"
 //	@Success		200		{object}	dto.ResponseSuccess
 //	@Failure		400		{object}	dto.ResponseError
+//	@Failure		429		{object}	dto.ResponseError
 //	@Failure		500		{object}	dto.ResponseError
 //	@Router			/auth/forgot-password/update [post]
 func (ac *AuthController) UpdateForgotPassword(ctx *gin.Context) {
@@ -222,11 +349,26 @@ func (ac *AuthController) UpdateForgotPassword(ctx *gin.Context) {
 	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
 		errStr := err.Error()
 
+		if strings.Contains(errStr, "Email") && strings.Contains(errStr, "required") {
+			response.Error(ctx, http.StatusBadRequest, "Email field cannot be empty")
+			return
+		}
+
+		if strings.Contains(errStr, "Email") && strings.Contains(errStr, "email") {
+			response.Error(ctx, http.StatusBadRequest, "Email must be a valid email address")
+			return
+		}
+
 		if strings.Contains(errStr, "Otp") && strings.Contains(errStr, "required") {
 			response.Error(ctx, http.StatusBadRequest, "OTP code is required")
 			return
 		}
 
+		if strings.Contains(errStr, "Otp") {
+			response.Error(ctx, http.StatusBadRequest, "OTP code must be 6 digits")
+			return
+		}
+
 		if strings.Contains(errStr, "NewPassword") && strings.Contains(errStr, "required") {
 			response.Error(ctx, http.StatusBadRequest, "Password is required")
 			return
@@ -253,6 +395,11 @@ func (ac *AuthController) UpdateForgotPassword(ctx *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, apperror.ErrOTPLocked) {
+			response.Error(ctx, http.StatusTooManyRequests, err.Error())
+			return
+		}
+
 		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
@@ -263,7 +410,7 @@ func (ac *AuthController) UpdateForgotPassword(ctx *gin.Context) {
 // Logout godoc
 //
 //	@Summary		User logout
-//	@Description	Logout user and invalidate token
+//	@Description	End the session of the access token on this device
 //	@Tags			Auth
 //	@Produce		json
 //	@Security		BearerAuth
@@ -273,7 +420,7 @@ func (ac *AuthController) UpdateForgotPassword(ctx *gin.Context) {
 //	@Router			/auth [delete]
 //	@Security		BearerAuth
 func (ac *AuthController) Logout(ctx *gin.Context) {
-	claims, exists := ctx.Get("token")
+	jwtClaims, exists := middleware.GetClaims(ctx)
 	if !exists {
 		ctx.JSON(http.StatusUnauthorized, dto.ResponseError{
 			Status:  "error",
@@ -283,19 +430,9 @@ func (ac *AuthController) Logout(ctx *gin.Context) {
 		return
 	}
 
-	jwtClaims, ok := claims.(jwtutil.JwtClaims)
-	if !ok {
-		ctx.JSON(http.StatusInternalServerError, dto.ResponseError{
-			Status:  "error",
-			Message: "Internal Server Error",
-			Error:   "internal server error",
-		})
-		return
-	}
-
-	err := ac.authService.Logout(ctx, jwtClaims.UserID)
+	err := ac.authService.Logout(ctx, jwtClaims.UserID, middleware.GetSessionID(ctx))
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) || errors.Is(err, apperror.ErrLogoutFailed) {
+		if errors.Is(err, apperror.ErrLogoutFailed) {
 			ctx.JSON(http.StatusBadRequest, dto.ResponseError{
 				Error:   "Bad Request",
 				Message: err.Error(),
@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type CartController struct {
+	cartService *service.CartService
+}
+
+func NewCartController(cartService *service.CartService) *CartController {
+	return &CartController{cartService: cartService}
+}
+
+// GetCart godoc
+//
+//	@Summary		Get cart
+//	@Description	Get the current user's cart with live priced totals
+//	@Tags			Cart
+//	@Produce		json
+//	@Success		200	{object}	dto.Cart
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		500	{object}	dto.ResponseError
+//	@Router			/cart [get]
+//	@Security		BearerAuth
+func (cc *CartController) GetCart(ctx *gin.Context) {
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, err := cc.cartService.GetCart(ctx, accessToken.UserID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Cart retrieved successfully", data)
+}
+
+// AddItem godoc
+//
+//	@Summary		Add cart item
+//	@Description	Add a menu to the cart, merging the quantity when the same size and type is already there
+//	@Tags			Cart
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.AddCartItemRequest	true	"Cart item"
+//	@Success		201		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/cart [post]
+//	@Security		BearerAuth
+func (cc *CartController) AddItem(ctx *gin.Context) {
+	var req dto.AddCartItemRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "Qty") {
+			response.Error(ctx, http.StatusBadRequest, "Qty must be at least 1")
+			return
+		}
+
+		if strings.Contains(errStr, "required") {
+			response.Error(ctx, http.StatusBadRequest, "Menu, product size and product type are required")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := cc.cartService.AddItem(ctx, req, accessToken.UserID); err != nil {
+		if errors.Is(err, apperror.ErrMenuNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrCartInvalidItem) || errors.Is(err, apperror.ErrMixedOutlets) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrProductSizeNotFound) || errors.Is(err, apperror.ErrProductTypeNotFound) || errors.Is(err, apperror.ErrProductSizeNotAvailable) || errors.Is(err, apperror.ErrProductTypeNotAvailable) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Item added to cart", nil)
+}
+
+// UpdateItem godoc
+//
+//	@Summary		Update cart item
+//	@Description	Change the quantity of a cart item
+//	@Tags			Cart
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Cart item ID"
+//	@Param			request	body		dto.UpdateCartItemRequest	true	"New quantity"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/cart/{id} [patch]
+//	@Security		BearerAuth
+func (cc *CartController) UpdateItem(ctx *gin.Context) {
+	var param dto.CartURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid cart item id")
+		return
+	}
+
+	var req dto.UpdateCartItemRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Qty must be at least 1")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := cc.cartService.UpdateItem(ctx, req, accessToken.UserID, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrCartItemNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Cart item updated successfully", nil)
+}
+
+// RemoveItem godoc
+//
+//	@Summary		Remove cart item
+//	@Description	Remove a single item from the cart
+//	@Tags			Cart
+//	@Produce		json
+//	@Param			id	path		int	true	"Cart item ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/cart/{id} [delete]
+//	@Security		BearerAuth
+func (cc *CartController) RemoveItem(ctx *gin.Context) {
+	var param dto.CartURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid cart item id")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := cc.cartService.RemoveItem(ctx, accessToken.UserID, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrCartItemNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Cart item removed successfully", nil)
+}
+
+// ClearCart godoc
+//
+//	@Summary		Clear cart
+//	@Description	Remove every item from the cart
+//	@Tags			Cart
+//	@Produce		json
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Router			/cart [delete]
+//	@Security		BearerAuth
+func (cc *CartController) ClearCart(ctx *gin.Context) {
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := cc.cartService.ClearCart(ctx, accessToken.UserID); err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Cart cleared successfully", nil)
+}
+
+// Checkout godoc
+//
+//	@Summary		Checkout cart
+//	@Description	Turn the current cart into an order and empty the cart
+//	@Tags			Cart
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CheckoutCartRequest	true	"Checkout data"
+//	@Success		201		{object}	dto.CreateOrderResponse
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		409		{object}	dto.ResponseError
+//	@Failure		500		{object}	dto.ResponseError
+//	@Router			/cart/checkout [post]
+//	@Security		BearerAuth
+func (cc *CartController) Checkout(ctx *gin.Context) {
+	var req dto.CheckoutCartRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "Shipping") {
+			response.Error(ctx, http.StatusBadRequest, "Shipping must be dine in, pickup or delivery")
+			return
+		}
+
+		if strings.Contains(errStr, "AddressId") {
+			response.Error(ctx, http.StatusBadRequest, "Delivery orders need an address")
+			return
+		}
+
+		if strings.Contains(errStr, "Payment_Id") {
+			response.Error(ctx, http.StatusBadRequest, "Payment field cannot be empty")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, err := cc.cartService.Checkout(ctx, req, accessToken.UserID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrCartEmpty) || errors.Is(err, apperror.ErrVoucherNotFound) || errors.Is(err, apperror.ErrVoucherNotActive) || errors.Is(err, apperror.ErrVoucherMinOrder) || errors.Is(err, apperror.ErrVoucherUsageLimit) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrStockInsufficient) {
+			response.Error(ctx, http.StatusBadRequest, "Stock Insufficient !!")
+			return
+		}
+
+		if errors.Is(err, apperror.ErrMenuNotFound) || errors.Is(err, apperror.ErrProductSizeNotFound) || errors.Is(err, apperror.ErrProductTypeNotFound) || errors.Is(err, apperror.ErrProductSizeNotAvailable) || errors.Is(err, apperror.ErrProductTypeNotAvailable) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrAddressNotFound) || errors.Is(err, apperror.ErrAddressNotDeliverable) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrMixedOutlets) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrScheduleInPast) || errors.Is(err, apperror.ErrStoreClosed) || errors.Is(err, apperror.ErrInvalidSlot) || errors.Is(err, apperror.ErrOpeningHoursNotFound) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrSlotFull) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Order Created Successfully", data)
+}
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type CategoryController struct {
+	categoryService *service.CategoryService
+}
+
+func NewCategoryController(categoryService *service.CategoryService) *CategoryController {
+	return &CategoryController{categoryService: categoryService}
+}
+
+// GetAllCategories godoc
+//
+//	@Summary		Get categories
+//	@Description	Get every active category, used to build the product filter
+//	@Tags			Products
+//	@Produce		json
+//	@Success		200	{object}	[]dto.Category
+//	@Failure		500	{object}	dto.ResponseError
+//	@Router			/categories [get]
+func (cc *CategoryController) GetAllCategories(ctx *gin.Context) {
+	data, err := cc.categoryService.GetAllCategories(ctx)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Categories retrieved successfully", data)
+}
+
+// CreateCategory godoc
+//
+//	@Summary		Create category
+//	@Description	Create a new product category
+//	@Tags			Admin Category Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CategoryRequest	true	"Category data"
+//	@Success		201		{object}	dto.Category
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		409		{object}	dto.ResponseError
+//	@Router			/admin/categories [post]
+//	@Security		BearerAuth
+func (cc *CategoryController) CreateCategory(ctx *gin.Context) {
+	var req dto.CategoryRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		cc.bindError(ctx, err)
+		return
+	}
+
+	data, err := cc.categoryService.CreateCategory(ctx, req)
+	if err != nil {
+		if errors.Is(err, apperror.ErrCategoryExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Category created successfully", data)
+}
+
+// GetCategory godoc
+//
+//	@Summary		Get category by ID
+//	@Description	Get a category with the number of products linked to it
+//	@Tags			Admin Category Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Category ID"
+//	@Success		200	{object}	dto.Category
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/categories/{id} [get]
+//	@Security		BearerAuth
+func (cc *CategoryController) GetCategory(ctx *gin.Context) {
+	var param dto.CategoryURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid category id")
+		return
+	}
+
+	data, err := cc.categoryService.GetCategory(ctx, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrCategoryNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Category retrieved successfully", data)
+}
+
+// GetCategories godoc
+//
+//	@Summary		Get all categories
+//	@Description	Get categories with pagination
+//	@Tags			Admin Category Management
+//	@Produce		json
+//	@Param			page	query		string	false	"Page number"
+//	@Param			search	query		string	false	"Search by name"
+//	@Success		200		{object}	[]dto.Category
+//	@Failure		401		{object}	dto.ResponseError
+//	@Router			/admin/categories [get]
+//	@Security		BearerAuth
+func (cc *CategoryController) GetCategories(ctx *gin.Context) {
+	var req dto.CategoryParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page := 1
+	if req.Page != "" {
+		page, _ = strconv.Atoi(req.Page)
+		if page < 1 {
+			page = 1
+		}
+	}
+
+	data, totalPage, err := cc.categoryService.GetCategories(ctx, req)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var nextPage string
+	var prevPage string
+
+	if page < totalPage {
+		nextPage = fmt.Sprintf("/admin/categories?page=%d", page+1)
+	}
+	if page > 1 {
+		prevPage = fmt.Sprintf("/admin/categories?page=%d", page-1)
+	}
+
+	response.SuccessWithMeta(ctx, http.StatusOK, "Categories retrieved successfully", data,
+		dto.PaginationMeta{
+			Page:      page,
+			TotalPage: totalPage,
+			NextPage:  nextPage,
+			PrevPage:  prevPage,
+		},
+	)
+}
+
+// UpdateCategory godoc
+//
+//	@Summary		Update category
+//	@Description	Rename a category by ID
+//	@Tags			Admin Category Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Category ID"
+//	@Param			request	body		dto.CategoryRequest	true	"Category data"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Failure		409		{object}	dto.ResponseError
+//	@Router			/admin/categories/{id} [patch]
+//	@Security		BearerAuth
+func (cc *CategoryController) UpdateCategory(ctx *gin.Context) {
+	var param dto.CategoryURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid category id")
+		return
+	}
+
+	var req dto.CategoryRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		cc.bindError(ctx, err)
+		return
+	}
+
+	if err := cc.categoryService.UpdateCategory(ctx, req, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrCategoryNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrCategoryExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Category updated successfully", nil)
+}
+
+// DeleteCategory godoc
+//
+//	@Summary		Delete category
+//	@Description	Soft delete a category and detach it from its products
+//	@Tags			Admin Category Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Category ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/categories/{id} [delete]
+//	@Security		BearerAuth
+func (cc *CategoryController) DeleteCategory(ctx *gin.Context) {
+	var param dto.CategoryURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid category id")
+		return
+	}
+
+	if err := cc.categoryService.DeleteCategory(ctx, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrCategoryNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Category deleted successfully", nil)
+}
+
+func (cc *CategoryController) bindError(ctx *gin.Context, err error) {
+	errStr := err.Error()
+
+	if strings.Contains(errStr, "Name") && strings.Contains(errStr, "required") {
+		response.Error(ctx, http.StatusBadRequest, "Name field cannot be empty")
+		return
+	}
+
+	if strings.Contains(errStr, "Name") {
+		response.Error(ctx, http.StatusBadRequest, "Name must be between 2 and 255 characters")
+		return
+	}
+
+	response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+}
@@ -2,16 +2,15 @@ package controller
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
-	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 )
@@ -27,7 +26,7 @@ func NewMenuController(menuService *service.MenuService) *MenuController {
 // CreateMenu godoc
 //
 //	@Summary		Create menu
-//	@Description	Create a new menu item
+//	@Description	Create a new menu item with its stock at one outlet
 //	@Tags			Admin Menu Management
 //	@Accept			json
 //	@Produce		json
@@ -35,6 +34,7 @@ func NewMenuController(menuService *service.MenuService) *MenuController {
 //	@Success		201		{object}	dto.ResponseSuccess
 //	@Failure		400		{object}	dto.ResponseError
 //	@Failure		401		{object}	dto.ResponseError
+//	@Failure		403		{object}	dto.ResponseError
 //	@Router			/admin/menu [post]
 //	@Security		BearerAuth
 func (mc *MenuController) CreateMenu(ctx *gin.Context) {
@@ -42,6 +42,11 @@ func (mc *MenuController) CreateMenu(ctx *gin.Context) {
 	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
 		errStr := err.Error()
 
+		if strings.Contains(errStr, "OutletID") && strings.Contains(errStr, "required") {
+			response.Error(ctx, http.StatusBadRequest, "Outlet ID field cannot be empty")
+			return
+		}
+
 		if strings.Contains(errStr, "ProductID") && strings.Contains(errStr, "required") {
 			response.Error(ctx, http.StatusBadRequest, "Product ID field cannot be empty")
 			return
@@ -61,22 +66,16 @@ func (mc *MenuController) CreateMenu(ctx *gin.Context) {
 		return
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
+	scope := middleware.GetOutletScope(ctx)
 
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
+	if err := mc.menuService.CreateMenu(ctx, req, scope); err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
 
-	if err := mc.menuService.CreateMenu(ctx, req, accessToken.UserID, token[1]); err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
 			return
 		}
 
@@ -96,6 +95,7 @@ func (mc *MenuController) CreateMenu(ctx *gin.Context) {
 //	@Param			id	path		int	true	"Menu ID"
 //	@Success		200	{object}	dto.ResponseSuccess
 //	@Failure		401	{object}	dto.ResponseError
+//	@Failure		403	{object}	dto.ResponseError
 //	@Failure		404	{object}	dto.ResponseError
 //	@Router			/admin/menu/{id} [get]
 //	@Security		BearerAuth
@@ -106,23 +106,17 @@ func (mc *MenuController) GetMenu(ctx *gin.Context) {
 		return
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
+	scope := middleware.GetOutletScope(ctx)
 
-	data, err := mc.menuService.GetMenu(ctx, accessToken.UserID, param.ID, token[1])
+	data, err := mc.menuService.GetMenu(ctx, scope, param.ID)
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrMenuNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
 			return
 		}
 		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
@@ -135,12 +129,18 @@ func (mc *MenuController) GetMenu(ctx *gin.Context) {
 // GetMenus godoc
 //
 //	@Summary		Get all menus
-//	@Description	Get all menu items with pagination
+//	@Description	Get the menu items of the outlets you manage with pagination
 //	@Tags			Admin Menu Management
 //	@Produce		json
-//	@Param			page	query		string	false	"Page number"
-//	@Success		200		{object}	dto.ResponseSuccess
-//	@Failure		401		{object}	dto.ResponseError
+//	@Param			page		query		string	false	"Page number"
+//	@Param			limit		query		int		false	"Menus per page, 5 by default"	minimum(1)	maximum(50)
+//	@Param			cursor		query		string	false	"next_cursor of the previous page, takes precedence over page"
+//	@Param			search		query		string	false	"Search by product name"
+//	@Param			outlet_id	query		int		false	"Outlet ID"
+//	@Success		200			{object}	dto.ResponseSuccess
+//	@Failure		400			{object}	dto.ResponseError
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
 //	@Router			/admin/menu [get]
 //	@Security		BearerAuth
 func (mc *MenuController) GetMenus(ctx *gin.Context) {
@@ -150,31 +150,23 @@ func (mc *MenuController) GetMenus(ctx *gin.Context) {
 		return
 	}
 
-	page := 1
-	if req.Page != "" {
-		page, _ = strconv.Atoi(req.Page)
-		if page < 1 {
-			page = 1
-		}
-	}
-
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
+	page, err := pagination.New(req.Page, req.Limit, req.Cursor, 5)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
+	scope := middleware.GetOutletScope(ctx)
 
-	data, totalPage, err := mc.menuService.GetMenus(ctx, req, accessToken.UserID, 0, token[1])
+	data, result, err := mc.menuService.GetMenus(ctx, req, page, scope, 0)
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
 			return
 		}
 
@@ -182,23 +174,8 @@ func (mc *MenuController) GetMenus(ctx *gin.Context) {
 		return
 	}
 
-	var nextPage string
-	var prevPage string
-
-	if page < totalPage {
-		nextPage = fmt.Sprintf("/menu?page=%d", page+1)
-	}
-	if page > 1 {
-		prevPage = fmt.Sprintf("/menu?page=%d", page-1)
-	}
-
 	response.SuccessWithMeta(ctx, http.StatusOK, "Menus retrieved successfully", data,
-		dto.PaginationMeta{
-			Page:      page,
-			TotalPage: totalPage,
-			NextPage:  nextPage,
-			PrevPage:  prevPage,
-		},
+		dto.NewPaginationMeta("/admin/menu", ctx.Request.URL.Query(), page, result),
 	)
 }
 
@@ -214,6 +191,8 @@ func (mc *MenuController) GetMenus(ctx *gin.Context) {
 //	@Success		200		{object}	dto.ResponseSuccess
 //	@Failure		400		{object}	dto.ResponseError
 //	@Failure		401		{object}	dto.ResponseError
+//	@Failure		403		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
 //	@Router			/admin/menu/{id} [patch]
 //	@Security		BearerAuth
 func (mc *MenuController) UpdateMenu(ctx *gin.Context) {
@@ -241,26 +220,20 @@ func (mc *MenuController) UpdateMenu(ctx *gin.Context) {
 		return
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
+	scope := middleware.GetOutletScope(ctx)
 
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
+	if err := mc.menuService.UpdateMenu(ctx, req, scope, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
 
-	if err := mc.menuService.UpdateMenu(ctx, req, accessToken.UserID, param.ID, token[1]); err != nil {
-		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
-			if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-				response.Error(ctx, http.StatusUnauthorized, err.Error())
-				return
-			}
+		if errors.Is(err, apperror.ErrMenuNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
 
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
 			response.Error(ctx, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -281,6 +254,8 @@ func (mc *MenuController) UpdateMenu(ctx *gin.Context) {
 //	@Param			id	path		int	true	"Menu ID"
 //	@Success		200	{object}	dto.ResponseSuccess
 //	@Failure		401	{object}	dto.ResponseError
+//	@Failure		403	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
 //	@Router			/admin/menu/{id} [delete]
 //	@Security		BearerAuth
 func (mc *MenuController) DeleteMenu(ctx *gin.Context) {
@@ -290,22 +265,16 @@ func (mc *MenuController) DeleteMenu(ctx *gin.Context) {
 		return
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
+	scope := middleware.GetOutletScope(ctx)
 
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
+	if err := mc.menuService.DeleteMenu(ctx, scope, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
 
-	if err := mc.menuService.DeleteMenu(ctx, accessToken.UserID, param.ID, token[1]); err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, apperror.ErrMenuNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
 			return
 		}
 
@@ -2,7 +2,6 @@ package controller
 
 import (
 	"errors"
-	"fmt"
 	"log"
 	"net/http"
 	"strconv"
@@ -10,9 +9,10 @@ import (
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
-	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/gin-gonic/gin"
 )
 
@@ -38,23 +38,22 @@ func NewOrdersController(orderService *service.OrderService) *OrdersController {
 //	@Failure	404		{object}	dto.ResponseError
 //	@Failure	400		{object}	dto.ResponseError
 //	@Failure	401		{object}	dto.ResponseError
+//	@Failure	409		{object}	dto.ResponseError
 //	@Router		/orders [post]
 //	@Security	BearerAuth
 func (o OrdersController) CreateOrder(c *gin.Context) {
 
 	var createOrder dto.CreateOrder
 
-	token, isExist := c.Get("token")
+	accessToken, isExist := middleware.GetClaims(c)
 	if !isExist {
 		response.Error(c, http.StatusForbidden, "Forbidden Access")
 		return
 	}
 
-	accessToken, _ := token.(jwtutil.JwtClaims)
-
 	if err := c.ShouldBindJSON(&createOrder); err != nil {
 		log.Println(err.Error())
-		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		response.Error(c, http.StatusBadRequest, "Invalid Body")
 		return
 	}
 
@@ -64,14 +63,34 @@ func (o OrdersController) CreateOrder(c *gin.Context) {
 
 	if err != nil {
 		str := err.Error()
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, apperror.ErrVoucherNotFound) || errors.Is(err, apperror.ErrVoucherNotActive) || errors.Is(err, apperror.ErrVoucherMinOrder) || errors.Is(err, apperror.ErrVoucherUsageLimit) {
+			response.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		if err.Error() == "Stock Insufficient, Order Can't be Done !!" {
+		if errors.Is(err, apperror.ErrStockInsufficient) {
 			response.Error(c, http.StatusBadRequest, "Stock Insufficient !!")
 			return
 		}
+		if errors.Is(err, apperror.ErrMenuNotFound) || errors.Is(err, apperror.ErrProductSizeNotFound) || errors.Is(err, apperror.ErrProductTypeNotFound) || errors.Is(err, apperror.ErrProductSizeNotAvailable) || errors.Is(err, apperror.ErrProductTypeNotAvailable) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrAddressNotFound) || errors.Is(err, apperror.ErrAddressNotDeliverable) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrMixedOutlets) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrScheduleInPast) || errors.Is(err, apperror.ErrStoreClosed) || errors.Is(err, apperror.ErrInvalidSlot) || errors.Is(err, apperror.ErrOpeningHoursNotFound) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrSlotFull) {
+			response.Error(c, http.StatusConflict, err.Error())
+			return
+		}
 		if strings.Contains(str, "empty") {
 			response.Error(c, http.StatusBadRequest, "Invalid Body")
 			return
@@ -84,6 +103,80 @@ func (o OrdersController) CreateOrder(c *gin.Context) {
 	})
 }
 
+// QuoteOrder godoc
+//
+//	@Summary		Quote order
+//	@Description	Price an order without placing it. Returns the unit price, surcharges and discount of every line with the voucher discount, itemized taxes, delivery fee and total that creating the order would charge
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.OrderQuoteRequest	true	"Order lines"
+//	@Success		200		{object}	dto.OrderQuote
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		500		{object}	dto.ResponseError
+//	@Router			/orders/quote [post]
+//	@Security		BearerAuth
+func (o OrdersController) QuoteOrder(c *gin.Context) {
+	var req dto.OrderQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "Shipping") {
+			response.Error(c, http.StatusBadRequest, "Shipping must be dine in, pickup or delivery")
+			return
+		}
+
+		if strings.Contains(errStr, "AddressId") {
+			response.Error(c, http.StatusBadRequest, "Delivery orders need an address")
+			return
+		}
+
+		if strings.Contains(errStr, "Qty") {
+			response.Error(c, http.StatusBadRequest, "Qty must be greater than 0")
+			return
+		}
+
+		if strings.Contains(errStr, "Menus") || strings.Contains(errStr, "Id") {
+			response.Error(c, http.StatusBadRequest, "Every line needs a menu, size and type")
+			return
+		}
+
+		response.Error(c, http.StatusBadRequest, "Invalid Body")
+		return
+	}
+
+	accessToken, isExist := middleware.GetClaims(c)
+	if !isExist {
+		response.Error(c, http.StatusForbidden, "Forbidden Access")
+		return
+	}
+
+	data, err := o.orderService.QuoteOrder(c.Request.Context(), req, accessToken.UserID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrVoucherNotFound) || errors.Is(err, apperror.ErrVoucherNotActive) || errors.Is(err, apperror.ErrVoucherMinOrder) || errors.Is(err, apperror.ErrVoucherUsageLimit) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrMenuNotFound) || errors.Is(err, apperror.ErrProductSizeNotFound) || errors.Is(err, apperror.ErrProductTypeNotFound) || errors.Is(err, apperror.ErrProductSizeNotAvailable) || errors.Is(err, apperror.ErrProductTypeNotAvailable) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrAddressNotFound) || errors.Is(err, apperror.ErrAddressNotDeliverable) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrMixedOutlets) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Order quoted successfully", data)
+}
+
 // UpdateProduct godoc
 //
 //	@Summary	Update status
@@ -94,7 +187,9 @@ func (o OrdersController) CreateOrder(c *gin.Context) {
 //	@Success	200		{object}	dto.ResponseSuccess
 //	@Failure	401		{object}	dto.ResponseError
 //	@Failure	400		{object}	dto.ResponseError
+//	@Failure	403		{object}	dto.ResponseError
 //	@Failure	404		{object}	dto.ResponseError
+//	@Failure	409		{object}	dto.ResponseError
 //	@Failure	422		{object}	dto.ResponseError
 //	@Failure	500		{object}	dto.ResponseError
 //	@Router		/admin/orders [patch]
@@ -102,33 +197,39 @@ func (o OrdersController) CreateOrder(c *gin.Context) {
 func (o OrdersController) UpdateStatusOrder(c *gin.Context) {
 	var updtStatus dto.UpdateStatusOrder
 
-	_, isExist := c.Get("token")
+	accessToken, isExist := middleware.GetClaims(c)
 	if !isExist {
 		response.Error(c, http.StatusForbidden, "Forbidden Access")
 		return
 	}
 
+	scope := middleware.GetOutletScope(c)
+
 	if err := c.ShouldBindJSON(&updtStatus); err != nil {
 		log.Println(err.Error())
 		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
-	if err := o.orderService.UpdateStatusByOrderId(c.Request.Context(), updtStatus); err != nil {
+	if err := o.orderService.UpdateStatusByOrderId(c.Request.Context(), updtStatus, scope, accessToken.UserID); err != nil {
 		str := err.Error()
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(c, http.StatusForbidden, err.Error())
 			return
 		}
-		if str == "status is not valid" {
+		if errors.Is(err, apperror.ErrInvalidOrderStatus) {
 			response.Error(c, http.StatusUnprocessableEntity, "Status Is Not Appropriate")
 			return
 		}
+		if errors.Is(err, apperror.ErrInvalidStatusTransition) || errors.Is(err, apperror.ErrRefundRequired) {
+			response.Error(c, http.StatusConflict, err.Error())
+			return
+		}
 		if strings.Contains(str, "empty") {
 			response.Error(c, http.StatusBadRequest, "Invalid Body")
 			return
 		}
-		if err.Error() == "no rows in result set" || err.Error() == "no data deleted" {
+		if errors.Is(err, apperror.ErrOrderNotFound) {
 			response.Error(c, http.StatusNotFound, "Data Not Found")
 			return
 		}
@@ -138,79 +239,22 @@ func (o OrdersController) UpdateStatusOrder(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Status Order Updated Successfully", nil)
 }
 
-// AddReview godoc
-//
-//	@Summary	Add review to order
-//	@Tags		Orders
-//	@Accept		json
-//	@Produce	json
-//	@Param		request	body		dto.AddReview	true	"Add review"
-//	@Success	200		{object}	dto.ResponseSuccess
-//	@Failure	400		{object}	dto.ResponseError
-//	@Failure	401		{object}	dto.ResponseError
-//	@Failure	403		{object}	dto.ResponseError
-//	@Failure	500		{object}	dto.ResponseError
-//	@Router		/orders/review [post]
-//	@Security	BearerAuth
-func (o OrdersController) AddReview(ctx *gin.Context) {
-	var req dto.AddReview
-
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		errStr := err.Error()
-
-		if strings.Contains(errStr, "DtOrderId") && strings.Contains(errStr, "required") {
-			response.Error(ctx, http.StatusBadRequest, "Detail Order ID is required")
-			return
-		}
-
-		if strings.Contains(errStr, "Rating") && strings.Contains(errStr, "required") {
-			response.Error(ctx, http.StatusBadRequest, "Rating is required")
-			return
-		}
-
-		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
-		return
-	}
-
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
-
-	if err := o.orderService.AddReview(ctx.Request.Context(), req, accessToken.UserID, token[1]); err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		if err.Error() == "no rows in result set" {
-			response.Error(ctx, http.StatusNotFound, "Order Not Found")
-			return
-		}
-		response.Error(ctx, http.StatusInternalServerError, "Internal Server Error")
-		return
-	}
-
-	response.Success(ctx, http.StatusOK, "Review Added Successfully", nil)
-}
-
 // Get Order godoc
 //
 //	@Summary	Get all order
 //	@Tags		Admin Order Management
 //	@Produce	json
 //	@Param		page	query		string	false	"Page Start"
+//	@Param		limit	query		int	false	"Orders per page, 5 by default"	minimum(1)	maximum(50)
+//	@Param		cursor	query		string	false	"next_cursor of the previous page, takes precedence over page"
 //	@Param		status	query		string	false	"Status"
 //	@Param		order_id	query		string	false	"Order Id"
+//	@Param		sort	query		string	false	"latest (default) or scheduled"
+//	@Param		outlet_id	query		int	false	"Outlet ID"
 //	@Success	200		{object}	[]dto.ProductType
+//	@Failure	400		{object}	dto.ResponseError
 //	@Failure	401		{object}	dto.ResponseError
+//	@Failure	403		{object}	dto.ResponseError
 //	@Failure	500		{object}	dto.ResponseError
 //	@Router		/admin/orders [get]
 //	@Security	BearerAuth
@@ -218,42 +262,56 @@ func (o *OrdersController) GetAllOrderByAdmin(c *gin.Context) {
 	pageParam := c.Query("page")
 	status := c.Query("status")
 	orderId := c.Query("order_id")
+	sort := c.Query("sort")
+	if sort != "" && sort != "latest" && sort != "scheduled" {
+		response.Error(c, http.StatusBadRequest, "Sort must be latest or scheduled")
+		return
+	}
 
-	page := 1
-	if pageParam != "" {
-		page, _ = strconv.Atoi(pageParam)
-		if page < 1 {
-			page = 1
+	outletId := 0
+	if outletParam := c.Query("outlet_id"); outletParam != "" {
+		var err error
+		outletId, err = strconv.Atoi(outletParam)
+		if err != nil || outletId < 1 {
+			response.Error(c, http.StatusBadRequest, "Invalid outlet id")
+			return
 		}
 	}
 
-	data, totalPage, err := o.orderService.GetAllOrderByAdmin(c.Request.Context(), orderId, status, page)
-	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
+	limit := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, pagination.ErrInvalidLimit.Error())
 			return
 		}
-		response.Error(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+
+	page, err := pagination.New(pageParam, limit, c.Query("cursor"), 5)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	var nextPage string
-	var prevPage string
+	scope := middleware.GetOutletScope(c)
 
-	if page < totalPage {
-		nextPage = fmt.Sprintf("/admin/orders?page=%d", page+1)
-	}
-	if page > 1 {
-		prevPage = fmt.Sprintf("/admin/orders?page=%d", page-1)
+	data, result, err := o.orderService.GetAllOrderByAdmin(c.Request.Context(), orderId, status, sort, page, outletId, scope)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
 	}
 
 	response.SuccessWithMeta(c, http.StatusOK, "Orders data Retrieved Successfully", data,
-		dto.PaginationMeta{
-			Page:      page,
-			TotalPage: totalPage,
-			NextPage:  nextPage,
-			PrevPage:  prevPage,
-		},
+		dto.NewPaginationMeta("/admin/orders", c.Request.URL.Query(), page, result),
 	)
 }
 
@@ -262,8 +320,11 @@ func (o *OrdersController) GetAllOrderByAdmin(c *gin.Context) {
 //	@Summary	Get all history
 //	@Tags		Orders
 //	@Produce	json
-//	@Param		page	query		string	true	"Page Start"
+//	@Param		page	query		string	false	"Page Start"
+//	@Param		limit	query		int		false	"Orders per page, 5 by default"	minimum(1)	maximum(50)
+//	@Param		cursor	query		string	false	"next_cursor of the previous page, takes precedence over page"
 //	@Success	200		{object}	[]dto.History
+//	@Failure	400		{object}	dto.ResponseError
 //	@Failure	401		{object}	dto.ResponseError
 //	@Failure	500		{object}	dto.ResponseError
 //	@Router		/orders/history [get]
@@ -271,14 +332,12 @@ func (o *OrdersController) GetAllOrderByAdmin(c *gin.Context) {
 func (o *OrdersController) GetHistoryByUser(c *gin.Context) {
 	var req dto.HistoryQueries
 
-	token, isExist := c.Get("token")
+	accessToken, isExist := middleware.GetClaims(c)
 	if !isExist {
 		response.Error(c, http.StatusForbidden, "Forbidden Access")
 		return
 	}
 
-	accessToken, _ := token.(jwtutil.JwtClaims)
-
 	if err := c.ShouldBindQuery(&req); err != nil {
 		response.Error(c, http.StatusBadRequest, "Invalid query parameters")
 		return
@@ -286,41 +345,24 @@ func (o *OrdersController) GetHistoryByUser(c *gin.Context) {
 
 	userId := accessToken.UserID
 
-	page := 1
-	if req.Page != "" {
-		page, _ = strconv.Atoi(req.Page)
-		if page < 1 {
-			page = 1
-		}
+	page, err := pagination.New(req.Page, req.Limit, req.Cursor, 5)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	data, totalPage, err := o.orderService.GetHistoryByUser(c, page, userId)
+	data, result, err := o.orderService.GetHistoryByUser(c, page, userId)
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			response.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
 		response.Error(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
 
-	var nextPage string
-	var prevPage string
-
-	if page < totalPage {
-		nextPage = fmt.Sprintf("/history?page=%d", page+1)
-	}
-	if page > 1 {
-		prevPage = fmt.Sprintf("/history?page=%d", page-1)
-	}
-
 	response.SuccessWithMeta(c, http.StatusOK, "History data Retrieved Successfully", data,
-		dto.PaginationMeta{
-			Page:      page,
-			TotalPage: totalPage,
-			NextPage:  nextPage,
-			PrevPage:  prevPage,
-		},
+		dto.NewPaginationMeta("/orders/history", c.Request.URL.Query(), page, result),
 	)
 }
 
@@ -341,14 +383,23 @@ func (o *OrdersController) GetHistoryByUser(c *gin.Context) {
 // @security BearerAuth
 func (o OrdersController) GetDetailHistoryById(c *gin.Context) {
 	id := c.Param("id")
-	data, err := o.orderService.GetDetailHistoryById(c.Request.Context(), id)
+
+	accessToken, isExist := middleware.GetClaims(c)
+	if !isExist {
+		response.Error(c, http.StatusForbidden, "Forbidden Access")
+		return
+	}
+
+	// Users see their own orders, admins the orders of their outlets.
+	userID := accessToken.UserID
+	if accessToken.Role == "admin" {
+		userID = 0
+	}
+
+	data, err := o.orderService.GetDetailHistoryById(c.Request.Context(), id, userID, middleware.GetOutletScope(c))
 
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
-			return
-		}
-		if err.Error() == "no rows in result set" {
+		if errors.Is(err, apperror.ErrOrderNotFound) {
 			response.Error(c, http.StatusNotFound, "Data Not Found")
 			return
 		}
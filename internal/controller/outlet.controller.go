@@ -0,0 +1,496 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type OutletController struct {
+	outletService *service.OutletService
+}
+
+func NewOutletController(outletService *service.OutletService) *OutletController {
+	return &OutletController{outletService: outletService}
+}
+
+// GetActiveOutlets godoc
+//
+//	@Summary		Get outlets
+//	@Description	Get the outlets customers can order from. Pass an outlet id to /products to see its menu
+//	@Tags			Outlets
+//	@Produce		json
+//	@Success		200	{object}	[]dto.Outlet
+//	@Failure		500	{object}	dto.ResponseError
+//	@Router			/outlets [get]
+func (oc *OutletController) GetActiveOutlets(ctx *gin.Context) {
+	data, err := oc.outletService.GetActiveOutlets(ctx)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Outlets retrieved successfully", data)
+}
+
+// CreateOutlet godoc
+//
+//	@Summary		Create outlet
+//	@Description	Create an outlet with the default opening hours. Only admins who manage every outlet can create outlets
+//	@Tags			Admin Outlet Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.OutletRequest	true	"Outlet data"
+//	@Success		201		{object}	dto.Outlet
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		403		{object}	dto.ResponseError
+//	@Failure		409		{object}	dto.ResponseError
+//	@Router			/admin/outlets [post]
+//	@Security		BearerAuth
+func (oc *OutletController) CreateOutlet(ctx *gin.Context) {
+	var req dto.OutletRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		oc.bindError(ctx, err)
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := oc.outletService.CreateOutlet(ctx, req, scope)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Outlet created successfully", data)
+}
+
+// GetOutlet godoc
+//
+//	@Summary		Get outlet by ID
+//	@Description	Get outlet details by ID
+//	@Tags			Admin Outlet Management
+//	@Produce		json
+//	@Param			outlet_id	path		int	true	"Outlet ID"
+//	@Success		200			{object}	dto.Outlet
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Router			/admin/outlets/{outlet_id} [get]
+//	@Security		BearerAuth
+func (oc *OutletController) GetOutlet(ctx *gin.Context) {
+	var param dto.OutletURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid outlet id")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := oc.outletService.GetOutlet(ctx, scope, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Outlet retrieved successfully", data)
+}
+
+// GetOutlets godoc
+//
+//	@Summary		Get all outlets
+//	@Description	Get the outlets you manage with pagination, including inactive ones
+//	@Tags			Admin Outlet Management
+//	@Produce		json
+//	@Param			page	query		string	false	"Page number"
+//	@Param			search	query		string	false	"Search by name"
+//	@Success		200		{object}	[]dto.Outlet
+//	@Failure		401		{object}	dto.ResponseError
+//	@Router			/admin/outlets [get]
+//	@Security		BearerAuth
+func (oc *OutletController) GetOutlets(ctx *gin.Context) {
+	var req dto.OutletParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page := 1
+	if req.Page != "" {
+		page, _ = strconv.Atoi(req.Page)
+		if page < 1 {
+			page = 1
+		}
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, totalPage, err := oc.outletService.GetOutlets(ctx, req, scope)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var nextPage string
+	var prevPage string
+
+	if page < totalPage {
+		nextPage = fmt.Sprintf("/admin/outlets?page=%d", page+1)
+	}
+	if page > 1 {
+		prevPage = fmt.Sprintf("/admin/outlets?page=%d", page-1)
+	}
+
+	response.SuccessWithMeta(ctx, http.StatusOK, "Outlets retrieved successfully", data,
+		dto.PaginationMeta{
+			Page:      page,
+			TotalPage: totalPage,
+			NextPage:  nextPage,
+			PrevPage:  prevPage,
+		},
+	)
+}
+
+// UpdateOutlet godoc
+//
+//	@Summary		Update outlet
+//	@Description	Update an outlet you manage. Products of inactive outlets are hidden from /products
+//	@Tags			Admin Outlet Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			outlet_id	path		int						true	"Outlet ID"
+//	@Param			request		body		dto.UpdateOutletRequest	true	"Outlet data"
+//	@Success		200			{object}	dto.ResponseSuccess
+//	@Failure		400			{object}	dto.ResponseError
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Failure		409			{object}	dto.ResponseError
+//	@Router			/admin/outlets/{outlet_id} [patch]
+//	@Security		BearerAuth
+func (oc *OutletController) UpdateOutlet(ctx *gin.Context) {
+	var param dto.OutletURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid outlet id")
+		return
+	}
+
+	var req dto.UpdateOutletRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		oc.bindError(ctx, err)
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	if err := oc.outletService.UpdateOutlet(ctx, req, scope, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Outlet updated successfully", nil)
+}
+
+// DeleteOutlet godoc
+//
+//	@Summary		Delete outlet
+//	@Description	Delete an outlet together with its menus. Its orders stay in the order history. Only admins who manage every outlet can delete outlets
+//	@Tags			Admin Outlet Management
+//	@Produce		json
+//	@Param			outlet_id	path		int	true	"Outlet ID"
+//	@Success		200			{object}	dto.ResponseSuccess
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Router			/admin/outlets/{outlet_id} [delete]
+//	@Security		BearerAuth
+func (oc *OutletController) DeleteOutlet(ctx *gin.Context) {
+	var param dto.OutletURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid outlet id")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	if err := oc.outletService.DeleteOutlet(ctx, scope, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Outlet deleted successfully", nil)
+}
+
+// GetOutletAdmins godoc
+//
+//	@Summary		Get outlet admins
+//	@Description	Get the admins assigned to an outlet. Admins granted every outlet with users.all_outlets are not listed
+//	@Tags			Admin Outlet Management
+//	@Produce		json
+//	@Param			outlet_id	path		int	true	"Outlet ID"
+//	@Success		200			{object}	[]dto.OutletAdmin
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Router			/admin/outlets/{outlet_id}/admins [get]
+//	@Security		BearerAuth
+func (oc *OutletController) GetOutletAdmins(ctx *gin.Context) {
+	var param dto.OutletURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid outlet id")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := oc.outletService.GetOutletAdmins(ctx, scope, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Outlet admins retrieved successfully", data)
+}
+
+// SetOutletAdmins godoc
+//
+//	@Summary		Set outlet admins
+//	@Description	Replace the admins assigned to an outlet. Assigned admins only manage the menus, stock, orders and opening hours of their outlets. Only admins who manage every outlet can assign outlets
+//	@Tags			Admin Outlet Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			outlet_id	path		int						true	"Outlet ID"
+//	@Param			request		body		dto.OutletAdminsRequest	true	"Admin user ids"
+//	@Success		200			{object}	[]dto.OutletAdmin
+//	@Failure		400			{object}	dto.ResponseError
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Router			/admin/outlets/{outlet_id}/admins [put]
+//	@Security		BearerAuth
+func (oc *OutletController) SetOutletAdmins(ctx *gin.Context) {
+	var param dto.OutletURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid outlet id")
+		return
+	}
+
+	var req dto.OutletAdminsRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "User ids field cannot be empty")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := oc.outletService.SetOutletAdmins(ctx, req, scope, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletAdminInvalid) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Outlet admins updated successfully", data)
+}
+
+// GetGlobalAdmins godoc
+//
+//	@Summary		Get global admins
+//	@Description	List the admins granted every outlet. Only admins who manage every outlet can see them
+//	@Tags			Admin Outlet Management
+//	@Produce		json
+//	@Success		200	{object}	[]dto.OutletAdmin
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		403	{object}	dto.ResponseError
+//	@Router			/admin/outlets/global-admins [get]
+//	@Security		BearerAuth
+func (oc *OutletController) GetGlobalAdmins(ctx *gin.Context) {
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := oc.outletService.GetGlobalAdmins(ctx, scope)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Global admins retrieved successfully", data)
+}
+
+// GrantGlobalAdmin godoc
+//
+//	@Summary		Grant every outlet
+//	@Description	Grant an admin access to every outlet, including adding outlets and assigning admins. Only admins who manage every outlet can grant it
+//	@Tags			Admin Outlet Management
+//	@Produce		json
+//	@Param			user_id	path		int	true	"Admin user ID"
+//	@Success		200		{object}	[]dto.OutletAdmin
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		403		{object}	dto.ResponseError
+//	@Router			/admin/outlets/global-admins/{user_id} [put]
+//	@Security		BearerAuth
+func (oc *OutletController) GrantGlobalAdmin(ctx *gin.Context) {
+	oc.setGlobalAdmin(ctx, true, "Global admin granted successfully")
+}
+
+// RevokeGlobalAdmin godoc
+//
+//	@Summary		Revoke every outlet
+//	@Description	Revoke an admin's access to every outlet. The admin keeps the outlets assigned to them. Admins cannot revoke their own access
+//	@Tags			Admin Outlet Management
+//	@Produce		json
+//	@Param			user_id	path		int	true	"Admin user ID"
+//	@Success		200		{object}	[]dto.OutletAdmin
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		403		{object}	dto.ResponseError
+//	@Router			/admin/outlets/global-admins/{user_id} [delete]
+//	@Security		BearerAuth
+func (oc *OutletController) RevokeGlobalAdmin(ctx *gin.Context) {
+	oc.setGlobalAdmin(ctx, false, "Global admin revoked successfully")
+}
+
+func (oc *OutletController) setGlobalAdmin(ctx *gin.Context, all bool, message string) {
+	var param dto.GlobalAdminURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	accessToken, isExist := middleware.GetClaims(ctx)
+	if !isExist {
+		response.Error(ctx, http.StatusForbidden, "Forbidden Access")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := oc.outletService.SetGlobalAdmin(ctx, scope, accessToken.UserID, param.UserID, all)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletAdminInvalid) || errors.Is(err, apperror.ErrRevokeOwnOutlets) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, message, data)
+}
+
+func (oc *OutletController) bindError(ctx *gin.Context, err error) {
+	errStr := err.Error()
+
+	if strings.Contains(errStr, "Name") && strings.Contains(errStr, "required") {
+		response.Error(ctx, http.StatusBadRequest, "Name field cannot be empty")
+		return
+	}
+
+	if strings.Contains(errStr, "Name") {
+		response.Error(ctx, http.StatusBadRequest, "Name must be at most 100 characters")
+		return
+	}
+
+	if strings.Contains(errStr, "Phone") {
+		response.Error(ctx, http.StatusBadRequest, "Phone must be at most 40 characters")
+		return
+	}
+
+	response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+}
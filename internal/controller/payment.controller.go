@@ -0,0 +1,431 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/payment"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type PaymentController struct {
+	paymentService *service.PaymentService
+}
+
+func NewPaymentController(paymentService *service.PaymentService) *PaymentController {
+	return &PaymentController{paymentService: paymentService}
+}
+
+// CreatePayment godoc
+//
+//	@Summary		Create payment method
+//	@Description	Create a new payment method
+//	@Tags			Admin Payment Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.PaymentMethodRequest	true	"Payment method data"
+//	@Success		201		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Router			/admin/payments [post]
+//	@Security		BearerAuth
+func (pc *PaymentController) CreatePayment(ctx *gin.Context) {
+	var req dto.PaymentMethodRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "Name") {
+			response.Error(ctx, http.StatusBadRequest, "Name must be at least 2 characters")
+			return
+		}
+
+		if strings.Contains(errStr, "Provider") {
+			response.Error(ctx, http.StatusBadRequest, "Provider field cannot be empty")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := pc.paymentService.CreatePayment(ctx, req); err != nil {
+		if errors.Is(err, apperror.ErrPaymentExists) || errors.Is(err, apperror.ErrPaymentProviderNotFound) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Payment method created successfully", nil)
+}
+
+// GetPayment godoc
+//
+//	@Summary		Get payment method by ID
+//	@Description	Get payment method details by ID
+//	@Tags			Admin Payment Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Payment method ID"
+//	@Success		200	{object}	dto.PaymentMethod
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/payments/{id} [get]
+//	@Security		BearerAuth
+func (pc *PaymentController) GetPayment(ctx *gin.Context) {
+	var param dto.PaymentURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid payment method id")
+		return
+	}
+
+	data, err := pc.paymentService.GetPayment(ctx, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrPaymentNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Payment method retrieved successfully", data)
+}
+
+// GetPayments godoc
+//
+//	@Summary		Get all payment methods
+//	@Description	Get all payment methods with pagination
+//	@Tags			Admin Payment Management
+//	@Produce		json
+//	@Param			page	query		string	false	"Page number"
+//	@Param			search	query		string	false	"Search by name"
+//	@Success		200		{object}	[]dto.PaymentMethod
+//	@Failure		401		{object}	dto.ResponseError
+//	@Router			/admin/payments [get]
+//	@Security		BearerAuth
+func (pc *PaymentController) GetPayments(ctx *gin.Context) {
+	var req dto.PaymentParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page := 1
+	if req.Page != "" {
+		page, _ = strconv.Atoi(req.Page)
+		if page < 1 {
+			page = 1
+		}
+	}
+
+	data, totalPage, err := pc.paymentService.GetPayments(ctx, req)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var nextPage string
+	var prevPage string
+
+	if page < totalPage {
+		nextPage = fmt.Sprintf("/admin/payments?page=%d", page+1)
+	}
+	if page > 1 {
+		prevPage = fmt.Sprintf("/admin/payments?page=%d", page-1)
+	}
+
+	response.SuccessWithMeta(ctx, http.StatusOK, "Payment methods retrieved successfully", data,
+		dto.PaginationMeta{
+			Page:      page,
+			TotalPage: totalPage,
+			NextPage:  nextPage,
+			PrevPage:  prevPage,
+		},
+	)
+}
+
+// UpdatePayment godoc
+//
+//	@Summary		Update payment method
+//	@Description	Update payment method by ID
+//	@Tags			Admin Payment Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Payment method ID"
+//	@Param			request	body		dto.UpdatePaymentMethodRequest	true	"Payment method data"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/admin/payments/{id} [patch]
+//	@Security		BearerAuth
+func (pc *PaymentController) UpdatePayment(ctx *gin.Context) {
+	var param dto.PaymentURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid payment method id")
+		return
+	}
+
+	var req dto.UpdatePaymentMethodRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "Name") {
+			response.Error(ctx, http.StatusBadRequest, "Name must be at least 2 characters")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := pc.paymentService.UpdatePayment(ctx, req, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrPaymentNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) || errors.Is(err, apperror.ErrPaymentExists) || errors.Is(err, apperror.ErrPaymentProviderNotFound) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Payment method updated successfully", nil)
+}
+
+// DeletePayment godoc
+//
+//	@Summary		Delete payment method
+//	@Description	Delete payment method by ID
+//	@Tags			Admin Payment Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Payment method ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/payments/{id} [delete]
+//	@Security		BearerAuth
+func (pc *PaymentController) DeletePayment(ctx *gin.Context) {
+	var param dto.PaymentURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid payment method id")
+		return
+	}
+
+	if err := pc.paymentService.DeletePayment(ctx, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrPaymentNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Payment method deleted successfully", nil)
+}
+
+// PayOrder godoc
+//
+//	@Summary		Pay order
+//	@Description	Charge a pending order through the provider of its payment method and record the attempt. While a charge is still waiting for the customer it is returned again with 200 instead of creating another one.
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			id	path		string	true	"Order ID"
+//	@Success		200	{object}	dto.PaymentAttempt
+//	@Success		201	{object}	dto.PaymentAttempt
+//	@Failure		400	{object}	dto.ResponseError
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Failure		409	{object}	dto.ResponseError
+//	@Router			/orders/{id}/pay [post]
+//	@Security		BearerAuth
+func (pc *PaymentController) PayOrder(ctx *gin.Context) {
+	var param dto.OrderURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, created, err := pc.paymentService.PayOrder(ctx, param.ID, accessToken.UserID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOrderNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOrderNotPayable) || errors.Is(err, apperror.ErrInvalidStatusTransition) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrPaymentInactive) || errors.Is(err, apperror.ErrPaymentProviderNotFound) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if !created {
+		response.Success(ctx, http.StatusOK, "Payment already in progress", data)
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Payment attempt recorded", data)
+}
+
+// RefundOrder godoc
+//
+//	@Summary		Refund order
+//	@Description	Refund the settled charge of an order through its payment provider
+//	@Tags			Admin Order Management
+//	@Produce		json
+//	@Param			id	path		string	true	"Order ID"
+//	@Success		200	{object}	dto.PaymentAttempt
+//	@Failure		400	{object}	dto.ResponseError
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		403	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Failure		409	{object}	dto.ResponseError
+//	@Router			/admin/orders/{id}/refund [post]
+//	@Security		BearerAuth
+func (pc *PaymentController) RefundOrder(ctx *gin.Context) {
+	var param dto.OrderURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, err := pc.paymentService.RefundOrder(ctx, param.ID, middleware.GetOutletScope(ctx), accessToken.UserID)
+	if err != nil {
+		pc.orderPaymentError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Order refunded", data)
+}
+
+// ReconcileOrder godoc
+//
+//	@Summary		Reconcile order payment
+//	@Description	Ask the payment provider for the status of the order's latest charge and apply it, for charges whose webhook never arrived
+//	@Tags			Admin Order Management
+//	@Produce		json
+//	@Param			id	path		string	true	"Order ID"
+//	@Success		200	{object}	dto.PaymentAttempt
+//	@Failure		400	{object}	dto.ResponseError
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		403	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/orders/{id}/reconcile [post]
+//	@Security		BearerAuth
+func (pc *PaymentController) ReconcileOrder(ctx *gin.Context) {
+	var param dto.OrderURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid order id")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, err := pc.paymentService.ReconcileOrder(ctx, param.ID, middleware.GetOutletScope(ctx), accessToken.UserID)
+	if err != nil {
+		pc.orderPaymentError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Order payment reconciled", data)
+}
+
+func (pc *PaymentController) orderPaymentError(ctx *gin.Context, err error) {
+	if errors.Is(err, apperror.ErrOutletForbidden) {
+		response.Error(ctx, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if errors.Is(err, apperror.ErrOrderNotFound) || errors.Is(err, apperror.ErrChargeNotFound) {
+		response.Error(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if errors.Is(err, apperror.ErrRefundNotAllowed) {
+		response.Error(ctx, http.StatusConflict, err.Error())
+		return
+	}
+
+	if errors.Is(err, apperror.ErrPaymentProviderNotFound) {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+// HandleWebhook godoc
+//
+//	@Summary		Payment gateway webhook
+//	@Description	Receive a signed charge update from a payment gateway. The raw body must be signed with HMAC-SHA256 using the provider secret and sent in the X-Signature header.
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			provider	path		string	true	"Provider name"
+//	@Param			X-Signature	header		string	true	"Hex encoded HMAC-SHA256 of the body"
+//	@Success		200			{object}	dto.ResponseSuccess
+//	@Failure		400			{object}	dto.ResponseError
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Router			/webhooks/payments/{provider} [post]
+func (pc *PaymentController) HandleWebhook(ctx *gin.Context) {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	provider := ctx.Param("provider")
+	signature := ctx.GetHeader(payment.SignatureHeader)
+
+	if err := pc.paymentService.HandleWebhook(ctx, provider, body, signature); err != nil {
+		if errors.Is(err, apperror.ErrInvalidSignature) {
+			response.Error(ctx, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrPaymentProviderNotFound) || errors.Is(err, apperror.ErrChargeNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrInvalidWebhookPayload) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Webhook processed", nil)
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"net/url"
 	"path"
 	"path/filepath"
 	"regexp"
@@ -15,9 +14,10 @@ import (
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
-	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 )
@@ -39,11 +39,16 @@ func NewProductsController(productService *service.ProductService) *ProductsCont
 //	@Produce	json
 //	@Param		id			query		string		false	"Blacklist id product"
 //	@Param		page		query		string		false	"Page number"
-//	@Param		title		query		string		false	"Product title search"
+//	@Param		limit		query		int			false	"Products per page, 6 by default"	minimum(1)	maximum(50)
+//	@Param		cursor		query		string		false	"next_cursor of the previous page, takes precedence over page"
+//	@Param		title		query		string		false	"Search in names, descriptions and categories, tolerating typos"
 //	@Param		min			query		string		false	"Minimum price"
 //	@Param		max			query		string		false	"Maximum price"
 //	@Param		category	query		[]string	false	"Categories filter"	collectionFormat(multi)
+//	@Param		outlet_id	query		int			false	"Only products sold at this outlet"
+//	@Param		sort		query		string		false	"Sort order, searches default to relevance"	Enums(relevance, priciest, cheapest, recommended, latest)
 //	@Success	200			{object}	dto.ProductResponse
+//	@Failure	400			{object}	dto.ResponseError
 //	@Failure	401			{object}	dto.ResponseError
 //	@Failure	500			{object}	dto.ResponseError
 //	@Router		/products [get]
@@ -54,60 +59,51 @@ func (p ProductsController) GetAllProducts(c *gin.Context) {
 		return
 	}
 
-	page := 1
-	if req.Page != "" {
-		page, _ = strconv.Atoi(req.Page)
-		if page < 1 {
-			page = 1
-		}
+	page, err := pagination.New(req.Page, req.Limit, req.Cursor, 6)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	data, totalPage, err := p.productService.GetAllProducts(c.Request.Context(), req)
+	data, result, err := p.productService.GetAllProducts(c.Request.Context(), req, page)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
-	var nextPage string
-	var prevPage string
-
-	queryParams := url.Values{}
-	if req.Title != "" {
-		queryParams.Set("title", req.Title)
-	}
-	if req.Min != "" {
-		queryParams.Set("min", req.Min)
-	}
-	if req.Max != "" {
-		queryParams.Set("max", req.Max)
-	}
-	// if req.ID != "" {
-	// 	queryParams.Set("id", req.ID)
-	// }
-	for _, cat := range req.Category {
-		queryParams.Add("category", cat)
-	}
+	response.SuccessWithMeta(c, http.StatusOK, "Products Retrieved Successfully", data,
+		dto.NewPaginationMeta("/products", c.Request.URL.Query(), page, result),
+	)
+}
 
-	baseQuery := ""
-	if len(queryParams) > 0 {
-		baseQuery = "&" + queryParams.Encode()
+// Suggest Products godoc
+//
+//	@Summary	Suggest products
+//	@Tags		Products
+//	@Produce	json
+//	@Param		q	query		string	true	"Start of a product name, typos are tolerated"
+//	@Success	200	{object}	[]dto.ProductSuggestion
+//	@Failure	400	{object}	dto.ResponseError
+//	@Failure	500	{object}	dto.ResponseError
+//	@Router		/products/suggest [get]
+func (p ProductsController) SuggestProducts(c *gin.Context) {
+	var req dto.ProductSuggestQueries
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Query q is required and at most 100 characters")
+		return
 	}
 
-	if page < totalPage {
-		nextPage = fmt.Sprintf("/products?page=%d%s", page+1, baseQuery)
-	}
-	if page > 1 {
-		prevPage = fmt.Sprintf("/products?page=%d%s", page-1, baseQuery)
+	data, err := p.productService.SuggestProducts(c.Request.Context(), req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
 	}
 
-	response.SuccessWithMeta(c, http.StatusOK, "Products Retrieved Successfully", data,
-		dto.PaginationMeta{
-			Page:      page,
-			TotalPage: totalPage,
-			NextPage:  nextPage,
-			PrevPage:  prevPage,
-		},
-	)
+	response.Success(c, http.StatusOK, "Suggestions Retrieved Successfully", data)
 }
 
 // Post Product godoc
@@ -120,6 +116,7 @@ func (p ProductsController) GetAllProducts(c *gin.Context) {
 //	@Param		product_name	formData	string	true	"Products name"
 //	@Param		price			formData	number	true	"Price"
 //	@Param		description		formData	string	true	"Description"
+//	@Param		category_ids	formData	[]int	false	"Category ids"	collectionFormat(multi)
 //	@Success	200				{object}	dto.ResponseSuccess
 //	@Failure	500				{object}	dto.ResponseError
 //	@Failure	401				{object}	dto.ResponseError
@@ -131,14 +128,12 @@ func (p ProductsController) PostProducts(c *gin.Context) {
 	const maxSize = 2 * 1024 * 1024
 	var postImages dto.PostImagesRequest
 
-	token, isExist := c.Get("token")
+	accessToken, isExist := middleware.GetClaims(c)
 	if !isExist {
 		response.Error(c, http.StatusForbidden, "Forbidden Access")
 		return
 	}
 
-	accessToken, _ := token.(jwtutil.JwtClaims)
-
 	if err := c.ShouldBindWith(&postImages, binding.FormMultipart); err != nil {
 		log.Println(err.Error())
 		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
@@ -194,8 +189,8 @@ func (p ProductsController) PostProducts(c *gin.Context) {
 
 	if err != nil {
 		str := err.Error()
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, apperror.ErrCategoryNotFound) {
+			response.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
 		if strings.Contains(str, "empty") {
@@ -219,6 +214,7 @@ func (p ProductsController) PostProducts(c *gin.Context) {
 //	@Param		product_name	formData	string	false	"Products name"
 //	@Param		price			formData	number	false	"Price"
 //	@Param		description		formData	string	false	"Description"
+//	@Param		category_ids	formData	[]int	false	"Category ids, replaces the current categories"	collectionFormat(multi)
 //	@Success	200				{object}	dto.ResponseSuccess
 //	@Failure	401				{object}	dto.ResponseError
 //	@Failure	400				{object}	dto.ResponseError
@@ -233,14 +229,12 @@ func (p ProductsController) UpdateProduct(c *gin.Context) {
 	const maxSize = 2 * 1024 * 1024
 	var updateImages dto.PostImagesRequest
 
-	token, isExist := c.Get("token")
+	accessToken, isExist := middleware.GetClaims(c)
 	if !isExist {
 		response.Error(c, http.StatusForbidden, "Forbidden Access")
 		return
 	}
 
-	accessToken, _ := token.(jwtutil.JwtClaims)
-
 	if err := c.ShouldBindWith(&updateImages, binding.FormMultipart); err != nil {
 		log.Println(err.Error())
 		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
@@ -276,10 +270,6 @@ func (p ProductsController) UpdateProduct(c *gin.Context) {
 
 	if err := c.ShouldBindWith(&updateProduct, binding.FormMultipart); err != nil {
 		str := err.Error()
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
-			return
-		}
 		if strings.Contains(str, "Field") {
 			response.Error(c, http.StatusBadRequest, "Invalid Body")
 			return
@@ -294,6 +284,10 @@ func (p ProductsController) UpdateProduct(c *gin.Context) {
 
 	if err := p.productService.UpdateProduct(c.Request.Context(), updateProduct, updateImages, strId); err != nil {
 		str := err.Error()
+		if errors.Is(err, apperror.ErrCategoryNotFound) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		if strings.Contains(str, "empty") {
 			response.Error(c, http.StatusBadRequest, "Invalid Body")
 			return
@@ -327,7 +321,7 @@ func (p ProductsController) DeleteProductById(c *gin.Context) {
 	id := c.Param("id")
 	strId, _ := strconv.Atoi(id)
 
-	_, isExist := c.Get("token")
+	_, isExist := middleware.GetClaims(c)
 	if !isExist {
 		response.Error(c, http.StatusForbidden, "Forbidden Access")
 		return
@@ -363,17 +357,13 @@ func (p ProductsController) DeleteProductImageById(c *gin.Context) {
 	id := c.Param("id")
 	strId, _ := strconv.Atoi(id)
 
-	_, isExist := c.Get("token")
+	_, isExist := middleware.GetClaims(c)
 	if !isExist {
 		response.Error(c, http.StatusForbidden, "Forbidden Access")
 		return
 	}
 
 	if err := p.productService.DeleteProductImageById(c.Request.Context(), strId); err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
-			return
-		}
 		if err.Error() == "no data deleted" {
 			response.Error(c, http.StatusNotFound, "Data Not Found")
 			return
@@ -401,17 +391,13 @@ func (p ProductsController) GetDetailProductById(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 	data, err := p.productService.GetProductById(c.Request.Context(), id)
 
-	_, isExist := c.Get("token")
+	_, isExist := middleware.GetClaims(c)
 	if !isExist {
 		response.Error(c, http.StatusForbidden, "Forbidden Access")
 		return
 	}
 
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(c, http.StatusUnauthorized, err.Error())
-			return
-		}
 		if err.Error() == "no rows in result set" {
 			response.Error(c, http.StatusNotFound, "Data Not Found")
 			return
@@ -444,6 +430,8 @@ func (p ProductsController) GetDetailProductByUserWithId(c *gin.Context) {
 			response.Error(c, http.StatusNotFound, "Data Not Found")
 			return
 		}
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
 	}
 	response.Success(c, http.StatusOK, "Detail Products Retrieved Successfully", data)
 }
@@ -482,3 +470,297 @@ func (pc *ProductsController) GetAllProductSize(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Product Sizes Retrieved Successfully", data)
 }
+
+// CreateProductSize godoc
+//
+//	@Summary	Create product size
+//	@Tags		Admin Product Management
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.ProductOptionRequest	true	"Size data"
+//	@Success	201		{object}	dto.ProductSize
+//	@Failure	400		{object}	dto.ResponseError
+//	@Failure	401		{object}	dto.ResponseError
+//	@Failure	409		{object}	dto.ResponseError
+//	@Router		/admin/product-sizes [post]
+//	@security	BearerAuth
+func (pc *ProductsController) CreateProductSize(c *gin.Context) {
+	var req dto.ProductOptionRequest
+	if err := c.ShouldBindWith(&req, binding.JSON); err != nil {
+		productOptionBindError(c, err)
+		return
+	}
+
+	data, err := pc.productService.CreateProductSize(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, apperror.ErrProductSizeExists) {
+			response.Error(c, http.StatusConflict, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Product Size Created Successfully", data)
+}
+
+// UpdateProductSize godoc
+//
+//	@Summary	Update product size
+//	@Tags		Admin Product Management
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int								true	"Size id"
+//	@Param		request	body		dto.UpdateProductOptionRequest	true	"Size data"
+//	@Success	200		{object}	dto.ResponseSuccess
+//	@Failure	400		{object}	dto.ResponseError
+//	@Failure	401		{object}	dto.ResponseError
+//	@Failure	404		{object}	dto.ResponseError
+//	@Failure	409		{object}	dto.ResponseError
+//	@Router		/admin/product-sizes/{id} [patch]
+//	@security	BearerAuth
+func (pc *ProductsController) UpdateProductSize(c *gin.Context) {
+	var param dto.ProductOptionURIParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product size id")
+		return
+	}
+
+	var req dto.UpdateProductOptionRequest
+	if err := c.ShouldBindWith(&req, binding.JSON); err != nil {
+		productOptionBindError(c, err)
+		return
+	}
+
+	if err := pc.productService.UpdateProductSize(c.Request.Context(), req, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrProductSizeNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrProductSizeExists) {
+			response.Error(c, http.StatusConflict, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Product Size Updated Successfully", nil)
+}
+
+// DeleteProductSize godoc
+//
+//	@Summary		Delete product size
+//	@Description	Retire a size. Past orders keep showing it, new orders and cart items cannot use it
+//	@Tags			Admin Product Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Size id"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/product-sizes/{id} [delete]
+//	@security		BearerAuth
+func (pc *ProductsController) DeleteProductSize(c *gin.Context) {
+	var param dto.ProductOptionURIParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product size id")
+		return
+	}
+
+	if err := pc.productService.DeleteProductSize(c.Request.Context(), param.ID); err != nil {
+		if errors.Is(err, apperror.ErrProductSizeNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Product Size Deleted Successfully", nil)
+}
+
+// CreateProductType godoc
+//
+//	@Summary	Create product type
+//	@Tags		Admin Product Management
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.ProductOptionRequest	true	"Type data"
+//	@Success	201		{object}	dto.ProductType
+//	@Failure	400		{object}	dto.ResponseError
+//	@Failure	401		{object}	dto.ResponseError
+//	@Failure	409		{object}	dto.ResponseError
+//	@Router		/admin/product-types [post]
+//	@security	BearerAuth
+func (pc *ProductsController) CreateProductType(c *gin.Context) {
+	var req dto.ProductOptionRequest
+	if err := c.ShouldBindWith(&req, binding.JSON); err != nil {
+		productOptionBindError(c, err)
+		return
+	}
+
+	data, err := pc.productService.CreateProductType(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, apperror.ErrProductTypeExists) {
+			response.Error(c, http.StatusConflict, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Product Type Created Successfully", data)
+}
+
+// UpdateProductType godoc
+//
+//	@Summary	Update product type
+//	@Tags		Admin Product Management
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int								true	"Type id"
+//	@Param		request	body		dto.UpdateProductOptionRequest	true	"Type data"
+//	@Success	200		{object}	dto.ResponseSuccess
+//	@Failure	400		{object}	dto.ResponseError
+//	@Failure	401		{object}	dto.ResponseError
+//	@Failure	404		{object}	dto.ResponseError
+//	@Failure	409		{object}	dto.ResponseError
+//	@Router		/admin/product-types/{id} [patch]
+//	@security	BearerAuth
+func (pc *ProductsController) UpdateProductType(c *gin.Context) {
+	var param dto.ProductOptionURIParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product type id")
+		return
+	}
+
+	var req dto.UpdateProductOptionRequest
+	if err := c.ShouldBindWith(&req, binding.JSON); err != nil {
+		productOptionBindError(c, err)
+		return
+	}
+
+	if err := pc.productService.UpdateProductType(c.Request.Context(), req, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrProductTypeNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrProductTypeExists) {
+			response.Error(c, http.StatusConflict, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Product Type Updated Successfully", nil)
+}
+
+// DeleteProductType godoc
+//
+//	@Summary		Delete product type
+//	@Description	Retire a type. Past orders keep showing it, new orders and cart items cannot use it
+//	@Tags			Admin Product Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Type id"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/product-types/{id} [delete]
+//	@security		BearerAuth
+func (pc *ProductsController) DeleteProductType(c *gin.Context) {
+	var param dto.ProductOptionURIParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product type id")
+		return
+	}
+
+	if err := pc.productService.DeleteProductType(c.Request.Context(), param.ID); err != nil {
+		if errors.Is(err, apperror.ErrProductTypeNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Product Type Deleted Successfully", nil)
+}
+
+func productOptionBindError(c *gin.Context, err error) {
+	str := err.Error()
+	if strings.Contains(str, "Name") && strings.Contains(str, "required") {
+		response.Error(c, http.StatusBadRequest, "Name field cannot be empty")
+		return
+	}
+	if strings.Contains(str, "Price") && strings.Contains(str, "required") {
+		response.Error(c, http.StatusBadRequest, "Price field cannot be empty")
+		return
+	}
+	if strings.Contains(str, "Price") {
+		response.Error(c, http.StatusBadRequest, "Price cannot be negative")
+		return
+	}
+	response.Error(c, http.StatusBadRequest, "Invalid Body")
+}
+
+// SetProductOptions godoc
+//
+//	@Summary		Set product sizes and types
+//	@Description	Replace the sizes and types a product can be ordered with. A price overrides the option's own surcharge for this product. A list that is left out stays unchanged, an empty list allows every option again
+//	@Tags			Admin Product Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Product id"
+//	@Param			request	body		dto.ProductOptionsRequest	true	"Allowed options"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/admin/products/{id}/options [put]
+//	@security		BearerAuth
+func (pc *ProductsController) SetProductOptions(c *gin.Context) {
+	var param dto.ProductOptionURIParam
+	if err := c.ShouldBindUri(&param); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product id")
+		return
+	}
+
+	var req dto.ProductOptionsRequest
+	if err := c.ShouldBindWith(&req, binding.JSON); err != nil {
+		str := err.Error()
+		if strings.Contains(str, "ID") {
+			response.Error(c, http.StatusBadRequest, "Option id cannot be empty")
+			return
+		}
+		if strings.Contains(str, "Price") {
+			response.Error(c, http.StatusBadRequest, "Price cannot be negative")
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "Invalid Body")
+		return
+	}
+
+	if err := pc.productService.SetProductOptions(c.Request.Context(), req, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrProductNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, apperror.ErrProductSizeNotFound) || errors.Is(err, apperror.ErrProductTypeNotFound) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Product Options Updated Successfully", nil)
+}
@@ -0,0 +1,462 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type ReviewController struct {
+	reviewService *service.ReviewService
+}
+
+func NewReviewController(reviewService *service.ReviewService) *ReviewController {
+	return &ReviewController{reviewService: reviewService}
+}
+
+// AddReview godoc
+//
+//	@Summary		Add review to order
+//	@Description	Review an item of one of your completed orders. The body can also be sent as JSON when there is no photo. Photos must be jpg or png of at most 2MB. Every item can be reviewed once
+//	@Tags			Reviews
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			dt_orderid	formData	int		true	"Detail order ID"
+//	@Param			rating		formData	int		true	"Rating from 1 to 5"
+//	@Param			comment		formData	string	false	"Comment"
+//	@Param			photo		formData	file	false	"Review photo"
+//	@Success		201			{object}	dto.Review
+//	@Failure		400			{object}	dto.ResponseError
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Failure		409			{object}	dto.ResponseError
+//	@Router			/orders/review [post]
+//	@Security		BearerAuth
+func (rc *ReviewController) AddReview(ctx *gin.Context) {
+	var req dto.AddReview
+	if err := ctx.ShouldBind(&req); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "DtOrderId") && strings.Contains(errStr, "required") {
+			response.Error(ctx, http.StatusBadRequest, "Detail Order ID is required")
+			return
+		}
+
+		if strings.Contains(errStr, "Rating") && strings.Contains(errStr, "required") {
+			response.Error(ctx, http.StatusBadRequest, "Rating is required")
+			return
+		}
+
+		rc.bindError(ctx, err)
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	imagePath, ok := rc.savePhoto(ctx, req.Photo, accessToken.UserID)
+	if !ok {
+		return
+	}
+
+	data, err := rc.reviewService.AddReview(ctx.Request.Context(), req, imagePath, accessToken.UserID)
+	if err != nil {
+		removePhoto(imagePath)
+
+		if errors.Is(err, apperror.ErrOrderItemNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrReviewNotAllowed) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrReviewExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Review Added Successfully", data)
+}
+
+// UpdateReview godoc
+//
+//	@Summary		Update review
+//	@Description	Update the rating, comment or photo of your own review
+//	@Tags			Reviews
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		int		true	"Review ID"
+//	@Param			rating	formData	int		false	"Rating from 1 to 5"
+//	@Param			comment	formData	string	false	"Comment"
+//	@Param			photo	formData	file	false	"Review photo"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		403		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/reviews/{id} [patch]
+//	@Security		BearerAuth
+func (rc *ReviewController) UpdateReview(ctx *gin.Context) {
+	var param dto.ReviewURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	var req dto.UpdateReviewRequest
+	if err := ctx.ShouldBindWith(&req, binding.FormMultipart); err != nil {
+		if strings.Contains(err.Error(), "no multipart boundary param in Content-Type") {
+			response.Error(ctx, http.StatusBadRequest, "No fields to update")
+			return
+		}
+
+		rc.bindError(ctx, err)
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	imagePath, ok := rc.savePhoto(ctx, req.Photo, accessToken.UserID)
+	if !ok {
+		return
+	}
+
+	oldPath, err := rc.reviewService.UpdateReview(ctx.Request.Context(), req, imagePath, accessToken.UserID, param.ID)
+	if err != nil {
+		removePhoto(imagePath)
+
+		if errors.Is(err, apperror.ErrReviewNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrReviewForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	removePhoto(oldPath)
+
+	response.Success(ctx, http.StatusOK, "Review updated successfully", nil)
+}
+
+// DeleteReview godoc
+//
+//	@Summary		Delete review
+//	@Description	Delete your own review
+//	@Tags			Reviews
+//	@Produce		json
+//	@Param			id	path		int	true	"Review ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		403	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/reviews/{id} [delete]
+//	@Security		BearerAuth
+func (rc *ReviewController) DeleteReview(ctx *gin.Context) {
+	var param dto.ReviewURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	if err := rc.reviewService.DeleteReview(ctx.Request.Context(), accessToken.UserID, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrReviewNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrReviewForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Review deleted successfully", nil)
+}
+
+// GetProductReviews godoc
+//
+//	@Summary		Get product reviews
+//	@Description	Get the reviews of a product newest first, with the average rating and the number of reviews per rating
+//	@Tags			Reviews
+//	@Produce		json
+//	@Param			id		path		int		true	"Product ID"
+//	@Param			page	query		string	false	"Page number"
+//	@Success		200		{object}	dto.ProductReviews
+//	@Failure		404		{object}	dto.ResponseError
+//	@Failure		500		{object}	dto.ResponseError
+//	@Router			/products/{id}/reviews [get]
+func (rc *ReviewController) GetProductReviews(ctx *gin.Context) {
+	var param dto.ReviewURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid product id")
+		return
+	}
+
+	var req dto.ReviewParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page := 1
+	if req.Page != "" {
+		page, _ = strconv.Atoi(req.Page)
+		if page < 1 {
+			page = 1
+		}
+	}
+
+	data, totalPage, err := rc.reviewService.GetProductReviews(ctx.Request.Context(), param.ID, req)
+	if err != nil {
+		if errors.Is(err, apperror.ErrProductNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var nextPage string
+	var prevPage string
+
+	if page < totalPage {
+		nextPage = fmt.Sprintf("/products/%d/reviews?page=%d", param.ID, page+1)
+	}
+	if page > 1 {
+		prevPage = fmt.Sprintf("/products/%d/reviews?page=%d", param.ID, page-1)
+	}
+
+	response.SuccessWithMeta(ctx, http.StatusOK, "Reviews retrieved successfully", data,
+		dto.PaginationMeta{
+			Page:      page,
+			TotalPage: totalPage,
+			NextPage:  nextPage,
+			PrevPage:  prevPage,
+		},
+	)
+}
+
+// GetReviews godoc
+//
+//	@Summary		Get all reviews
+//	@Description	Get reviews for moderation newest first, hidden ones included
+//	@Tags			Admin Review Management
+//	@Produce		json
+//	@Param			page		query		string	false	"Page number"
+//	@Param			product_id	query		int		false	"Filter by product"
+//	@Param			hidden		query		string	false	"Filter by visibility"	Enums(true, false)
+//	@Success		200			{object}	[]dto.AdminReview
+//	@Failure		400			{object}	dto.ResponseError
+//	@Failure		401			{object}	dto.ResponseError
+//	@Router			/admin/reviews [get]
+//	@Security		BearerAuth
+func (rc *ReviewController) GetReviews(ctx *gin.Context) {
+	var req dto.AdminReviewParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page := 1
+	if req.Page != "" {
+		page, _ = strconv.Atoi(req.Page)
+		if page < 1 {
+			page = 1
+		}
+	}
+
+	data, totalPage, err := rc.reviewService.GetReviews(ctx.Request.Context(), req)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var nextPage string
+	var prevPage string
+
+	queryParams := ""
+	if req.ProductId != 0 {
+		queryParams += fmt.Sprintf("&product_id=%d", req.ProductId)
+	}
+	if req.Hidden != "" {
+		queryParams += "&hidden=" + req.Hidden
+	}
+
+	if page < totalPage {
+		nextPage = fmt.Sprintf("/admin/reviews?page=%d%s", page+1, queryParams)
+	}
+	if page > 1 {
+		prevPage = fmt.Sprintf("/admin/reviews?page=%d%s", page-1, queryParams)
+	}
+
+	response.SuccessWithMeta(ctx, http.StatusOK, "Reviews retrieved successfully", data,
+		dto.PaginationMeta{
+			Page:      page,
+			TotalPage: totalPage,
+			NextPage:  nextPage,
+			PrevPage:  prevPage,
+		},
+	)
+}
+
+// SetReviewHidden godoc
+//
+//	@Summary		Hide or show review
+//	@Description	Hide an abusive review from product pages and ratings, or show it again
+//	@Tags			Admin Review Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Review ID"
+//	@Param			request	body		dto.HideReviewRequest	true	"Visibility"
+//	@Success		200		{object}	dto.AdminReview
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/admin/reviews/{id}/visibility [patch]
+//	@Security		BearerAuth
+func (rc *ReviewController) SetReviewHidden(ctx *gin.Context) {
+	var param dto.ReviewURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid review id")
+		return
+	}
+
+	var req dto.HideReviewRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "Hidden") && strings.Contains(errStr, "required") {
+			response.Error(ctx, http.StatusBadRequest, "Hidden field cannot be empty")
+			return
+		}
+
+		if strings.Contains(errStr, "Reason") {
+			response.Error(ctx, http.StatusBadRequest, "Reason must be at most 255 characters")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	accessToken, _ := middleware.GetClaims(ctx)
+
+	data, err := rc.reviewService.SetReviewHidden(ctx.Request.Context(), req, accessToken.UserID, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrReviewNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Review visibility updated successfully", data)
+}
+
+// savePhoto stores an uploaded review photo under public/reviews. It writes
+// the error response itself and reports false when the upload is rejected.
+func (rc *ReviewController) savePhoto(ctx *gin.Context, photo *multipart.FileHeader, userID int) (string, bool) {
+	const maxSize = 2 * 1024 * 1024
+
+	if photo == nil {
+		return "", true
+	}
+
+	ext := strings.ToLower(path.Ext(photo.Filename))
+	re := regexp.MustCompile(`^\.(jpg|png)$`)
+	if !re.MatchString(ext) {
+		response.Error(ctx, http.StatusBadRequest, "File must be jpg or png")
+		return "", false
+	}
+
+	if photo.Size > maxSize {
+		response.Error(ctx, http.StatusBadRequest, "File size must be at most 2MB")
+		return "", false
+	}
+
+	filename := fmt.Sprintf(
+		"%d_review_%d%s",
+		time.Now().UnixNano(),
+		userID,
+		ext,
+	)
+
+	if err := ctx.SaveUploadedFile(
+		photo,
+		filepath.Join("public", "reviews", filename),
+	); err != nil {
+		log.Println(err.Error())
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return "", false
+	}
+
+	return fmt.Sprintf("/reviews/%s", filename), true
+}
+
+func removePhoto(imagePath string) {
+	if imagePath == "" {
+		return
+	}
+
+	if err := os.Remove(filepath.Join("public", imagePath)); err != nil {
+		log.Println("failed to delete review photo:", err.Error())
+	}
+}
+
+func (rc *ReviewController) bindError(ctx *gin.Context, err error) {
+	errStr := err.Error()
+
+	if strings.Contains(errStr, "Rating") {
+		response.Error(ctx, http.StatusBadRequest, "Rating must be between 1 and 5")
+		return
+	}
+
+	if strings.Contains(errStr, "Comment") {
+		response.Error(ctx, http.StatusBadRequest, "Comment must be at most 1000 characters")
+		return
+	}
+
+	response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+}
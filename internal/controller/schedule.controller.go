@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type ScheduleController struct {
+	scheduleService *service.ScheduleService
+}
+
+func NewScheduleController(scheduleService *service.ScheduleService) *ScheduleController {
+	return &ScheduleController{scheduleService: scheduleService}
+}
+
+// GetSlots godoc
+//
+//	@Summary		Get order slots
+//	@Description	Get the pickup and delivery slots of an outlet on a date with the places left in each. Send the start of an available slot as scheduled_for when ordering from that outlet
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			outlet_id	query		int		true	"Outlet ID"
+//	@Param			date		query		string	true	"Date (YYYY-MM-DD)"
+//	@Success		200			{object}	[]dto.OrderSlot
+//	@Failure		400			{object}	dto.ResponseError
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Router			/orders/slots [get]
+//	@Security		BearerAuth
+func (sc *ScheduleController) GetSlots(ctx *gin.Context) {
+	var req dto.SlotQueries
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		if strings.Contains(err.Error(), "OutletID") {
+			response.Error(ctx, http.StatusBadRequest, "Outlet ID is required")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Date must use the YYYY-MM-DD format")
+		return
+	}
+
+	data, err := sc.scheduleService.GetSlots(ctx, req.OutletID, req.Date)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOpeningHoursNotFound) || errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletInactive) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Slots retrieved successfully", data)
+}
+
+// GetOpeningHours godoc
+//
+//	@Summary		Get opening hours
+//	@Description	Get the opening hours and slot settings of an outlet for every weekday, Sunday first
+//	@Tags			Admin Opening Hours
+//	@Produce		json
+//	@Param			outlet_id	path		int	true	"Outlet ID"
+//	@Success		200			{object}	[]dto.OpeningHours
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Router			/admin/outlets/{outlet_id}/opening-hours [get]
+//	@Security		BearerAuth
+func (sc *ScheduleController) GetOpeningHours(ctx *gin.Context) {
+	var param dto.OutletURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid outlet id")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := sc.scheduleService.GetOpeningHours(ctx, scope, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Opening hours retrieved successfully", data)
+}
+
+// UpdateOpeningHours godoc
+//
+//	@Summary		Update opening hours
+//	@Description	Set the opening hours, slot length and slot capacity of an outlet for a weekday (0 is Sunday). Orders already scheduled keep their slot
+//	@Tags			Admin Opening Hours
+//	@Accept			json
+//	@Produce		json
+//	@Param			outlet_id	path		int						true	"Outlet ID"
+//	@Param			weekday		path		int						true	"Weekday, 0 is Sunday"
+//	@Param			request		body		dto.OpeningHoursRequest	true	"Opening hours"
+//	@Success		200			{object}	dto.OpeningHours
+//	@Failure		400			{object}	dto.ResponseError
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
+//	@Failure		404			{object}	dto.ResponseError
+//	@Router			/admin/outlets/{outlet_id}/opening-hours/{weekday} [put]
+//	@Security		BearerAuth
+func (sc *ScheduleController) UpdateOpeningHours(ctx *gin.Context) {
+	var param dto.OpeningHoursURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		if strings.Contains(err.Error(), "OutletID") {
+			response.Error(ctx, http.StatusBadRequest, "Invalid outlet id")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Weekday must be between 0 and 6")
+		return
+	}
+
+	var req dto.OpeningHoursRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "OpensAt") || strings.Contains(errStr, "ClosesAt") {
+			response.Error(ctx, http.StatusBadRequest, "Opening and closing times must use the HH:MM format")
+			return
+		}
+
+		if strings.Contains(errStr, "SlotMinutes") {
+			response.Error(ctx, http.StatusBadRequest, "Slot length must be between 5 and 240 minutes")
+			return
+		}
+
+		if strings.Contains(errStr, "SlotCapacity") {
+			response.Error(ctx, http.StatusBadRequest, "Slot capacity must be zero or more")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := sc.scheduleService.UpdateOpeningHours(ctx, req, scope, param.OutletID, param.Weekday)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOpeningHoursNotFound) || errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrOpeningHoursInvalid) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Opening hours updated successfully", data)
+}
@@ -0,0 +1,301 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type TaxController struct {
+	taxService *service.TaxService
+}
+
+func NewTaxController(taxService *service.TaxService) *TaxController {
+	return &TaxController{taxService: taxService}
+}
+
+// CreateTaxRule godoc
+//
+//	@Summary		Create tax rule
+//	@Description	Create a tax or service charge rule. Rules without a shipping mode apply to every order, and only admins of every outlet may add rules without an outlet
+//	@Tags			Admin Tax Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.TaxRuleRequest	true	"Tax rule data"
+//	@Success		201		{object}	dto.TaxRule
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		403		{object}	dto.ResponseError
+//	@Router			/admin/tax-rules [post]
+//	@Security		BearerAuth
+func (tc *TaxController) CreateTaxRule(ctx *gin.Context) {
+	var req dto.TaxRuleRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		tc.bindError(ctx, err)
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := tc.taxService.CreateTaxRule(ctx, req, scope)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrTaxRuleInvalidPeriod) || errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Tax rule created successfully", data)
+}
+
+// GetTaxRule godoc
+//
+//	@Summary		Get tax rule by ID
+//	@Description	Get tax or service charge rule details by ID
+//	@Tags			Admin Tax Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Tax rule ID"
+//	@Success		200	{object}	dto.TaxRule
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		403	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/tax-rules/{id} [get]
+//	@Security		BearerAuth
+func (tc *TaxController) GetTaxRule(ctx *gin.Context) {
+	var param dto.TaxRuleURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid tax rule id")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, err := tc.taxService.GetTaxRule(ctx, scope, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrTaxRuleNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Tax rule retrieved successfully", data)
+}
+
+// GetTaxRules godoc
+//
+//	@Summary		Get all tax rules
+//	@Description	Get tax and service charge rules for the admin's outlets with pagination, newest period first
+//	@Tags			Admin Tax Management
+//	@Produce		json
+//	@Param			page		query		string	false	"Page number"
+//	@Param			search		query		string	false	"Search by name"
+//	@Param			shipping	query		string	false	"Only rules that apply to this shipping mode"
+//	@Param			outlet_id	query		int		false	"Only rules that apply at this outlet"
+//	@Success		200			{object}	[]dto.TaxRule
+//	@Failure		401			{object}	dto.ResponseError
+//	@Failure		403			{object}	dto.ResponseError
+//	@Router			/admin/tax-rules [get]
+//	@Security		BearerAuth
+func (tc *TaxController) GetTaxRules(ctx *gin.Context) {
+	var req dto.TaxRuleParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page := 1
+	if req.Page != "" {
+		page, _ = strconv.Atoi(req.Page)
+		if page < 1 {
+			page = 1
+		}
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	data, totalPage, err := tc.taxService.GetTaxRules(ctx, req, scope)
+	if err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var nextPage string
+	var prevPage string
+
+	if page < totalPage {
+		nextPage = fmt.Sprintf("/admin/tax-rules?page=%d", page+1)
+	}
+	if page > 1 {
+		prevPage = fmt.Sprintf("/admin/tax-rules?page=%d", page-1)
+	}
+
+	response.SuccessWithMeta(ctx, http.StatusOK, "Tax rules retrieved successfully", data,
+		dto.PaginationMeta{
+			Page:      page,
+			TotalPage: totalPage,
+			NextPage:  nextPage,
+			PrevPage:  prevPage,
+		},
+	)
+}
+
+// UpdateTaxRule godoc
+//
+//	@Summary		Update tax rule
+//	@Description	Update a tax or service charge rule. Orders already placed keep the breakdown they were charged
+//	@Tags			Admin Tax Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Tax rule ID"
+//	@Param			request	body		dto.UpdateTaxRuleRequest	true	"Tax rule data"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		403		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/admin/tax-rules/{id} [patch]
+//	@Security		BearerAuth
+func (tc *TaxController) UpdateTaxRule(ctx *gin.Context) {
+	var param dto.TaxRuleURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid tax rule id")
+		return
+	}
+
+	var req dto.UpdateTaxRuleRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		tc.bindError(ctx, err)
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	if err := tc.taxService.UpdateTaxRule(ctx, req, scope, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrTaxRuleNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) || errors.Is(err, apperror.ErrTaxRuleInvalidPeriod) || errors.Is(err, apperror.ErrOutletNotFound) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Tax rule updated successfully", nil)
+}
+
+// DeleteTaxRule godoc
+//
+//	@Summary		Delete tax rule
+//	@Description	Delete a tax or service charge rule by ID
+//	@Tags			Admin Tax Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Tax rule ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		403	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/tax-rules/{id} [delete]
+//	@Security		BearerAuth
+func (tc *TaxController) DeleteTaxRule(ctx *gin.Context) {
+	var param dto.TaxRuleURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid tax rule id")
+		return
+	}
+
+	scope := middleware.GetOutletScope(ctx)
+
+	if err := tc.taxService.DeleteTaxRule(ctx, scope, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrOutletForbidden) {
+			response.Error(ctx, http.StatusForbidden, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrTaxRuleNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Tax rule deleted successfully", nil)
+}
+
+func (tc *TaxController) bindError(ctx *gin.Context, err error) {
+	errStr := err.Error()
+
+	if strings.Contains(errStr, "Name") && strings.Contains(errStr, "required") {
+		response.Error(ctx, http.StatusBadRequest, "Name field cannot be empty")
+		return
+	}
+
+	if strings.Contains(errStr, "Name") {
+		response.Error(ctx, http.StatusBadRequest, "Name must be at most 100 characters")
+		return
+	}
+
+	if strings.Contains(errStr, "Kind") {
+		response.Error(ctx, http.StatusBadRequest, "Kind must be tax or service")
+		return
+	}
+
+	if strings.Contains(errStr, "Rate") {
+		response.Error(ctx, http.StatusBadRequest, "Rate must be between 0 and 100")
+		return
+	}
+
+	if strings.Contains(errStr, "OutletID") {
+		response.Error(ctx, http.StatusBadRequest, "Outlet id must be a positive number")
+		return
+	}
+
+	if strings.Contains(errStr, "Effective") {
+		response.Error(ctx, http.StatusBadRequest, "Effective dates must use the YYYY-MM-DD format")
+		return
+	}
+
+	response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+}
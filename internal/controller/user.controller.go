@@ -9,15 +9,15 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
-	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 )
@@ -75,18 +75,7 @@ func (uc *UserController) UpdateProfile(ctx *gin.Context) {
 		return
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
+	accessToken, _ := middleware.GetClaims(ctx)
 
 	var imagePath string
 
@@ -117,13 +106,8 @@ func (uc *UserController) UpdateProfile(ctx *gin.Context) {
 		imagePath = fmt.Sprintf("/profile/%s", filename)
 	}
 
-	oldPath, err := uc.userService.UpdateProfile(ctx, req, imagePath, accessToken.UserID, token[1])
+	oldPath, err := uc.userService.UpdateProfile(ctx, req, imagePath, accessToken.UserID)
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-
 		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
 			response.Error(ctx, http.StatusBadRequest, err.Error())
 			return
@@ -202,18 +186,7 @@ func (uc *UserController) UpdateProfileAdmin(ctx *gin.Context) {
 		Address:  req.Address,
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
+	accessToken, _ := middleware.GetClaims(ctx)
 
 	var imagePath string
 
@@ -244,13 +217,8 @@ func (uc *UserController) UpdateProfileAdmin(ctx *gin.Context) {
 		imagePath = fmt.Sprintf("/profile/%s", filename)
 	}
 
-	oldPath, err := uc.userService.UpdateProfileAdmin(ctx, reqChange, imagePath, param.ID, accessToken.UserID, token[1])
+	oldPath, err := uc.userService.UpdateProfileAdmin(ctx, reqChange, imagePath, param.ID)
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-
 		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
 			response.Error(ctx, http.StatusBadRequest, err.Error())
 			return
@@ -317,24 +285,8 @@ func (uc *UserController) UpdatePassword(ctx *gin.Context) {
 		return
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
-	if err := uc.userService.UpdatePassword(ctx, req, accessToken.UserID, token[1]); err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-
+	accessToken, _ := middleware.GetClaims(ctx)
+	if err := uc.userService.UpdatePassword(ctx, req, accessToken.UserID); err != nil {
 		if errors.Is(err, apperror.ErrGetPassword) || errors.Is(err, apperror.ErrUpdatePassword) || errors.Is(err, apperror.ErrVerifyPassword) {
 			response.Error(ctx, http.StatusBadRequest, err.Error())
 			return
@@ -358,25 +310,9 @@ func (uc *UserController) UpdatePassword(ctx *gin.Context) {
 //	@Router			/user [get]
 //	@Security		BearerAuth
 func (uc *UserController) GetProfile(ctx *gin.Context) {
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
-	data, err := uc.userService.GetProfile(ctx, accessToken.UserID, token[1])
+	accessToken, _ := middleware.GetClaims(ctx)
+	data, err := uc.userService.GetProfile(ctx, accessToken.UserID)
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-
 		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
@@ -472,18 +408,7 @@ func (uc *UserController) InsertUser(ctx *gin.Context) {
 		return
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
+	accessToken, _ := middleware.GetClaims(ctx)
 
 	var imagePath string
 
@@ -514,12 +439,7 @@ func (uc *UserController) InsertUser(ctx *gin.Context) {
 		imagePath = fmt.Sprintf("/profile/%s", filename)
 	}
 
-	if err := uc.userService.InsertUser(ctx, req, accessToken.UserID, imagePath, token[1]); err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-
+	if err := uc.userService.InsertUser(ctx, req, imagePath); err != nil {
 		if errors.Is(err, apperror.ErrEmailAlreadyExists) || errors.Is(err, apperror.ErrInvalidEmailFormat) {
 			response.Error(ctx, http.StatusBadRequest, err.Error())
 			return
@@ -552,24 +472,7 @@ func (uc *UserController) DeleteUser(ctx *gin.Context) {
 		return
 	}
 
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
-	if err := uc.userService.DeleteUser(ctx, accessToken.UserID, param.ID, token[1]); err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-
+	if err := uc.userService.DeleteUser(ctx, param.ID); err != nil {
 		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
@@ -584,7 +487,10 @@ func (uc *UserController) DeleteUser(ctx *gin.Context) {
 //	@Tags			Admin User Management
 //	@Produce		json
 //	@Param			page	query		string	false	"Page number"
+//	@Param			limit	query		int		false	"Users per page, 5 by default"	minimum(1)	maximum(50)
+//	@Param			cursor	query		string	false	"next_cursor of the previous page, takes precedence over page"
 //	@Success		200		{object}	dto.UserProfileResponse
+//	@Failure		400		{object}	dto.ResponseError
 //	@Failure		401		{object}	dto.ResponseError
 //	@Router			/admin/user [get]
 //	@Security		BearerAuth
@@ -595,30 +501,16 @@ func (uc *UserController) GetUsers(ctx *gin.Context) {
 		return
 	}
 
-	page := 1
-	if req.Page != "" {
-		page, _ = strconv.Atoi(req.Page)
-		if page < 1 {
-			page = 1
-		}
-	}
-
-	token := strings.Split(ctx.GetHeader("Authorization"), " ")
-	if len(token) != 2 {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
-		return
-	}
-	if token[0] != "Bearer" {
-		response.Error(ctx, http.StatusUnauthorized, "Invalid Token")
+	page, err := pagination.New(req.Page, req.Limit, req.Cursor, 5)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	tokenData, _ := ctx.Get("token")
-	accessToken, _ := tokenData.(jwtutil.JwtClaims)
-	data, totalPage, err := uc.userService.GetUsers(ctx, req, accessToken.UserID, token[1])
+	data, result, err := uc.userService.GetUsers(ctx, page)
 	if err != nil {
-		if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
-			response.Error(ctx, http.StatusUnauthorized, err.Error())
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
 			return
 		}
 		
@@ -626,22 +518,7 @@ func (uc *UserController) GetUsers(ctx *gin.Context) {
 		return
 	}
 
-	var nextPage string
-	var prevPage string
-
-	if page < totalPage {
-		nextPage = fmt.Sprintf("/admin/users?page=%d", page+1)
-	}
-	if page > 1 {
-		prevPage = fmt.Sprintf("/admin/users?page=%d", page-1)
-	}
-
 	response.SuccessWithMeta(ctx, http.StatusOK, "Users data Retrieved Successfully", data,
-		dto.PaginationMeta{
-			Page:      page,
-			TotalPage: totalPage,
-			NextPage:  nextPage,
-			PrevPage:  prevPage,
-		},
+		dto.NewPaginationMeta("/admin/user", ctx.Request.URL.Query(), page, result),
 	)
 }
@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type VoucherController struct {
+	voucherService *service.VoucherService
+}
+
+func NewVoucherController(voucherService *service.VoucherService) *VoucherController {
+	return &VoucherController{voucherService: voucherService}
+}
+
+// CreateVoucher godoc
+//
+//	@Summary		Create voucher
+//	@Description	Create a new promo code
+//	@Tags			Admin Voucher Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.VoucherRequest	true	"Voucher data"
+//	@Success		201		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Router			/admin/vouchers [post]
+//	@Security		BearerAuth
+func (vc *VoucherController) CreateVoucher(ctx *gin.Context) {
+	var req dto.VoucherRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "Code") && strings.Contains(errStr, "required") {
+			response.Error(ctx, http.StatusBadRequest, "Code field cannot be empty")
+			return
+		}
+
+		if strings.Contains(errStr, "Code") && strings.Contains(errStr, "max") {
+			response.Error(ctx, http.StatusBadRequest, "Code must be at most 20 characters")
+			return
+		}
+
+		if strings.Contains(errStr, "Name") && strings.Contains(errStr, "required") {
+			response.Error(ctx, http.StatusBadRequest, "Name field cannot be empty")
+			return
+		}
+
+		if strings.Contains(errStr, "Discount") {
+			response.Error(ctx, http.StatusBadRequest, "Discount must be between 0 and 100")
+			return
+		}
+
+		if strings.Contains(errStr, "Date") && strings.Contains(errStr, "datetime") {
+			response.Error(ctx, http.StatusBadRequest, "Date must use the YYYY-MM-DD format")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := vc.voucherService.CreateVoucher(ctx, req); err != nil {
+		if errors.Is(err, apperror.ErrVoucherExists) || errors.Is(err, apperror.ErrVoucherInvalidPeriod) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Voucher created successfully", nil)
+}
+
+// GetVoucher godoc
+//
+//	@Summary		Get voucher by ID
+//	@Description	Get promo code details by ID
+//	@Tags			Admin Voucher Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Voucher ID"
+//	@Success		200	{object}	dto.Voucher
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/vouchers/{id} [get]
+//	@Security		BearerAuth
+func (vc *VoucherController) GetVoucher(ctx *gin.Context) {
+	var param dto.VoucherURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid voucher id")
+		return
+	}
+
+	data, err := vc.voucherService.GetVoucher(ctx, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrVoucherNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Voucher retrieved successfully", data)
+}
+
+// GetVouchers godoc
+//
+//	@Summary		Get all vouchers
+//	@Description	Get all promo codes with pagination
+//	@Tags			Admin Voucher Management
+//	@Produce		json
+//	@Param			page	query		string	false	"Page number"
+//	@Param			search	query		string	false	"Search by code or name"
+//	@Success		200		{object}	[]dto.Voucher
+//	@Failure		401		{object}	dto.ResponseError
+//	@Router			/admin/vouchers [get]
+//	@Security		BearerAuth
+func (vc *VoucherController) GetVouchers(ctx *gin.Context) {
+	var req dto.VoucherParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page := 1
+	if req.Page != "" {
+		page, _ = strconv.Atoi(req.Page)
+		if page < 1 {
+			page = 1
+		}
+	}
+
+	data, totalPage, err := vc.voucherService.GetVouchers(ctx, req)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var nextPage string
+	var prevPage string
+
+	if page < totalPage {
+		nextPage = fmt.Sprintf("/admin/vouchers?page=%d", page+1)
+	}
+	if page > 1 {
+		prevPage = fmt.Sprintf("/admin/vouchers?page=%d", page-1)
+	}
+
+	response.SuccessWithMeta(ctx, http.StatusOK, "Vouchers retrieved successfully", data,
+		dto.PaginationMeta{
+			Page:      page,
+			TotalPage: totalPage,
+			NextPage:  nextPage,
+			PrevPage:  prevPage,
+		},
+	)
+}
+
+// UpdateVoucher godoc
+//
+//	@Summary		Update voucher
+//	@Description	Update promo code by ID. Omitted fields are left unchanged, an empty start_date or end_date removes it and a usage_limit of 0 removes the limit. The resulting end date may not be before the start date
+//	@Tags			Admin Voucher Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Voucher ID"
+//	@Param			request	body		dto.UpdateVoucherRequest	true	"Voucher data"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Router			/admin/vouchers/{id} [patch]
+//	@Security		BearerAuth
+func (vc *VoucherController) UpdateVoucher(ctx *gin.Context) {
+	var param dto.VoucherURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid voucher id")
+		return
+	}
+
+	var req dto.UpdateVoucherRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		errStr := err.Error()
+
+		if strings.Contains(errStr, "Code") && strings.Contains(errStr, "max") {
+			response.Error(ctx, http.StatusBadRequest, "Code must be at most 20 characters")
+			return
+		}
+
+		if strings.Contains(errStr, "Discount") {
+			response.Error(ctx, http.StatusBadRequest, "Discount must be between 0 and 100")
+			return
+		}
+
+		if strings.Contains(errStr, "Date") && strings.Contains(errStr, "datetime") {
+			response.Error(ctx, http.StatusBadRequest, "Date must use the YYYY-MM-DD format")
+			return
+		}
+
+		response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := vc.voucherService.UpdateVoucher(ctx, req, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrVoucherNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) || errors.Is(err, apperror.ErrVoucherExists) || errors.Is(err, apperror.ErrVoucherInvalidPeriod) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Voucher updated successfully", nil)
+}
+
+// DeleteVoucher godoc
+//
+//	@Summary		Delete voucher
+//	@Description	Delete promo code by ID
+//	@Tags			Admin Voucher Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Voucher ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/vouchers/{id} [delete]
+//	@Security		BearerAuth
+func (vc *VoucherController) DeleteVoucher(ctx *gin.Context) {
+	var param dto.VoucherURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid voucher id")
+		return
+	}
+
+	if err := vc.voucherService.DeleteVoucher(ctx, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrVoucherNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Voucher deleted successfully", nil)
+}
@@ -0,0 +1,269 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/response"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type ZoneController struct {
+	zoneService *service.ZoneService
+}
+
+func NewZoneController(zoneService *service.ZoneService) *ZoneController {
+	return &ZoneController{zoneService: zoneService}
+}
+
+// GetActiveDeliveryZones godoc
+//
+//	@Summary		Get delivery zones
+//	@Description	Get the zones we currently deliver to and their delivery fee
+//	@Tags			Delivery Zones
+//	@Produce		json
+//	@Success		200	{object}	[]dto.DeliveryZone
+//	@Failure		500	{object}	dto.ResponseError
+//	@Router			/delivery-zones [get]
+func (zc *ZoneController) GetActiveDeliveryZones(ctx *gin.Context) {
+	data, err := zc.zoneService.GetActiveDeliveryZones(ctx)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Delivery zones retrieved successfully", data)
+}
+
+// CreateDeliveryZone godoc
+//
+//	@Summary		Create delivery zone
+//	@Description	Create a delivery zone with its flat delivery fee in rupiah
+//	@Tags			Admin Delivery Zone Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.DeliveryZoneRequest	true	"Delivery zone data"
+//	@Success		201		{object}	dto.DeliveryZone
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		409		{object}	dto.ResponseError
+//	@Router			/admin/delivery-zones [post]
+//	@Security		BearerAuth
+func (zc *ZoneController) CreateDeliveryZone(ctx *gin.Context) {
+	var req dto.DeliveryZoneRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		zc.bindError(ctx, err)
+		return
+	}
+
+	data, err := zc.zoneService.CreateDeliveryZone(ctx, req)
+	if err != nil {
+		if errors.Is(err, apperror.ErrDeliveryZoneExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "Delivery zone created successfully", data)
+}
+
+// GetDeliveryZone godoc
+//
+//	@Summary		Get delivery zone by ID
+//	@Description	Get delivery zone details by ID
+//	@Tags			Admin Delivery Zone Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Delivery zone ID"
+//	@Success		200	{object}	dto.DeliveryZone
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/delivery-zones/{id} [get]
+//	@Security		BearerAuth
+func (zc *ZoneController) GetDeliveryZone(ctx *gin.Context) {
+	var param dto.DeliveryZoneURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid delivery zone id")
+		return
+	}
+
+	data, err := zc.zoneService.GetDeliveryZone(ctx, param.ID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrDeliveryZoneNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Delivery zone retrieved successfully", data)
+}
+
+// GetDeliveryZones godoc
+//
+//	@Summary		Get all delivery zones
+//	@Description	Get delivery zones with pagination, including inactive ones
+//	@Tags			Admin Delivery Zone Management
+//	@Produce		json
+//	@Param			page	query		string	false	"Page number"
+//	@Param			search	query		string	false	"Search by name"
+//	@Success		200		{object}	[]dto.DeliveryZone
+//	@Failure		401		{object}	dto.ResponseError
+//	@Router			/admin/delivery-zones [get]
+//	@Security		BearerAuth
+func (zc *ZoneController) GetDeliveryZones(ctx *gin.Context) {
+	var req dto.DeliveryZoneParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page := 1
+	if req.Page != "" {
+		page, _ = strconv.Atoi(req.Page)
+		if page < 1 {
+			page = 1
+		}
+	}
+
+	data, totalPage, err := zc.zoneService.GetDeliveryZones(ctx, req)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var nextPage string
+	var prevPage string
+
+	if page < totalPage {
+		nextPage = fmt.Sprintf("/admin/delivery-zones?page=%d", page+1)
+	}
+	if page > 1 {
+		prevPage = fmt.Sprintf("/admin/delivery-zones?page=%d", page-1)
+	}
+
+	response.SuccessWithMeta(ctx, http.StatusOK, "Delivery zones retrieved successfully", data,
+		dto.PaginationMeta{
+			Page:      page,
+			TotalPage: totalPage,
+			NextPage:  nextPage,
+			PrevPage:  prevPage,
+		},
+	)
+}
+
+// UpdateDeliveryZone godoc
+//
+//	@Summary		Update delivery zone
+//	@Description	Update a delivery zone. Orders already placed keep the fee they were charged
+//	@Tags			Admin Delivery Zone Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int								true	"Delivery zone ID"
+//	@Param			request	body		dto.UpdateDeliveryZoneRequest	true	"Delivery zone data"
+//	@Success		200		{object}	dto.ResponseSuccess
+//	@Failure		400		{object}	dto.ResponseError
+//	@Failure		401		{object}	dto.ResponseError
+//	@Failure		404		{object}	dto.ResponseError
+//	@Failure		409		{object}	dto.ResponseError
+//	@Router			/admin/delivery-zones/{id} [patch]
+//	@Security		BearerAuth
+func (zc *ZoneController) UpdateDeliveryZone(ctx *gin.Context) {
+	var param dto.DeliveryZoneURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid delivery zone id")
+		return
+	}
+
+	var req dto.UpdateDeliveryZoneRequest
+	if err := ctx.ShouldBindWith(&req, binding.JSON); err != nil {
+		zc.bindError(ctx, err)
+		return
+	}
+
+	if err := zc.zoneService.UpdateDeliveryZone(ctx, req, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrDeliveryZoneNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrDeliveryZoneExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+
+		if errors.Is(err, apperror.ErrNoFieldsToUpdate) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Delivery zone updated successfully", nil)
+}
+
+// DeleteDeliveryZone godoc
+//
+//	@Summary		Delete delivery zone
+//	@Description	Delete a delivery zone. Addresses in the zone can no longer be used for delivery
+//	@Tags			Admin Delivery Zone Management
+//	@Produce		json
+//	@Param			id	path		int	true	"Delivery zone ID"
+//	@Success		200	{object}	dto.ResponseSuccess
+//	@Failure		401	{object}	dto.ResponseError
+//	@Failure		404	{object}	dto.ResponseError
+//	@Router			/admin/delivery-zones/{id} [delete]
+//	@Security		BearerAuth
+func (zc *ZoneController) DeleteDeliveryZone(ctx *gin.Context) {
+	var param dto.DeliveryZoneURIParam
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "Invalid delivery zone id")
+		return
+	}
+
+	if err := zc.zoneService.DeleteDeliveryZone(ctx, param.ID); err != nil {
+		if errors.Is(err, apperror.ErrDeliveryZoneNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.Error(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "Delivery zone deleted successfully", nil)
+}
+
+func (zc *ZoneController) bindError(ctx *gin.Context, err error) {
+	errStr := err.Error()
+
+	if strings.Contains(errStr, "Name") && strings.Contains(errStr, "required") {
+		response.Error(ctx, http.StatusBadRequest, "Name field cannot be empty")
+		return
+	}
+
+	if strings.Contains(errStr, "Name") {
+		response.Error(ctx, http.StatusBadRequest, "Name must be at most 100 characters")
+		return
+	}
+
+	if strings.Contains(errStr, "Fee") {
+		response.Error(ctx, http.StatusBadRequest, "Fee must be zero or more")
+		return
+	}
+
+	response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+}
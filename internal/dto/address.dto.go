@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+type DeliveryZone struct {
+	ID       int          `json:"id" example:"1"`
+	Name     string       `json:"name" example:"Jakarta Selatan"`
+	Fee      money.Amount `json:"fee" example:"10000"`
+	IsActive bool         `json:"is_active" example:"true"`
+}
+
+type UserAddress struct {
+	ID          int          `json:"id" example:"1"`
+	Label       string       `json:"label" example:"Office"`
+	Recipient   string       `json:"recipient" example:"Budi Santoso"`
+	Phone       string       `json:"phone" example:"081234567890"`
+	Address     string       `json:"address" example:"Jl. Sudirman No. 1, Jakarta"`
+	ZoneId      *int         `json:"zone_id,omitempty" example:"1"`
+	Zone        string       `json:"zone,omitempty" example:"Jakarta Selatan"`
+	DeliveryFee money.Amount `json:"delivery_fee" example:"10000"`
+	Deliverable bool         `json:"deliverable" example:"true"`
+	IsDefault   bool         `json:"is_default" example:"true"`
+}
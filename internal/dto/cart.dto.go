@@ -0,0 +1,30 @@
+package dto
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+type CartItem struct {
+	ID            int          `json:"id" example:"1"`
+	MenuID        int          `json:"menu_id" example:"1"`
+	ProductName   string       `json:"product_name" example:"Caramel Macchiato"`
+	Image         string       `json:"image" example:"1770000000_product_1.png"`
+	ProductSizeID int          `json:"product_size_id" example:"1"`
+	ProductSize   string       `json:"product_size" example:"Regular"`
+	ProductTypeID int          `json:"product_type_id" example:"1"`
+	ProductType   string       `json:"product_type" example:"Hot"`
+	Qty           int          `json:"qty" example:"2"`
+	Price         money.Amount `json:"price" example:"25000"`
+	Discount      float64      `json:"discount" example:"0"`
+	SizePrice     money.Amount `json:"size_price" example:"0"`
+	TypePrice     money.Amount `json:"type_price" example:"0"`
+	UnitPrice     money.Amount `json:"unit_price" example:"25000"`
+	Stock         int          `json:"stock" example:"10"`
+	Subtotal      money.Amount `json:"subtotal" example:"50000"`
+}
+
+type Cart struct {
+	Items    []CartItem   `json:"items"`
+	Subtotal money.Amount `json:"subtotal" example:"50000"`
+	Tax      money.Amount `json:"tax" example:"5000"`
+	Taxes    []OrderTax   `json:"taxes"`
+	Total    money.Amount `json:"total" example:"55000"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type Category struct {
+	ID           int    `json:"id" example:"1"`
+	Name         string `json:"name" example:"Coffee"`
+	ProductCount int    `json:"product_count,omitempty" example:"12"`
+}
@@ -2,6 +2,8 @@ package dto
 
 type Menu struct {
 	ID          int     `json:"id"`
+	OutletID    int     `json:"outlet_id"`
+	Outlet      string  `json:"outlet"`
 	Discount    float64 `json:"discount"`
 	ProductID   int     `json:"product_id"`
 	ProductName string  `json:"product_name"`
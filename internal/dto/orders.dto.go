@@ -1,18 +1,22 @@
 package dto
 
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
 type Order struct {
-	Order_Id string  `json:"order_id"`
-	Date     string  `json:"date"`
-	Item     string  `json:"item"`
-	Status   string  `json:"status"`
-	Total    float32 `json:"total"`
+	Order_Id     string       `json:"order_id"`
+	Date         string       `json:"date"`
+	Item         string       `json:"item"`
+	Status       string       `json:"status"`
+	Outlet       string       `json:"outlet"`
+	ScheduledFor string       `json:"scheduled_for,omitempty"`
+	Total        money.Amount `json:"total"`
 }
 
 type History struct {
-	Order_Id string  `json:"order_id"`
-	Date     string  `json:"date"`
-	Total    float32 `json:"total"`
-	Status   string  `json:"status"`
+	Order_Id string       `json:"order_id"`
+	Date     string       `json:"date"`
+	Total    money.Amount `json:"total"`
+	Status   string       `json:"status"`
 }
 
 type DetailOrder struct {
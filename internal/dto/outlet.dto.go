@@ -0,0 +1,41 @@
+package dto
+
+import "slices"
+
+type Outlet struct {
+	ID       int    `json:"id" example:"1"`
+	Name     string `json:"name" example:"Solid Coffee Kemang"`
+	Address  string `json:"address" example:"Jl. Kemang Raya No. 10, Jakarta"`
+	Phone    string `json:"phone" example:"0217190000"`
+	IsActive bool   `json:"is_active" example:"true"`
+}
+
+type OutletAdmin struct {
+	UserId   int    `json:"user_id" example:"2"`
+	Fullname string `json:"fullname" example:"John Doe"`
+	Email    string `json:"email" example:"example123@gmail.com"`
+}
+
+// OutletScope is the set of outlets the current user may manage. All is set
+// for admins granted every outlet, an empty scope allows none.
+type OutletScope struct {
+	All       bool
+	OutletIds []int
+}
+
+func (s OutletScope) Allows(outletId int) bool {
+	return s.All || slices.Contains(s.OutletIds, outletId)
+}
+
+// Filter returns the outlet ids queries should be limited to, or nil when
+// the scope covers every outlet. An empty scope gives an empty, non-nil
+// filter so it matches nothing.
+func (s OutletScope) Filter() []int {
+	if s.All {
+		return nil
+	}
+	if s.OutletIds == nil {
+		return []int{}
+	}
+	return s.OutletIds
+}
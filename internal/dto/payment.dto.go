@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+type PaymentMethod struct {
+	ID       int    `json:"id" example:"1"`
+	Name     string `json:"name" example:"Cash"`
+	Provider string `json:"provider" example:"manual"`
+	IsActive bool   `json:"is_active" example:"true"`
+}
+
+type PaymentAttempt struct {
+	ID         int          `json:"id" example:"1"`
+	OrderId    string       `json:"order_id" example:"3f1c2a9e-8b7d-4c6e-9a1b-2d3e4f5a6b7c"`
+	Provider   string       `json:"provider" example:"fake"`
+	Reference  string       `json:"reference" example:"fake-3f1c2a9e-8b7d-4c6e-9a1b-2d3e4f5a6b7c-1"`
+	Amount     money.Amount `json:"amount" example:"55000"`
+	Status     string       `json:"status" example:"settled"`
+	PaymentURL string       `json:"payment_url,omitempty" example:"https://fake-gateway.local/pay/fake-1"`
+}
@@ -1,41 +1,58 @@
 package dto
 
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
 type Products struct {
-	Id          int     `json:"id"`
-	Name        string  `json:"name"`
-	Images_Name string  `json:"image_products"`
-	Price       float64 `json:"price"`
-	Discount    float64 `json:"discount"`
-	Rating      float64 `json:"rating_product"`
+	Id          int          `json:"id"`
+	MenuId      int          `json:"menu_id"`
+	Name        string       `json:"name"`
+	Images_Name string       `json:"image_products"`
+	Price       money.Amount `json:"price"`
+	Discount    float64      `json:"discount"`
+	FinalPrice  money.Amount `json:"final_price"`
+	Rating      float64      `json:"rating_product"`
+	ReviewCount int          `json:"review_count"`
 }
 
 type DetailProduct struct {
-	IdProduct   int      `json:"id_product"`
-	ProductName string   `json:"product_name"`
-	Description string   `json:"description"`
-	Price       float64  `json:"price"`
-	IdImages    []string `json:"id_images"`
-	Images      []string `json:"images"`
+	IdProduct   int           `json:"id_product"`
+	ProductName string        `json:"product_name"`
+	Description string        `json:"description"`
+	Price       money.Amount  `json:"price"`
+	IdImages    []string      `json:"id_images"`
+	Images      []string      `json:"images"`
+	Categories  []Category    `json:"categories"`
+	Sizes       []ProductSize `json:"sizes"`
+	Types       []ProductType `json:"types"`
 }
 
 type DetailProductUser struct {
-	IdProduct    int     `json:"id_product"`
-	ProductName  string  `json:"product_name"`
-	Images       string  `json:"images"`
-	Price        float64 `json:"price"`
-	Description  string  `json:"description"`
-	Discount     float32 `json:"discount"`
-	Rating       float64 `json:"rating"`
-	Total_Review int     `json:"total_review"`
+	IdProduct    int           `json:"id_product"`
+	ProductName  string        `json:"product_name"`
+	Images       string        `json:"images"`
+	Price        money.Amount  `json:"price"`
+	Description  string        `json:"description"`
+	Discount     float32       `json:"discount"`
+	FinalPrice   money.Amount  `json:"final_price"`
+	Rating       float64       `json:"rating"`
+	Total_Review int           `json:"total_review"`
+	Sizes        []ProductSize `json:"sizes"`
+	Types        []ProductType `json:"types"`
 }
 type ProductType struct {
-	Id    int    `json:"id"`
-	Name  string `json:"name"`
-	Price int    `json:"price"`
+	Id    int          `json:"id"`
+	Name  string       `json:"name"`
+	Price money.Amount `json:"price"`
 }
 
 type ProductSize struct {
-	Id    int    `json:"id"`
-	Name  string `json:"name"`
-	Price int    `json:"price"`
+	Id    int          `json:"id"`
+	Name  string       `json:"name"`
+	Price money.Amount `json:"price"`
+}
+
+type ProductSuggestion struct {
+	Id     int    `json:"id" example:"3"`
+	MenuId int    `json:"menu_id" example:"5"`
+	Name   string `json:"name" example:"Cappuccino"`
 }
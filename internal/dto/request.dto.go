@@ -1,6 +1,11 @@
 package dto
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
 
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email" example:"example123@gmail.com"`
@@ -35,9 +40,10 @@ type UpdatePasswordRequest struct {
 }
 
 type PostProductsRequest struct {
-	ProductName string  `form:"product_name,omitempty" json:"product_name"`
-	Price       float32 `form:"price,omitempty" json:"price"`
-	Description string  `form:"description,omitempty" json:"description"`
+	ProductName string       `form:"product_name,omitempty" json:"product_name"`
+	Price       money.Amount `form:"price,omitempty" json:"price"`
+	Description string       `form:"description,omitempty" json:"description"`
+	CategoryIds []int        `form:"category_ids,omitempty" json:"category_ids"`
 }
 
 type PostImagesRequest struct {
@@ -56,26 +62,41 @@ type InsertUserRequest struct {
 }
 
 type UpdateProductsRequest struct {
-	ProductName string  `form:"product_name,omitempty" json:"product_name"`
-	Price       float32 `form:"price,omitempty" json:"price"`
-	Description string  `form:"description,omitempty" json:"description"`
+	ProductName string       `form:"product_name,omitempty" json:"product_name"`
+	Price       money.Amount `form:"price,omitempty" json:"price"`
+	Description string       `form:"description,omitempty" json:"description"`
+	CategoryIds []int        `form:"category_ids,omitempty" json:"category_ids"`
 }
 
 type UserParams struct {
 	ID int `uri:"id"`
 }
 
+// PageQueries picks a page of a list. A cursor from next_cursor continues
+// after the previous page and takes precedence over page.
+type PageQueries struct {
+	Page   string `form:"page"`
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+}
+
 type UserQueries struct {
-	Page string `form:"page"`
+	PageQueries
 }
 
 type ProductQueries struct {
 	ID       string   `form:"id"`
 	Category []string `form:"category"`
-	Page     string   `form:"page"`
 	Title    string   `form:"title"`
 	Min      string   `form:"min"`
 	Max      string   `form:"max"`
+	Outlet   int      `form:"outlet_id"`
+	Sort     string   `form:"sort" binding:"omitempty,oneof=relevance priciest cheapest recommended latest"`
+	PageQueries
+}
+
+type ProductSuggestQueries struct {
+	Q string `form:"q" binding:"required,max=100"`
 }
 
 type ForgotPasswordRequest struct {
@@ -83,53 +104,96 @@ type ForgotPasswordRequest struct {
 }
 
 type UpdateForgotPasswordRequest struct {
-	Otp             string `json:"otp_code" binding:"required,max=6" example:"123456"`
+	Email           string `json:"email" binding:"required,email" example:"example123@gmail.com"`
+	Otp             string `json:"otp_code" binding:"required,len=6,numeric" example:"123456"`
 	NewPassword     string `json:"password" binding:"required,min=8" example:"example123"`
 	ConfirmPassword string `json:"confirm_password" binding:"required,eqfield=NewPassword" example:"example123"`
 }
 
 type CreateOrder struct {
-	Shipping   string `json:"shipping" binding:"required"`
-	Payment_Id int    `json:"payment_id" binding:"required"`
+	Shipping    string `json:"shipping" binding:"required,oneof='dine in' pickup delivery" example:"delivery"`
+	AddressId   int    `json:"address_id" binding:"required_if=Shipping delivery" example:"1"`
+	Payment_Id  int    `json:"payment_id" binding:"required"`
+	VoucherCode string `json:"voucher_code" binding:"omitempty,max=20" example:"ADD10PERCENT"`
+	// ScheduledFor is the start of a slot from GET /orders/slots. Orders
+	// without it are prepared right away.
+	ScheduledFor *time.Time `json:"scheduled_for" example:"2026-01-01T12:00:00+07:00"`
 	// Status   string            `json:"status" binding:"required"`
-	Menus []CreateMenuOrder `json:"menus" binding:"required"`
+	Menus []CreateMenuOrder `json:"menus" binding:"required,min=1,dive"`
+}
+
+// OrderQuoteRequest takes the same lines as CreateOrder, so a quote can be
+// turned into an order by adding the payment method.
+type OrderQuoteRequest struct {
+	Shipping    string            `json:"shipping" binding:"required,oneof='dine in' pickup delivery" example:"delivery"`
+	AddressId   int               `json:"address_id" binding:"required_if=Shipping delivery" example:"1"`
+	VoucherCode string            `json:"voucher_code" binding:"omitempty,max=20" example:"ADD10PERCENT"`
+	Menus       []CreateMenuOrder `json:"menus" binding:"required,min=1,dive"`
 }
 
 type CreateDetailOrder struct {
-	OrderId       string  `json:"order_id"`
-	MenuId        int     `json:"menu_id"`
-	Qty           int     `json:"qty"`
-	ProductSizeId int     `json:"product_size_id"`
-	ProductTypeId int     `json:"product_type_id"`
-	Subtotal      float64 `json:"subtotal"`
+	OrderId       string       `json:"order_id"`
+	MenuId        int          `json:"menu_id"`
+	Qty           int          `json:"qty"`
+	ProductSizeId int          `json:"product_size_id"`
+	ProductTypeId int          `json:"product_type_id"`
+	Subtotal      money.Amount `json:"subtotal"`
 }
 
 type CreateMenuOrder struct {
-	MenuId        int `json:"menu_id"`
-	Qty           int `json:"qty"`
-	ProductSizeId int `json:"product_size_id"`
-	ProductTypeId int `json:"product_type_id"`
+	MenuId        int `json:"menu_id" binding:"required" example:"1"`
+	Qty           int `json:"qty" binding:"required,gt=0" example:"2"`
+	ProductSizeId int `json:"product_size_id" binding:"required" example:"1"`
+	ProductTypeId int `json:"product_type_id" binding:"required" example:"1"`
 }
 
 type UpdateOrder struct {
-	OrderId string  `json:"order_id" binding:"required"`
-	Tax     float64 `json:"tax" binding:"required"`
-	Total   float64 `json:"total" binding:"required"`
-}
-
-type UpdateStock struct {
-	MenuId int `json:"menu_id"`
-	Stock  int `json:"stock"`
+	OrderId     string       `json:"order_id" binding:"required"`
+	Tax         money.Amount `json:"tax" binding:"required"`
+	Total       money.Amount `json:"total" binding:"required"`
+	Discount    money.Amount `json:"discount"`
+	DeliveryFee money.Amount `json:"delivery_fee"`
+	VoucherId   *int         `json:"voucher_id"`
 }
 
 type UpdateStatusOrder struct {
 	OrderId string `json:"order_id" binding:"required"`
-	Status  string `json:"status" binding:"required"`
+	Status  string `json:"status" binding:"required" example:"preparing"`
+	Note    string `json:"note" binding:"omitempty,max=255" example:"Barista started the order"`
 }
 
+// AddReview is accepted as JSON, or as multipart form when a photo is
+// uploaded with the review.
 type AddReview struct {
-	DtOrderId int `json:"dt_orderid" binding:"required"`
-	Rating    int `json:"rating" binding:"required"`
+	DtOrderId int                   `json:"dt_orderid" form:"dt_orderid" binding:"required" example:"12"`
+	Rating    int                   `json:"rating" form:"rating" binding:"required,min=1,max=5" example:"5"`
+	Comment   string                `json:"comment" form:"comment" binding:"omitempty,max=1000" example:"Great coffee, friendly barista"`
+	Photo     *multipart.FileHeader `json:"-" form:"photo" swaggerignore:"true"`
+}
+
+type UpdateReviewRequest struct {
+	Rating  int                   `form:"rating" binding:"omitempty,min=1,max=5" example:"4"`
+	Comment string                `form:"comment" binding:"omitempty,max=1000" example:"A bit too sweet this time"`
+	Photo   *multipart.FileHeader `form:"photo"`
+}
+
+type HideReviewRequest struct {
+	Hidden *bool  `json:"hidden" binding:"required" example:"true"`
+	Reason string `json:"reason" binding:"omitempty,max=255" example:"Offensive language"`
+}
+
+type ReviewParams struct {
+	Page string `form:"page"`
+}
+
+type AdminReviewParams struct {
+	ProductId int    `form:"product_id"`
+	Hidden    string `form:"hidden" binding:"omitempty,oneof=true false"`
+	Page      string `form:"page"`
+}
+
+type ReviewURIParam struct {
+	ID int `uri:"id" binding:"required"`
 }
 
 type OrderQueries struct {
@@ -139,7 +203,7 @@ type OrderQueries struct {
 }
 
 type HistoryQueries struct {
-	Page string `json:"page"`
+	PageQueries
 }
 
 type ProductAdminQueries struct {
@@ -148,14 +212,16 @@ type ProductAdminQueries struct {
 }
 
 type MenuRequest struct {
+	OutletID  int     `json:"outlet_id" binding:"required" example:"1"`
 	ProductID int     `json:"product_id" binding:"required" example:"1"`
 	Stock     int     `json:"stock" binding:"required,min=0" example:"10"`
-	Discount  float64 `json:"discount" binding:"min=0,max=100" example:"0.5"`
+	Discount  float64 `json:"discount" binding:"min=0,max=100" example:"10"`
 }
 
 type MenuParams struct {
-	Search string `form:"search"`
-	Page   string `form:"page"`
+	Search   string `form:"search"`
+	OutletID int    `form:"outlet_id"`
+	PageQueries
 }
 
 type UpdateMenuRequest struct {
@@ -167,3 +233,257 @@ type UpdateMenuRequest struct {
 type MenuURIParam struct {
 	ID int `uri:"id" binding:"required"`
 }
+
+type VoucherRequest struct {
+	Code        string       `json:"code" binding:"required,max=20" example:"ADD10PERCENT"`
+	Name        string       `json:"name" binding:"required,min=3" example:"Additional discount 10%"`
+	Description string       `json:"description" example:"Get additional discount 10% for every order above Rp.100.000"`
+	Discount    float64      `json:"discount" binding:"required,gt=0,max=100" example:"10"`
+	MinOrder    money.Amount `json:"min_order" binding:"min=0" example:"100000"`
+	StartDate   string       `json:"start_date" binding:"omitempty,datetime=2006-01-02" example:"2026-02-04"`
+	EndDate     string       `json:"end_date" binding:"omitempty,datetime=2006-01-02" example:"2026-02-10"`
+	UsageLimit  int          `json:"usage_limit" binding:"min=0" example:"50"`
+}
+
+// UpdateVoucherRequest leaves nil fields unchanged. An empty start_date or
+// end_date removes that bound, a usage_limit of 0 removes the limit.
+type UpdateVoucherRequest struct {
+	Code        string        `json:"code" binding:"omitempty,max=20" example:"ADD10PERCENT"`
+	Name        string        `json:"name" binding:"omitempty,min=3" example:"Additional discount 10%"`
+	Description *string       `json:"description" example:"Get additional discount 10% for every order above Rp.100.000"`
+	Discount    *float64      `json:"discount" binding:"omitempty,gt=0,max=100" example:"10"`
+	MinOrder    *money.Amount `json:"min_order" binding:"omitempty,min=0" example:"100000"`
+	StartDate   *string       `json:"start_date" binding:"omitempty,len=0|datetime=2006-01-02" example:"2026-02-04"`
+	EndDate     *string       `json:"end_date" binding:"omitempty,len=0|datetime=2006-01-02" example:"2026-02-10"`
+	UsageLimit  *int          `json:"usage_limit" binding:"omitempty,min=0" example:"50"`
+}
+
+type VoucherParams struct {
+	Search string `form:"search"`
+	Page   string `form:"page"`
+}
+
+type VoucherURIParam struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type TaxRuleRequest struct {
+	Name          string   `json:"name" binding:"required,max=100" example:"PB1"`
+	Kind          string   `json:"kind" binding:"required,oneof=tax service" example:"tax"`
+	Rate          *float64 `json:"rate" binding:"required,min=0,max=100" example:"10"`
+	Shipping      string   `json:"shipping" binding:"omitempty,oneof='dine in' pickup delivery" example:"dine in"`
+	OutletID      *int     `json:"outlet_id" binding:"omitempty,min=1" example:"1"`
+	IsInclusive   bool     `json:"is_inclusive" example:"false"`
+	EffectiveFrom string   `json:"effective_from" binding:"required,datetime=2006-01-02" example:"2026-01-01"`
+	EffectiveTo   string   `json:"effective_to" binding:"omitempty,datetime=2006-01-02" example:"2026-12-31"`
+}
+
+// UpdateTaxRuleRequest leaves nil fields unchanged. An empty shipping makes
+// the rule apply to every shipping mode, an outlet_id of 0 to every outlet,
+// and an empty effective_to removes the end date.
+type UpdateTaxRuleRequest struct {
+	Name          string   `json:"name" binding:"omitempty,max=100" example:"PB1"`
+	Kind          string   `json:"kind" binding:"omitempty,oneof=tax service" example:"tax"`
+	Rate          *float64 `json:"rate" binding:"omitempty,min=0,max=100" example:"10"`
+	Shipping      *string  `json:"shipping" binding:"omitempty,len=0|oneof='dine in' pickup delivery" example:"dine in"`
+	OutletID      *int     `json:"outlet_id" binding:"omitempty,min=0" example:"1"`
+	IsInclusive   *bool    `json:"is_inclusive" example:"false"`
+	EffectiveFrom string   `json:"effective_from" binding:"omitempty,datetime=2006-01-02" example:"2026-01-01"`
+	EffectiveTo   *string  `json:"effective_to" binding:"omitempty,len=0|datetime=2006-01-02" example:"2026-12-31"`
+}
+
+type TaxRuleParams struct {
+	Search   string `form:"search"`
+	Shipping string `form:"shipping"`
+	OutletID int    `form:"outlet_id"`
+	Page     string `form:"page"`
+}
+
+type TaxRuleURIParam struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type DeliveryZoneRequest struct {
+	Name     string        `json:"name" binding:"required,max=100" example:"Jakarta Selatan"`
+	Fee      *money.Amount `json:"fee" binding:"required,min=0" example:"10000"`
+	IsActive *bool         `json:"is_active" example:"true"`
+}
+
+type UpdateDeliveryZoneRequest struct {
+	Name     string        `json:"name" binding:"omitempty,max=100" example:"Jakarta Selatan"`
+	Fee      *money.Amount `json:"fee" binding:"omitempty,min=0" example:"10000"`
+	IsActive *bool         `json:"is_active" example:"true"`
+}
+
+type DeliveryZoneParams struct {
+	Search string `form:"search"`
+	Page   string `form:"page"`
+}
+
+type DeliveryZoneURIParam struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type UserAddressRequest struct {
+	Label     string `json:"label" binding:"required,max=50" example:"Office"`
+	Recipient string `json:"recipient" binding:"required,max=255" example:"Budi Santoso"`
+	Phone     string `json:"phone" binding:"omitempty,max=40" example:"081234567890"`
+	Address   string `json:"address" binding:"required,min=3" example:"Jl. Sudirman No. 1, Jakarta"`
+	ZoneId    int    `json:"zone_id" binding:"required" example:"1"`
+	IsDefault bool   `json:"is_default" example:"false"`
+}
+
+// UpdateUserAddressRequest leaves empty fields unchanged. Making an address
+// the default takes the flag away from the previous default.
+type UpdateUserAddressRequest struct {
+	Label     string `json:"label" binding:"omitempty,max=50" example:"Office"`
+	Recipient string `json:"recipient" binding:"omitempty,max=255" example:"Budi Santoso"`
+	Phone     string `json:"phone" binding:"omitempty,max=40" example:"081234567890"`
+	Address   string `json:"address" binding:"omitempty,min=3" example:"Jl. Sudirman No. 1, Jakarta"`
+	ZoneId    int    `json:"zone_id" example:"1"`
+	IsDefault *bool  `json:"is_default" example:"true"`
+}
+
+type UserAddressURIParam struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// OpeningHoursRequest replaces the hours of one weekday. A slot capacity of
+// 0 keeps the store open for walk-in orders but takes no scheduled ones.
+type OpeningHoursRequest struct {
+	OpensAt      string `json:"opens_at" binding:"required,datetime=15:04" example:"08:00"`
+	ClosesAt     string `json:"closes_at" binding:"required,datetime=15:04" example:"22:00"`
+	SlotMinutes  int    `json:"slot_minutes" binding:"required,min=5,max=240" example:"30"`
+	SlotCapacity *int   `json:"slot_capacity" binding:"required,min=0" example:"10"`
+	IsOpen       *bool  `json:"is_open" binding:"required" example:"true"`
+}
+
+type OpeningHoursURIParam struct {
+	OutletID int `uri:"outlet_id" binding:"required"`
+	Weekday  int `uri:"weekday" binding:"min=0,max=6"`
+}
+
+type SlotQueries struct {
+	OutletID int    `form:"outlet_id" binding:"required"`
+	Date     string `form:"date" binding:"required,datetime=2006-01-02"`
+}
+
+type OutletRequest struct {
+	Name     string `json:"name" binding:"required,max=100" example:"Solid Coffee Kemang"`
+	Address  string `json:"address" example:"Jl. Kemang Raya No. 10, Jakarta"`
+	Phone    string `json:"phone" binding:"omitempty,max=40" example:"0217190000"`
+	IsActive *bool  `json:"is_active" example:"true"`
+}
+
+type UpdateOutletRequest struct {
+	Name     string `json:"name" binding:"omitempty,max=100" example:"Solid Coffee Kemang"`
+	Address  string `json:"address" example:"Jl. Kemang Raya No. 10, Jakarta"`
+	Phone    string `json:"phone" binding:"omitempty,max=40" example:"0217190000"`
+	IsActive *bool  `json:"is_active" example:"true"`
+}
+
+// OutletAdminsRequest replaces the admins of an outlet. An empty list leaves
+// the outlet to the admins granted every outlet.
+type OutletAdminsRequest struct {
+	UserIds []int `json:"user_ids" binding:"required" example:"2,3"`
+}
+
+type OutletParams struct {
+	Search string `form:"search"`
+	Page   string `form:"page"`
+}
+
+type OutletURIParam struct {
+	ID int `uri:"outlet_id" binding:"required"`
+}
+
+type GlobalAdminURIParam struct {
+	UserID int `uri:"user_id" binding:"required"`
+}
+
+type OrderURIParam struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type PaymentMethodRequest struct {
+	Name     string `json:"name" binding:"required,min=2" example:"Cash"`
+	Provider string `json:"provider" binding:"required" example:"manual"`
+	IsActive *bool  `json:"is_active" example:"true"`
+}
+
+type UpdatePaymentMethodRequest struct {
+	Name     string `json:"name" binding:"omitempty,min=2" example:"Cash"`
+	Provider string `json:"provider" example:"manual"`
+	IsActive *bool  `json:"is_active" example:"true"`
+}
+
+type PaymentParams struct {
+	Search string `form:"search"`
+	Page   string `form:"page"`
+}
+
+type PaymentURIParam struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type CategoryRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=255" example:"Coffee"`
+}
+
+type CategoryParams struct {
+	Search string `form:"search"`
+	Page   string `form:"page"`
+}
+
+type CategoryURIParam struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type ProductOptionRequest struct {
+	Name  string        `json:"name" binding:"required,max=255" example:"Large"`
+	Price *money.Amount `json:"price" binding:"required,min=0" example:"5000"`
+}
+
+type UpdateProductOptionRequest struct {
+	Name  string        `json:"name" binding:"omitempty,max=255" example:"Large"`
+	Price *money.Amount `json:"price" binding:"omitempty,min=0" example:"6000"`
+}
+
+type ProductOptionItem struct {
+	ID    int           `json:"id" binding:"required" example:"1"`
+	Price *money.Amount `json:"price" binding:"omitempty,min=0" example:"3000"`
+}
+
+// ProductOptionsRequest replaces the sizes and types a product offers. A list
+// that is left out stays as it is, an empty list lifts the restriction.
+type ProductOptionsRequest struct {
+	Sizes []ProductOptionItem `json:"sizes" binding:"omitempty,dive"`
+	Types []ProductOptionItem `json:"types" binding:"omitempty,dive"`
+}
+
+type ProductOptionURIParam struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type AddCartItemRequest struct {
+	MenuId        int `json:"menu_id" binding:"required" example:"1"`
+	ProductSizeId int `json:"product_size_id" binding:"required" example:"1"`
+	ProductTypeId int `json:"product_type_id" binding:"required" example:"1"`
+	Qty           int `json:"qty" binding:"required,min=1" example:"1"`
+}
+
+type UpdateCartItemRequest struct {
+	Qty int `json:"qty" binding:"required,min=1" example:"2"`
+}
+
+type CartURIParam struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type CheckoutCartRequest struct {
+	Shipping     string     `json:"shipping" binding:"required,oneof='dine in' pickup delivery" example:"dine in"`
+	AddressId    int        `json:"address_id" binding:"required_if=Shipping delivery" example:"1"`
+	Payment_Id   int        `json:"payment_id" binding:"required" example:"1"`
+	VoucherCode  string     `json:"voucher_code" binding:"omitempty,max=20" example:"ADD10PERCENT"`
+	ScheduledFor *time.Time `json:"scheduled_for" example:"2026-01-01T12:00:00+07:00"`
+}
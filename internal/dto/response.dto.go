@@ -1,5 +1,13 @@
 package dto
 
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
+)
+
 type ResponseSuccess struct {
 	Status  string `json:"status" example:"Success"`
 	Message string `json:"message" example:"Data retrieved successfully"`
@@ -27,10 +35,56 @@ type ProductResponse struct {
 }
 
 type PaginationMeta struct {
-	Page      int    `json:"page,omitempty"`
-	TotalPage int    `json:"total_page,omitempty"`
-	NextPage  string `json:"next_page,omitempty"`
-	PrevPage  string `json:"prev_page,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	TotalPage  int    `json:"total_page,omitempty"`
+	TotalItems int    `json:"total_items,omitempty"`
+	NextPage   string `json:"next_page,omitempty"`
+	PrevPage   string `json:"prev_page,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPaginationMeta describes a page of the list served at path. The links
+// keep the filters of query and the page size. Cursor pages only link
+// forward, since a cursor cannot be walked back.
+func NewPaginationMeta(path string, query url.Values, page pagination.Page, result pagination.Result) PaginationMeta {
+	meta := PaginationMeta{
+		Page:       page.Number,
+		Limit:      page.Limit,
+		TotalPage:  result.TotalPages(page.Limit),
+		TotalItems: result.Total,
+		NextCursor: result.NextCursor,
+	}
+
+	params := url.Values{}
+	for k, v := range query {
+		if k != "page" && k != "cursor" && k != "limit" {
+			params[k] = v
+		}
+	}
+	params.Set("limit", fmt.Sprint(page.Limit))
+
+	link := func(key, value string) string {
+		params.Set(key, value)
+		defer params.Del(key)
+		return path + "?" + params.Encode()
+	}
+
+	if page.Cursor != nil {
+		if result.NextCursor != "" {
+			meta.NextPage = link("cursor", result.NextCursor)
+		}
+		return meta
+	}
+
+	if page.Number < meta.TotalPage {
+		meta.NextPage = link("page", fmt.Sprint(page.Number+1))
+	}
+	if page.Number > 1 {
+		meta.PrevPage = link("page", fmt.Sprint(page.Number-1))
+	}
+
+	return meta
 }
 
 type PostProductResponse struct {
@@ -47,42 +101,91 @@ type UpdateProductResponse struct {
 }
 
 type MenuPriceResponse struct {
-	Menu_Id  int     `json:"menu_id,omitempty"`
-	Price    float64 `json:"price,omitempty"`
-	Discount float64 `json:"discount,omitempty"`
-	Stock    int     `json:"stock,omitempty"`
+	Menu_Id  int          `json:"menu_id,omitempty"`
+	Price    money.Amount `json:"price,omitempty"`
+	Discount float64      `json:"discount,omitempty"`
+	Stock    int          `json:"stock,omitempty"`
+	OutletId int          `json:"outlet_id,omitempty"`
 }
 type CreateOrderResponse struct {
-	Id_Order string  `json:"id,omitempty"`
-	Tax      float64 `json:"tax,omitempty"`
-	Total    float64 `json:"total,omitempty"`
+	Id_Order string       `json:"id,omitempty"`
+	Tax      money.Amount `json:"tax,omitempty"`
+	Total    money.Amount `json:"total,omitempty"`
 }
 
 type CreateDetailOrderResponse struct {
-	Qty      int     `json:"qty,omitempty"`
-	Subtotal float64 `json:"subtotal,omitempty"`
-	MenuId   int     `json:"menu_id,omitempty"`
+	Qty      int          `json:"qty,omitempty"`
+	Subtotal money.Amount `json:"subtotal,omitempty"`
+	MenuId   int          `json:"menu_id,omitempty"`
 }
 
 type DetailOrderResponse struct {
 	Order_Id      string               `json:"order_id"`
 	DateOrder     string               `json:"date_order"`
+	OutletId      int                  `json:"outlet_id"`
+	Outlet        string               `json:"outlet"`
 	FullName      string               `json:"fullname"`
 	Address       string               `json:"address"`
 	Phone         string               `json:"phone"`
+	DeliveryLabel string               `json:"delivery_label,omitempty"`
+	DeliveryZone  string               `json:"delivery_zone,omitempty"`
 	PaymentMethod string               `json:"payment_method"`
 	Shipping      string               `json:"shipping"`
 	Status        string               `json:"status"`
-	Total         string               `json:"total"`
+	VoucherCode   string               `json:"voucher_code,omitempty"`
+	Discount      money.Amount         `json:"discount"`
+	Tax           money.Amount         `json:"tax"`
+	Taxes         []OrderTax           `json:"taxes"`
+	DeliveryFee   money.Amount         `json:"delivery_fee"`
+	Total         money.Amount         `json:"total"`
+	ScheduledFor  string               `json:"scheduled_for,omitempty"`
+	RefundedAt    string               `json:"refunded_at,omitempty"`
 	DetailItem    []DetailItemResponse `json:"detail_item"`
+	Timeline      []OrderStatusHistory `json:"timeline"`
+}
+
+// OrderQuoteLine shows how one line is priced. Discount is the menu discount
+// in percent, UnitDiscount what it takes off one unit, and UnitPrice what one
+// unit costs with its size and type surcharges.
+type OrderQuoteLine struct {
+	MenuId        int          `json:"menu_id" example:"1"`
+	ProductSizeId int          `json:"product_size_id" example:"2"`
+	ProductTypeId int          `json:"product_type_id" example:"1"`
+	Qty           int          `json:"qty" example:"2"`
+	Price         money.Amount `json:"price" example:"25000"`
+	Discount      float64      `json:"discount" example:"10"`
+	UnitDiscount  money.Amount `json:"unit_discount" example:"2500"`
+	SizePrice     money.Amount `json:"size_price" example:"5000"`
+	TypePrice     money.Amount `json:"type_price" example:"0"`
+	UnitPrice     money.Amount `json:"unit_price" example:"27500"`
+	Subtotal      money.Amount `json:"subtotal" example:"55000"`
+}
+
+type OrderQuote struct {
+	Lines       []OrderQuoteLine `json:"lines"`
+	Subtotal    money.Amount     `json:"subtotal" example:"55000"`
+	VoucherCode string           `json:"voucher_code,omitempty" example:"ADD10PERCENT"`
+	Discount    money.Amount     `json:"discount" example:"5500"`
+	Taxes       []OrderTax       `json:"taxes"`
+	Tax         money.Amount     `json:"tax" example:"4950"`
+	DeliveryFee money.Amount     `json:"delivery_fee" example:"10000"`
+	Total       money.Amount     `json:"total" example:"64450"`
+}
+
+type OrderStatusHistory struct {
+	Status    string `json:"status" example:"paid"`
+	ActorId   *int   `json:"actor_id,omitempty" example:"1"`
+	ActorName string `json:"actor_name,omitempty" example:"Admin"`
+	Note      string `json:"note,omitempty" example:"Payment received"`
+	CreatedAt string `json:"created_at" example:"12 February 2026 09:15 AM"`
 }
 
 type DetailItemResponse struct {
-	Detail_Id   int      `json:"detail_id"`
-	ItemName    string   `json:"item_name"`
-	Qty         int      `json:"qty"`
-	Images      []string `json:"image"`
-	ProductSize string   `json:"product_size"`
-	ProductType string   `json:"product_type"`
-	Subtotal    string   `json:"subtotal"`
+	Detail_Id   int          `json:"detail_id"`
+	ItemName    string       `json:"item_name"`
+	Qty         int          `json:"qty"`
+	Images      []string     `json:"image"`
+	ProductSize string       `json:"product_size"`
+	ProductType string       `json:"product_type"`
+	Subtotal    money.Amount `json:"subtotal"`
 }
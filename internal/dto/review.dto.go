@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+type Review struct {
+	ID        int        `json:"id" example:"1"`
+	ProductId int        `json:"product_id" example:"3"`
+	UserId    int        `json:"user_id" example:"7"`
+	Fullname  string     `json:"fullname" example:"John Doe"`
+	Rating    int        `json:"rating" example:"5"`
+	Comment   string     `json:"comment" example:"Great coffee, friendly barista"`
+	Photo     string     `json:"photo,omitempty" example:"/reviews/1700000000_review_7.jpg"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-01-01T09:00:00Z"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" example:"2025-01-02T09:00:00Z"`
+}
+
+// AdminReview is a review as moderators see it, hidden or not.
+type AdminReview struct {
+	Review
+	ProductName  string     `json:"product_name" example:"Caramel Latte"`
+	Hidden       bool       `json:"hidden" example:"false"`
+	HiddenAt     *time.Time `json:"hidden_at,omitempty" example:"2025-01-03T09:00:00Z"`
+	HiddenReason string     `json:"hidden_reason,omitempty" example:"Offensive language"`
+}
+
+type RatingCount struct {
+	Rating int `json:"rating" example:"5"`
+	Total  int `json:"total" example:"12"`
+}
+
+// ProductReviews is one page of a product's visible reviews together with
+// the rating summary over all of them.
+type ProductReviews struct {
+	ProductId    int           `json:"product_id" example:"3"`
+	Rating       float64       `json:"rating" example:"4.5"`
+	TotalReview  int           `json:"total_review" example:"20"`
+	Distribution []RatingCount `json:"distribution"`
+	Reviews      []Review      `json:"reviews"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+type OpeningHours struct {
+	OutletId     int    `json:"outlet_id" example:"1"`
+	Weekday      int    `json:"weekday" example:"1"`
+	Day          string `json:"day" example:"Monday"`
+	OpensAt      string `json:"opens_at" example:"08:00"`
+	ClosesAt     string `json:"closes_at" example:"22:00"`
+	SlotMinutes  int    `json:"slot_minutes" example:"30"`
+	SlotCapacity int    `json:"slot_capacity" example:"10"`
+	IsOpen       bool   `json:"is_open" example:"true"`
+}
+
+// OrderSlot is a pickup or delivery window. Start is the value to send as
+// scheduled_for when ordering.
+type OrderSlot struct {
+	Start     string `json:"start" example:"2026-01-01T12:00:00+07:00"`
+	End       string `json:"end" example:"2026-01-01T12:30:00+07:00"`
+	Capacity  int    `json:"capacity" example:"10"`
+	Booked    int    `json:"booked" example:"3"`
+	Remaining int    `json:"remaining" example:"7"`
+	Available bool   `json:"available" example:"true"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+type TaxRule struct {
+	ID            int     `json:"id" example:"1"`
+	Name          string  `json:"name" example:"PB1"`
+	Kind          string  `json:"kind" example:"tax"`
+	Rate          float64 `json:"rate" example:"10"`
+	Shipping      string  `json:"shipping,omitempty" example:"dine in"`
+	OutletID      *int    `json:"outlet_id,omitempty" example:"1"`
+	IsInclusive   bool    `json:"is_inclusive" example:"false"`
+	EffectiveFrom string  `json:"effective_from" example:"2026-01-01"`
+	EffectiveTo   string  `json:"effective_to,omitempty" example:"2026-12-31"`
+	IsActive      bool    `json:"is_active" example:"true"`
+}
+
+type OrderTax struct {
+	Name        string       `json:"name" example:"Service charge"`
+	Kind        string       `json:"kind" example:"service"`
+	Rate        float64      `json:"rate" example:"5"`
+	IsInclusive bool         `json:"is_inclusive" example:"false"`
+	Base        money.Amount `json:"base" example:"50000"`
+	Amount      money.Amount `json:"amount" example:"2500"`
+}
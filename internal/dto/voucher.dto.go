@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+type Voucher struct {
+	ID          int          `json:"id" example:"1"`
+	Code        string       `json:"code" example:"ADD10PERCENT"`
+	Name        string       `json:"name" example:"Additional discount 10%"`
+	Description string       `json:"description" example:"Get additional discount 10% for every order above Rp.100.000"`
+	Discount    float64      `json:"discount" example:"10"`
+	MinOrder    money.Amount `json:"min_order" example:"100000"`
+	StartDate   string       `json:"start_date,omitempty" example:"2026-02-04"`
+	EndDate     string       `json:"end_date,omitempty" example:"2026-02-10"`
+	UsageLimit  int          `json:"usage_limit" example:"50"`
+	UsageCount  int          `json:"usage_count" example:"0"`
+	IsActive    bool         `json:"is_active" example:"true"`
+}
@@ -0,0 +1,129 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is one email. Every message carries a plain text and an HTML
+// version of the same content.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer delivers a message right away. Services do not call it directly,
+// they hand messages to a Queue so requests never wait on the mail server.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+var (
+	defaultQueue *Queue
+	defaultOnce  sync.Once
+)
+
+// Default returns the queue shared by every service. MAIL_DRIVER picks how
+// mail leaves: smtp sends through MAIL_SMTP_HOST, file writes each message
+// to MAIL_DIR. Outside production the default is file, so local setups
+// never send real mail.
+func Default() *Queue {
+	defaultOnce.Do(func() {
+		defaultQueue = NewQueue(newMailer(), 2, 100)
+	})
+	return defaultQueue
+}
+
+func newMailer() Mailer {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Solid Coffee <no-reply@solidcoffee.local>"
+	}
+
+	driver := os.Getenv("MAIL_DRIVER")
+	if driver == "" {
+		driver = "file"
+		if os.Getenv("APP_ENV") == "production" {
+			driver = "smtp"
+		}
+	}
+
+	if driver == "smtp" {
+		return NewSMTPMailer(
+			os.Getenv("MAIL_SMTP_HOST"),
+			os.Getenv("MAIL_SMTP_PORT"),
+			os.Getenv("MAIL_SMTP_USERNAME"),
+			os.Getenv("MAIL_SMTP_PASSWORD"),
+			from,
+		)
+	}
+
+	dir := os.Getenv("MAIL_DIR")
+	if dir == "" {
+		dir = "mails"
+	}
+	return NewFileMailer(dir, from)
+}
+
+// compose renders the message as a multipart/alternative MIME message ready
+// to be handed to a mail server or saved as an .eml file.
+func compose(from string, msg Message) ([]byte, error) {
+	sender, err := netmail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", from, err)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	domain := sender.Address[strings.LastIndex(sender.Address, "@")+1:]
+
+	var head bytes.Buffer
+	fmt.Fprintf(&head, "From: %s\r\n", sender.String())
+	fmt.Fprintf(&head, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&head, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&head, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&head, "Message-ID: <%s@%s>\r\n", hex.EncodeToString(id), domain)
+	fmt.Fprintf(&head, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&head, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+
+	return append(head.Bytes(), body.Bytes()...), nil
+}
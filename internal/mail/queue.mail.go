@@ -0,0 +1,78 @@
+package mail
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// sendTimeout bounds one delivery attempt.
+	sendTimeout = 30 * time.Second
+
+	// maxAttempts is how often a message is tried before it is dropped.
+	maxAttempts = 5
+
+	// retryDelay is the wait before the first retry. It doubles with every
+	// further attempt.
+	retryDelay = 10 * time.Second
+)
+
+type job struct {
+	msg     Message
+	attempt int
+}
+
+// Queue delivers messages in the background. Failed deliveries are retried
+// with a growing delay, so a mail server hiccup does not lose the message
+// and never slows down the request that sent it. The queue lives in memory,
+// messages still waiting when the server stops are lost.
+type Queue struct {
+	mailer Mailer
+	jobs   chan job
+}
+
+// NewQueue starts workers goroutines delivering through mailer. size is how
+// many messages may wait before Enqueue starts dropping them.
+func NewQueue(mailer Mailer, workers, size int) *Queue {
+	q := &Queue{mailer: mailer, jobs: make(chan job, size)}
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	return q
+}
+
+// Enqueue hands the message to the workers and returns right away.
+func (q *Queue) Enqueue(msg Message) {
+	q.push(job{msg: msg})
+}
+
+func (q *Queue) push(j job) {
+	select {
+	case q.jobs <- j:
+	default:
+		log.Printf("mail queue full, dropping %q to %s", j.msg.Subject, j.msg.To)
+	}
+}
+
+func (q *Queue) work() {
+	for j := range q.jobs {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err := q.mailer.Send(ctx, j.msg)
+		cancel()
+		if err == nil {
+			continue
+		}
+
+		j.attempt++
+		if j.attempt >= maxAttempts {
+			log.Printf("failed to send %q to %s after %d attempts: %v", j.msg.Subject, j.msg.To, j.attempt, err)
+			continue
+		}
+
+		delay := retryDelay << (j.attempt - 1)
+		log.Printf("failed to send %q to %s, retrying in %s: %v", j.msg.Subject, j.msg.To, delay, err)
+		retry := j
+		time.AfterFunc(delay, func() { q.push(retry) })
+	}
+}
@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+)
+
+// Every mail has a <name>.html and a <name>.txt template rendered with the
+// same data.
+//
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html"))
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt"))
+)
+
+func render(to, subject, name string, data any) (Message, error) {
+	var html, text bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return Message{}, err
+	}
+	if err := textTemplates.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{To: to, Subject: subject, Text: text.String(), HTML: html.String()}, nil
+}
+
+// PasswordReset is the mail carrying a forgot password code that is valid
+// for ttl.
+func PasswordReset(to, code string, ttl time.Duration) (Message, error) {
+	return render(to, "Your Solid Coffee password reset code", "password_reset", struct {
+		Code      string
+		ExpiresIn int
+	}{code, int(ttl.Minutes())})
+}
+
+// Welcome greets a newly registered user.
+func Welcome(to, name string) (Message, error) {
+	return render(to, "Welcome to Solid Coffee", "welcome", struct {
+		Name string
+	}{name})
+}
+
+// OrderReceipt is the receipt sent once an order is paid.
+func OrderReceipt(to string, order dto.DetailOrderResponse) (Message, error) {
+	return render(to, "Your Solid Coffee receipt for order "+order.Order_Id, "order_receipt", order)
+}
@@ -6,13 +6,18 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/cache"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
 	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware verifies the access token and makes sure its session is
+// still live, so handlers behind it never see a revoked or replaced token.
+// The verified claims and session are stored for GetClaims and GetSessionID.
+func AuthMiddleware(rdb *redis.Client) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		token := strings.Split(ctx.GetHeader("Authorization"), " ")
 		if len(token) != 2 {
@@ -36,7 +41,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		_, err := jc.VerifyToken(token[1])
 		if err != nil {
 			log.Println(err.Error())
-			if errors.Is(err, jwt.ErrTokenExpired) {
+			if errors.Is(err, apperror.ErrTokenExpired) {
 				ctx.AbortWithStatusJSON(http.StatusUnauthorized, dto.ResponseError{
 					Status:  "error",
 					Message: "Unauthorized Access",
@@ -44,11 +49,30 @@ func AuthMiddleware() gin.HandlerFunc {
 				})
 				return
 			}
-			if errors.Is(err, jwt.ErrTokenInvalidIssuer) {
+			if errors.Is(err, apperror.ErrIssuerNotFound) {
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, dto.ResponseError{
+					Status:  "error",
+					Message: "Internal Server Error",
+					Error:   "internal server error",
+				})
+				return
+			}
+			// Bad signatures, tokens of rotated out keys and foreign issuers
+			// all mean the client has to login again.
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, dto.ResponseError{
+				Status:  "error",
+				Message: "Unauthorized Access",
+				Error:   "Invalid Token, Please Login Again",
+			})
+			return
+		}
+
+		if err := cache.CheckSession(ctx, rdb, jc.UserID, jc.SessionID, token[1]); err != nil {
+			if errors.Is(err, apperror.ErrSessionExpired) || errors.Is(err, apperror.ErrInvalidSession) {
 				ctx.AbortWithStatusJSON(http.StatusUnauthorized, dto.ResponseError{
 					Status:  "error",
 					Message: "Unauthorized Access",
-					Error:   "Invalid Token, Please Login Again",
+					Error:   err.Error(),
 				})
 				return
 			}
@@ -59,7 +83,8 @@ func AuthMiddleware() gin.HandlerFunc {
 			})
 			return
 		}
-		ctx.Set("token", jc)
+
+		setClaims(ctx, jc)
 		ctx.Next()
 	}
 }
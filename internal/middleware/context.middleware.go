@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	claimsKey  = "token"
+	sessionKey = "session"
+	outletsKey = "outlets"
+)
+
+func setClaims(ctx *gin.Context, claims jwtutil.JwtClaims) {
+	ctx.Set(claimsKey, claims)
+	ctx.Set(sessionKey, claims.SessionID)
+}
+
+// GetClaims returns the claims of the access token AuthMiddleware verified.
+// It reports false on routes without AuthMiddleware.
+func GetClaims(ctx *gin.Context) (jwtutil.JwtClaims, bool) {
+	value, exists := ctx.Get(claimsKey)
+	if !exists {
+		return jwtutil.JwtClaims{}, false
+	}
+
+	claims, ok := value.(jwtutil.JwtClaims)
+	return claims, ok
+}
+
+// GetSessionID returns the live session AuthMiddleware found for the access
+// token, or an empty string on routes without AuthMiddleware.
+func GetSessionID(ctx *gin.Context) string {
+	return ctx.GetString(sessionKey)
+}
+
+// GetOutletScope returns the outlets OutletRBACMiddleware allows the user to
+// manage. Routes without OutletRBACMiddleware get an empty scope.
+func GetOutletScope(ctx *gin.Context) dto.OutletScope {
+	value, _ := ctx.Get(outletsKey)
+	scope, _ := value.(dto.OutletScope)
+	return scope
+}
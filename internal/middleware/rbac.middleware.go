@@ -1,46 +1,112 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"slices"
+	"strconv"
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
 	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func RBACMiddleware(roles ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		token, isExist := ctx.Get("token")
-		if !isExist {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, dto.ResponseError{
-				Status:  "error",
-				Message: "Forbidden Access",
-				Error:   "Access Denied",
-			})
+		if _, ok := authorizeRole(ctx, roles); !ok {
 			return
 		}
 
-		accessToken, ok := token.(jwtutil.JwtClaims)
+		ctx.Next()
+	}
+}
+
+// OutletRBACMiddleware checks the role like RBACMiddleware and stores the
+// outlets the user may manage for GetOutletScope. Admins manage every outlet
+// only with users.all_outlets set, otherwise just the outlets assigned to
+// them in outlet_admins, which may be none. Other roles are not limited.
+// A request naming an outlet_id outside the scope, as path parameter or
+// query, is rejected here.
+func OutletRBACMiddleware(db *pgxpool.Pool, roles ...string) gin.HandlerFunc {
+	outletRepository := repository.NewOutletRepository()
+
+	return func(ctx *gin.Context) {
+		accessToken, ok := authorizeRole(ctx, roles)
 		if !ok {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, dto.ResponseError{
-				Status:  "error",
-				Message: "Internal Server Error",
-				Error:   "internal server error",
-			})
 			return
 		}
 
-		isAuthorized := slices.Contains(roles, accessToken.Role)
-		if !isAuthorized {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, dto.ResponseError{
-				Status:  "error",
-				Message: "Forbidden Access",
-				Error:   "Access Denied",
-			})
-			return
+		scope := dto.OutletScope{All: true}
+		if accessToken.Role == "admin" {
+			var err error
+			scope, err = adminOutletScope(ctx, db, outletRepository, accessToken.UserID)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, dto.ResponseError{
+					Status:  "error",
+					Message: "Internal Server Error",
+					Error:   "internal server error",
+				})
+				return
+			}
+		}
+
+		for _, raw := range []string{ctx.Param("outlet_id"), ctx.Query("outlet_id")} {
+			outletId, err := strconv.Atoi(raw)
+			if err != nil {
+				continue
+			}
+			if !scope.Allows(outletId) {
+				ctx.AbortWithStatusJSON(http.StatusForbidden, dto.ResponseError{
+					Status:  "error",
+					Message: "Forbidden Access",
+					Error:   "Outlet is outside of your scope",
+				})
+				return
+			}
 		}
 
+		ctx.Set(outletsKey, scope)
 		ctx.Next()
 	}
 }
+
+func adminOutletScope(ctx context.Context, db *pgxpool.Pool, outletRepository *repository.OutletRepository, userId int) (dto.OutletScope, error) {
+	all, err := outletRepository.ManagesAllOutlets(ctx, db, userId)
+	if err != nil || all {
+		return dto.OutletScope{All: all}, err
+	}
+
+	outletIds, err := outletRepository.GetManagedOutletIds(ctx, db, userId)
+	if err != nil {
+		return dto.OutletScope{}, err
+	}
+
+	return dto.OutletScope{OutletIds: outletIds}, nil
+}
+
+// authorizeRole aborts the request unless the token's role is one of roles.
+func authorizeRole(ctx *gin.Context, roles []string) (jwtutil.JwtClaims, bool) {
+	accessToken, isExist := GetClaims(ctx)
+	if !isExist {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, dto.ResponseError{
+			Status:  "error",
+			Message: "Forbidden Access",
+			Error:   "Access Denied",
+		})
+		return jwtutil.JwtClaims{}, false
+	}
+
+	isAuthorized := slices.Contains(roles, accessToken.Role)
+	if !isAuthorized {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, dto.ResponseError{
+			Status:  "error",
+			Message: "Forbidden Access",
+			Error:   "Access Denied",
+		})
+		return jwtutil.JwtClaims{}, false
+	}
+
+	return accessToken, true
+}
@@ -0,0 +1,27 @@
+package model
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+type DeliveryZone struct {
+	ID       int          `db:"id"`
+	Name     string       `db:"name"`
+	Fee      money.Amount `db:"fee"`
+	IsActive bool         `db:"is_active"`
+}
+
+// UserAddress is an address book entry joined with its delivery zone.
+// Deliverable is false when the address has no zone or its zone is no
+// longer active.
+type UserAddress struct {
+	ID          int          `db:"id"`
+	UserId      int          `db:"user_id"`
+	ZoneId      *int         `db:"zone_id"`
+	Zone        string       `db:"zone"`
+	DeliveryFee money.Amount `db:"delivery_fee"`
+	Deliverable bool         `db:"deliverable"`
+	Label       string       `db:"label"`
+	Recipient   string       `db:"recipient"`
+	Phone       string       `db:"phone"`
+	Address     string       `db:"address"`
+	IsDefault   bool         `db:"is_default"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+type CartItem struct {
+	ID            int          `db:"id"`
+	MenuID        int          `db:"menu_id"`
+	OutletID      int          `db:"outlet_id"`
+	ProductName   string       `db:"product_name"`
+	Image         string       `db:"image"`
+	ProductSizeID int          `db:"product_size_id"`
+	ProductSize   string       `db:"product_size"`
+	SizePrice     money.Amount `db:"size_price"`
+	ProductTypeID int          `db:"product_type_id"`
+	ProductType   string       `db:"product_type"`
+	TypePrice     money.Amount `db:"type_price"`
+	Qty           int          `db:"qty"`
+	Price         money.Amount `db:"price"`
+	Discount      float64      `db:"discount"`
+	Stock         int          `db:"stock"`
+}
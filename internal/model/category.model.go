@@ -0,0 +1,7 @@
+package model
+
+type Category struct {
+	ID           int    `db:"id"`
+	Name         string `db:"name"`
+	ProductCount int    `db:"product_count"`
+}
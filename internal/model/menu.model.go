@@ -2,6 +2,8 @@ package model
 
 type Menu struct {
 	ID          int     `db:"id"`
+	OutletID    int     `db:"outlet_id"`
+	Outlet      string  `db:"outlet"`
 	Discount    float64 `db:"discount"`
 	ProductID   int     `db:"product_id"`
 	ProductName string  `db:"name"`
@@ -1,38 +1,65 @@
 package model
 
+import (
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
 type Order struct {
-	Order_Id string  `db:"order_id"`
-	Date     string  `db:"date"`
-	Item     string  `db:"item"`
-	Status   string  `db:"status"`
-	Total    float32 `db:"total"`
+	Order_Id     string       `db:"order_id"`
+	Date         string       `db:"date"`
+	Item         string       `db:"item"`
+	Status       string       `db:"status"`
+	Outlet       string       `db:"outlet"`
+	ScheduledFor *time.Time   `db:"scheduled_for"`
+	Total        money.Amount `db:"total"`
 }
 
 type History struct {
-	Order_Id string  `db:"order_id"`
-	Date     string  `db:"date"`
-	Total    float32 `db:"total"`
-	Status   string  `db:"status"`
+	Order_Id string       `db:"order_id"`
+	Date     string       `db:"date"`
+	Total    money.Amount `db:"total"`
+	Status   string       `db:"status"`
 }
 
 type DetailOrder struct {
-	Order_Id      string `db:"order_id"`
-	DateOrder     string `db:"date_order"`
-	FullName      string `db:"fullname"`
-	Address       string `db:"address"`
-	Phone         string `db:"phone"`
-	PaymentMethod string `db:"payment_method"`
-	Shipping      string `db:"shipping"`
-	Status        string `db:"status"`
-	Total         string `db:"total"`
+	Order_Id      string       `db:"order_id"`
+	UserId        int          `db:"user_id"`
+	DateOrder     string       `db:"date_order"`
+	OutletId      int          `db:"outlet_id"`
+	Outlet        string       `db:"outlet"`
+	FullName      string       `db:"fullname"`
+	Address       string       `db:"address"`
+	Phone         string       `db:"phone"`
+	DeliveryLabel string       `db:"delivery_label"`
+	DeliveryZone  string       `db:"delivery_zone"`
+	PaymentMethod string       `db:"payment_method"`
+	Shipping      string       `db:"shipping"`
+	Status        string       `db:"status"`
+	VoucherCode   string       `db:"voucher_code"`
+	Discount      money.Amount `db:"discount"`
+	Tax           money.Amount `db:"tax"`
+	DeliveryFee   money.Amount `db:"delivery_fee"`
+	Total         money.Amount `db:"total"`
+	ScheduledFor  *time.Time   `db:"scheduled_for"`
+	RefundedAt    *time.Time   `db:"refunded_at"`
 }
 
 type DetailItem struct {
-	Detail_Id   int      `db:"detail_id"`
-	ItemName    string   `db:"item_name"`
-	Qty         int      `db:"qty"`
-	Image       []string `db:"image"`
-	ProductSize string   `db:"product_size"`
-	ProductType string   `db:"product_type"`
-	Subtotal    string   `db:"subtotal"`
+	Detail_Id   int          `db:"detail_id"`
+	ItemName    string       `db:"item_name"`
+	Qty         int          `db:"qty"`
+	Image       []string     `db:"image"`
+	ProductSize string       `db:"product_size"`
+	ProductType string       `db:"product_type"`
+	Subtotal    money.Amount `db:"subtotal"`
+}
+
+type OrderStatusHistory struct {
+	Status    string `db:"status"`
+	ActorId   *int   `db:"actor_id"`
+	ActorName string `db:"actor_name"`
+	Note      string `db:"note"`
+	CreatedAt string `db:"created_at"`
 }
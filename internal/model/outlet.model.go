@@ -0,0 +1,15 @@
+package model
+
+type Outlet struct {
+	ID       int    `db:"id"`
+	Name     string `db:"name"`
+	Address  string `db:"address"`
+	Phone    string `db:"phone"`
+	IsActive bool   `db:"is_active"`
+}
+
+type OutletAdmin struct {
+	UserId   int    `db:"user_id"`
+	Fullname string `db:"fullname"`
+	Email    string `db:"email"`
+}
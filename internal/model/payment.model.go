@@ -0,0 +1,31 @@
+package model
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+type Payment struct {
+	ID       int    `db:"id"`
+	Name     string `db:"name"`
+	Provider string `db:"provider"`
+	IsActive bool   `db:"is_active"`
+}
+
+type PayableOrder struct {
+	OrderId   string       `db:"order_id"`
+	UserId    int          `db:"user_id"`
+	Status    string       `db:"status"`
+	Total     money.Amount `db:"total"`
+	PaymentId int          `db:"payment_id"`
+	Provider  string       `db:"provider"`
+	IsActive  bool         `db:"is_active"`
+}
+
+type PaymentAttempt struct {
+	ID         int          `db:"id"`
+	OrderId    string       `db:"order_id"`
+	PaymentId  int          `db:"payment_id"`
+	Provider   string       `db:"provider"`
+	Reference  string       `db:"reference"`
+	Amount     money.Amount `db:"amount"`
+	Status     string       `db:"status"`
+	PaymentURL string       `db:"payment_url"`
+}
@@ -1,41 +1,51 @@
 package model
 
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
 type Products struct {
-	Id          int     `db:"id"`
-	Name        string  `db:"name"`
-	Images_Name string  `db:"image_products"`
-	Price       float64 `db:"price"`
-	Discount    float64 `db:"discount"`
-	Rating      float64 `db:"rating_product"`
+	Id          int          `db:"id"`
+	MenuId      int          `db:"menu_id"`
+	Name        string       `db:"name"`
+	Images_Name string       `db:"image_products"`
+	Price       money.Amount `db:"price"`
+	Discount    float64      `db:"discount"`
+	Rating      float64      `db:"rating_product"`
+	ReviewCount int          `db:"review_count"`
 }
 
 type DetailProduct struct {
-	IdProduct   int      `db:"id_product"`
-	ProductName string   `db:"product_name"`
-	Description string   `db:"description"`
-	Price       float64  `db:"price"`
-	IdImages    []string `db:"id_images"`
-	Images      []string `db:"images"`
+	IdProduct   int          `db:"id_product"`
+	ProductName string       `db:"product_name"`
+	Description string       `db:"description"`
+	Price       money.Amount `db:"price"`
+	IdImages    []string     `db:"id_images"`
+	Images      []string     `db:"images"`
 }
 
 type DetailProductUser struct {
-	IdProduct    int     `db:"id_product"`
-	ProductName  string  `db:"product_name"`
-	Images       string  `db:"images"`
-	Price        float64 `db:"price"`
-	Description  string  `db:"description"`
-	Discount     float32 `db:"discount"`
-	Rating       float64 `db:"rating"`
-	Total_Review int     `db:"total_review"`
+	IdProduct    int          `db:"id_product"`
+	ProductName  string       `db:"product_name"`
+	Images       string       `db:"images"`
+	Price        money.Amount `db:"price"`
+	Description  string       `db:"description"`
+	Discount     float32      `db:"discount"`
+	Rating       float64      `db:"rating"`
+	Total_Review int          `db:"total_review"`
 }
 type ProductType struct {
-	Id    int    `json:"id"`
-	Name  string `json:"name"`
-	Price int    `json:"price"`
+	Id    int          `json:"id"`
+	Name  string       `json:"name"`
+	Price money.Amount `json:"price"`
 }
 
 type ProductSize struct {
-	Id    int    `json:"id"`
-	Name  string `json:"name"`
-	Price int    `json:"price"`
+	Id    int          `json:"id"`
+	Name  string       `json:"name"`
+	Price money.Amount `json:"price"`
+}
+
+type ProductSuggestion struct {
+	Id     int    `db:"id"`
+	MenuId int    `db:"menu_id"`
+	Name   string `db:"name"`
 }
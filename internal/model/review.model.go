@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+type Review struct {
+	ID           int        `db:"id"`
+	DtOrderId    int        `db:"dt_orderid"`
+	ProductId    int        `db:"product_id"`
+	ProductName  string     `db:"product_name"`
+	UserId       int        `db:"user_id"`
+	Fullname     string     `db:"fullname"`
+	Rating       int        `db:"rating"`
+	Comment      string     `db:"comment"`
+	Photo        *string    `db:"photo"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    *time.Time `db:"updated_at"`
+	HiddenAt     *time.Time `db:"hidden_at"`
+	HiddenReason *string    `db:"hidden_reason"`
+}
+
+// ReviewableItem is the order line a review is written for.
+type ReviewableItem struct {
+	DtOrderId int    `db:"dt_orderid"`
+	ProductId int    `db:"product_id"`
+	UserId    int    `db:"user_id"`
+	Status    string `db:"status"`
+}
+
+type RatingCount struct {
+	Rating int `db:"rating"`
+	Total  int `db:"total"`
+}
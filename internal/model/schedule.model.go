@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// OpeningHours holds an outlet's hours for one weekday, 0 being Sunday. OpensAt and
+// ClosesAt are store local times formatted as HH:MM.
+type OpeningHours struct {
+	OutletId     int    `db:"outlet_id"`
+	Weekday      int    `db:"weekday"`
+	OpensAt      string `db:"opens_at"`
+	ClosesAt     string `db:"closes_at"`
+	SlotMinutes  int    `db:"slot_minutes"`
+	SlotCapacity int    `db:"slot_capacity"`
+	IsOpen       bool   `db:"is_open"`
+}
+
+type ScheduledCount struct {
+	ScheduledFor time.Time `db:"scheduled_for"`
+	Count        int       `db:"count"`
+}
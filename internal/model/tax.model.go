@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
+type TaxRule struct {
+	ID            int        `db:"id"`
+	Name          string     `db:"name"`
+	Kind          string     `db:"kind"`
+	Rate          float64    `db:"rate"`
+	Shipping      *string    `db:"shipping"`
+	OutletID      *int       `db:"outlet_id"`
+	IsInclusive   bool       `db:"is_inclusive"`
+	EffectiveFrom time.Time  `db:"effective_from"`
+	EffectiveTo   *time.Time `db:"effective_to"`
+	IsActive      bool       `db:"is_active"`
+}
+
+type OrderTax struct {
+	TaxRuleId   *int         `db:"tax_rule_id"`
+	Name        string       `db:"name"`
+	Kind        string       `db:"kind"`
+	Rate        float64      `db:"rate"`
+	IsInclusive bool         `db:"is_inclusive"`
+	Base        money.Amount `db:"base"`
+	Amount      money.Amount `db:"amount"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
+type Voucher struct {
+	ID          int          `db:"id"`
+	Code        string       `db:"code"`
+	Name        string       `db:"name"`
+	Description string       `db:"description"`
+	Discount    float64      `db:"discount"`
+	MinOrder    money.Amount `db:"min_order"`
+	StartDate   *time.Time   `db:"start_date"`
+	EndDate     *time.Time   `db:"end_date"`
+	UsageLimit  int          `db:"usage_limit"`
+	UsageCount  int          `db:"usage_count"`
+	IsActive    bool         `db:"is_active"`
+}
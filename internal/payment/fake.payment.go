@@ -0,0 +1,117 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
+// FakeProvider is an in-memory gateway for local development and tests. Every
+// charge ends in the configured outcome, and its status can be changed later
+// with SetStatus to simulate gateway updates. Webhook events only report a
+// change, parsing one leaves the charges untouched so replays do not alter
+// what GetStatus returns.
+type FakeProvider struct {
+	outcome Status
+	seq     atomic.Int64
+	mu      sync.Mutex
+	charges map[string]Status
+}
+
+func NewFakeProvider(outcome Status) *FakeProvider {
+	return &FakeProvider{outcome: outcome, charges: map[string]Status{}}
+}
+
+func (f *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (f *FakeProvider) CreateCharge(ctx context.Context, req ChargeRequest) (Charge, error) {
+	ref := fmt.Sprintf("fake-%s-%d", req.OrderId, f.seq.Add(1))
+
+	f.mu.Lock()
+	f.charges[ref] = f.outcome
+	f.mu.Unlock()
+
+	return Charge{
+		Reference:  ref,
+		Status:     f.outcome,
+		PaymentURL: "https://fake-gateway.local/pay/" + ref,
+	}, nil
+}
+
+func (f *FakeProvider) GetStatus(ctx context.Context, reference string) (Status, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status, ok := f.charges[reference]
+	if !ok {
+		return "", apperror.ErrChargeNotFound
+	}
+	return status, nil
+}
+
+func (f *FakeProvider) Refund(ctx context.Context, reference string, amount money.Amount) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status, ok := f.charges[reference]
+	if !ok {
+		return apperror.ErrChargeNotFound
+	}
+	if status == StatusRefunded {
+		return nil
+	}
+	if status != StatusSettled {
+		return apperror.ErrRefundNotAllowed
+	}
+
+	f.charges[reference] = StatusRefunded
+	return nil
+}
+
+func (f *FakeProvider) SetStatus(reference string, status Status) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.charges[reference] = status
+}
+
+// FakeEvent is the webhook payload sent by the fake gateway. Its statuses
+// mimic the names real gateways use, so the mapping below gets exercised.
+type FakeEvent struct {
+	EventId           string       `json:"event_id"`
+	OrderId           string       `json:"order_id"`
+	Reference         string       `json:"reference"`
+	TransactionStatus string       `json:"transaction_status"`
+	Amount            money.Amount `json:"amount"`
+}
+
+var fakeStatuses = map[string]Status{
+	"pending":    StatusPending,
+	"settlement": StatusSettled,
+	"capture":    StatusSettled,
+	"deny":       StatusFailed,
+	"cancel":     StatusFailed,
+	"expire":     StatusExpired,
+	"refund":     StatusRefunded,
+}
+
+func (f *FakeProvider) ParseEvent(body []byte) (Event, error) {
+	var ev FakeEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return Event{}, apperror.ErrInvalidWebhookPayload
+	}
+
+	status, ok := fakeStatuses[ev.TransactionStatus]
+	if !ok || ev.EventId == "" || ev.Reference == "" {
+		return Event{}, apperror.ErrInvalidWebhookPayload
+	}
+
+	return Event{ID: ev.EventId, Reference: ev.Reference, Status: status}, nil
+}
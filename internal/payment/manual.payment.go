@@ -0,0 +1,36 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
+// ManualProvider covers cash and other payments confirmed by staff. Charges
+// stay pending until an admin moves the order to paid.
+type ManualProvider struct{}
+
+func NewManualProvider() *ManualProvider {
+	return &ManualProvider{}
+}
+
+func (m *ManualProvider) Name() string {
+	return "manual"
+}
+
+func (m *ManualProvider) CreateCharge(ctx context.Context, req ChargeRequest) (Charge, error) {
+	return Charge{
+		Reference: fmt.Sprintf("manual-%s-%d", req.OrderId, time.Now().UnixNano()),
+		Status:    StatusPending,
+	}, nil
+}
+
+func (m *ManualProvider) GetStatus(ctx context.Context, reference string) (Status, error) {
+	return StatusPending, nil
+}
+
+func (m *ManualProvider) Refund(ctx context.Context, reference string, amount money.Amount) error {
+	return nil
+}
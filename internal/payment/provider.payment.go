@@ -0,0 +1,83 @@
+package payment
+
+import (
+	"context"
+	"os"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
+// Status is the state of a charge as reported by a provider.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusSettled  Status = "settled"
+	StatusFailed   Status = "failed"
+	StatusExpired  Status = "expired"
+	StatusRefunded Status = "refunded"
+	// StatusRefundPending is never reported by a provider. It marks a charge
+	// whose refund was recorded but not yet confirmed by the provider.
+	StatusRefundPending Status = "refund_pending"
+)
+
+type ChargeRequest struct {
+	OrderId string
+	Amount  money.Amount
+}
+
+type Charge struct {
+	Reference  string
+	Status     Status
+	PaymentURL string
+}
+
+// PaymentProvider is implemented by every way a customer can pay. The order
+// and payment services only talk to this interface, so a real gateway can be
+// added by registering another implementation. Refund has to be idempotent per
+// reference: refunding a charge that is already refunded succeeds without
+// refunding it again, so an unfinished refund can be retried safely.
+type PaymentProvider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (Charge, error)
+	GetStatus(ctx context.Context, reference string) (Status, error)
+	Refund(ctx context.Context, reference string, amount money.Amount) error
+}
+
+// Registry looks providers up by the name stored in payments.provider.
+type Registry struct {
+	providers map[string]PaymentProvider
+}
+
+func NewRegistry(providers ...PaymentProvider) *Registry {
+	r := &Registry{providers: map[string]PaymentProvider{}}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+func (r *Registry) Get(name string) (PaymentProvider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, apperror.ErrPaymentProviderNotFound
+	}
+	return p, nil
+}
+
+// Default returns the built-in providers. The fake gateway is left out when
+// APP_ENV is production. PAYMENT_FAKE_OUTCOME=pending makes its charges wait
+// for a webhook instead of settling at once.
+func Default() *Registry {
+	providers := []PaymentProvider{NewManualProvider()}
+	if os.Getenv("APP_ENV") != "production" {
+		outcome := StatusSettled
+		if v := os.Getenv("PAYMENT_FAKE_OUTCOME"); v != "" {
+			outcome = Status(v)
+		}
+		providers = append(providers, NewFakeProvider(outcome))
+	}
+	return NewRegistry(providers...)
+}
@@ -0,0 +1,50 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 of the raw webhook body.
+const SignatureHeader = "X-Signature"
+
+// Event is a charge update sent by a gateway, already mapped onto our own
+// charge statuses.
+type Event struct {
+	ID        string
+	Reference string
+	Status    Status
+}
+
+// WebhookProvider is implemented by providers that report charge updates
+// through webhooks. Each gateway has its own payload, so parsing is left to
+// the provider.
+type WebhookProvider interface {
+	PaymentProvider
+	ParseEvent(body []byte) (Event, error)
+}
+
+// WebhookSecret reads the signing secret of a provider from
+// PAYMENT_WEBHOOK_SECRET_<PROVIDER>.
+func WebhookSecret(provider string) string {
+	return os.Getenv("PAYMENT_WEBHOOK_SECRET_" + strings.ToUpper(provider))
+}
+
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature compares in constant time. An empty secret never verifies,
+// so a provider without a configured secret cannot receive webhooks.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(strings.ToLower(signature)))
+}
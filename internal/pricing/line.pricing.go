@@ -0,0 +1,58 @@
+// Package pricing turns menu prices into what a customer pays. The product
+// listing, the cart, order quotes and order creation all price through it,
+// so the amount shown is always the amount charged.
+package pricing
+
+import "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+
+// Item is an order line before pricing. Discount is the menu discount in
+// percent and only reduces the product price, size and type surcharges are
+// charged in full for every unit.
+type Item struct {
+	Price     money.Amount
+	Discount  float64
+	SizePrice money.Amount
+	TypePrice money.Amount
+	Qty       int
+}
+
+// Line is a priced Item. UnitPrice is what one unit costs after the discount
+// and with its surcharges.
+type Line struct {
+	Item
+	UnitDiscount money.Amount
+	UnitPrice    money.Amount
+	Subtotal     money.Amount
+}
+
+// DiscountedPrice is the product price after the menu discount, as shown in
+// the product listing. The discount is rounded per unit so every cup of a
+// line costs the same.
+func DiscountedPrice(price money.Amount, discount float64) money.Amount {
+	return price - unitDiscount(price, discount)
+}
+
+func unitDiscount(price money.Amount, discount float64) money.Amount {
+	return price.Apply(money.RateFromPercent(discount), money.RoundHalfUp)
+}
+
+func PriceItem(it Item) Line {
+	discount := unitDiscount(it.Price, it.Discount)
+	unit := it.Price - discount + it.SizePrice + it.TypePrice
+
+	return Line{
+		Item:         it,
+		UnitDiscount: discount,
+		UnitPrice:    unit,
+		Subtotal:     unit.Mul(it.Qty),
+	}
+}
+
+// Subtotal sums the priced lines, before vouchers and taxes.
+func Subtotal(items []Item) money.Amount {
+	var total money.Amount
+	for _, it := range items {
+		total += PriceItem(it).Subtotal
+	}
+	return total
+}
@@ -0,0 +1,75 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
+func TestDiscountedPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		price    money.Amount
+		discount float64
+		want     money.Amount
+	}{
+		{"no discount", 20000, 0, 20000},
+		{"exact", 18500, 15, 15725},
+		{"half rupiah discount rounds up", 12345, 10, 11110},
+		{"fractional percent", 20000, 12.5, 17500},
+		{"free", 20000, 100, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiscountedPrice(tt.price, tt.discount); got != tt.want {
+				t.Errorf("DiscountedPrice(%d, %v) = %d, want %d", tt.price, tt.discount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceItem(t *testing.T) {
+	tests := []struct {
+		name string
+		item Item
+		want Line
+	}{
+		{
+			name: "plain",
+			item: Item{Price: 25000, Qty: 2},
+			want: Line{UnitPrice: 25000, Subtotal: 50000},
+		},
+		{
+			name: "surcharges are not discounted",
+			item: Item{Price: 25000, Discount: 10, SizePrice: 5000, TypePrice: 2000, Qty: 3},
+			want: Line{UnitDiscount: 2500, UnitPrice: 29500, Subtotal: 88500},
+		},
+		{
+			name: "discount is rounded per unit",
+			item: Item{Price: 12345, Discount: 10, Qty: 3},
+			want: Line{UnitDiscount: 1235, UnitPrice: 11110, Subtotal: 33330},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PriceItem(tt.item)
+			tt.want.Item = tt.item
+			if got != tt.want {
+				t.Errorf("PriceItem() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubtotal(t *testing.T) {
+	items := []Item{
+		{Price: 25000, Discount: 10, SizePrice: 5000, Qty: 2},
+		{Price: 12345, Discount: 10, Qty: 3},
+	}
+
+	if got, want := Subtotal(items), money.Amount(88330); got != want {
+		t.Errorf("Subtotal() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package pricing
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
+// Tax rule kinds. Service charges are calculated before taxes, so a tax
+// also applies to the service charge.
+const (
+	KindService = "service"
+	KindTax     = "tax"
+)
+
+// Order is a fully priced order. Tax sums every itemized charge, inclusive
+// ones included, while Total only adds the exclusive charges and the
+// delivery fee.
+type Order struct {
+	Lines       []Line
+	Subtotal    money.Amount
+	Discount    money.Amount
+	Taxes       []model.OrderTax
+	Tax         money.Amount
+	DeliveryFee money.Amount
+	Total       money.Amount
+}
+
+// PriceOrder prices every line, takes the voucher discount (in percent) off
+// the subtotal and charges the given tax rules on what is left. Vouchers and
+// taxes are rounded once on the order total, never per line, so they do not
+// depend on how the items are split. The delivery fee is added last, it is
+// neither discounted nor taxed.
+func PriceOrder(items []Item, voucherDiscount float64, deliveryFee money.Amount, rules []model.TaxRule) Order {
+	order := Order{Lines: []Line{}, DeliveryFee: deliveryFee}
+
+	for _, it := range items {
+		line := PriceItem(it)
+		order.Subtotal += line.Subtotal
+		order.Lines = append(order.Lines, line)
+	}
+
+	order.Discount = order.Subtotal.Apply(money.RateFromPercent(voucherDiscount), money.RoundHalfUp)
+	taxable := order.Subtotal - order.Discount
+
+	var extra money.Amount
+	order.Taxes, extra = ApplyTaxRules(rules, taxable)
+	for _, t := range order.Taxes {
+		order.Tax += t.Amount
+	}
+	order.Total = taxable + extra + deliveryFee
+
+	return order
+}
+
+// ApplyTaxRules itemizes the charges on a taxable amount. Service charges are
+// based on the amount itself, taxes on the amount plus the exclusive service
+// charges. Inclusive rules only report the share already inside the price,
+// exclusive ones are added on top and make up the returned extra.
+func ApplyTaxRules(rules []model.TaxRule, taxable money.Amount) ([]model.OrderTax, money.Amount) {
+	lines := []model.OrderTax{}
+	var serviceExtra, extra money.Amount
+
+	for _, kind := range []string{KindService, KindTax} {
+		base := taxable
+		if kind == KindTax {
+			base += serviceExtra
+		}
+
+		for _, r := range rules {
+			if r.Kind != kind {
+				continue
+			}
+
+			rate := money.RateFromPercent(r.Rate)
+			line := model.OrderTax{
+				TaxRuleId:   &r.ID,
+				Name:        r.Name,
+				Kind:        r.Kind,
+				Rate:        r.Rate,
+				IsInclusive: r.IsInclusive,
+				Base:        base,
+			}
+
+			if r.IsInclusive {
+				line.Amount = base.Included(rate, money.RoundHalfUp)
+			} else {
+				line.Amount = base.Apply(rate, money.RoundHalfUp)
+				extra += line.Amount
+				if kind == KindService {
+					serviceExtra += line.Amount
+				}
+			}
+
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, extra
+}
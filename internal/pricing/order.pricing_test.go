@@ -0,0 +1,179 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+)
+
+var (
+	ppn         = model.TaxRule{ID: 1, Name: "PPN", Kind: KindTax, Rate: 10}
+	ppnIncluded = model.TaxRule{ID: 2, Name: "PPN", Kind: KindTax, Rate: 10, IsInclusive: true}
+	pb1Included = model.TaxRule{ID: 3, Name: "PB1", Kind: KindTax, Rate: 11, IsInclusive: true}
+	service     = model.TaxRule{ID: 4, Name: "Service", Kind: KindService, Rate: 5}
+	serviceIncl = model.TaxRule{ID: 5, Name: "Service", Kind: KindService, Rate: 5, IsInclusive: true}
+)
+
+func TestPriceOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       []Item
+		voucher     float64
+		deliveryFee money.Amount
+		rules       []model.TaxRule
+		subtotal    money.Amount
+		discount    money.Amount
+		tax         money.Amount
+		total       money.Amount
+	}{
+		{
+			name:     "no voucher, no taxes",
+			items:    []Item{{Price: 25000, Qty: 2}},
+			subtotal: 50000,
+			total:    50000,
+		},
+		{
+			name:     "exclusive tax",
+			items:    []Item{{Price: 25000, Qty: 2}},
+			rules:    []model.TaxRule{ppn},
+			subtotal: 50000,
+			tax:      5000,
+			total:    55000,
+		},
+		{
+			name:     "inclusive tax is reported but not added",
+			items:    []Item{{Price: 18500, Qty: 3}},
+			rules:    []model.TaxRule{pb1Included},
+			subtotal: 55500,
+			tax:      5500,
+			total:    55500,
+		},
+		{
+			name:     "exclusive tax rounds half up",
+			items:    []Item{{Price: 11111, Qty: 3}, {Price: 2, Qty: 1}},
+			rules:    []model.TaxRule{ppn},
+			subtotal: 33335,
+			tax:      3334,
+			total:    36669,
+		},
+		{
+			name:     "inclusive tax of the same amount",
+			items:    []Item{{Price: 11111, Qty: 3}, {Price: 2, Qty: 1}},
+			rules:    []model.TaxRule{ppnIncluded},
+			subtotal: 33335,
+			tax:      3030,
+			total:    33335,
+		},
+		{
+			name:     "percentage voucher before tax",
+			items:    []Item{{Price: 25000, Qty: 2}},
+			voucher:  10,
+			rules:    []model.TaxRule{ppn},
+			subtotal: 50000,
+			discount: 5000,
+			tax:      4500,
+			total:    49500,
+		},
+		{
+			name:     "percentage voucher rounds half up",
+			items:    []Item{{Price: 12345, Qty: 1}},
+			voucher:  10,
+			rules:    []model.TaxRule{ppn},
+			subtotal: 12345,
+			discount: 1235,
+			tax:      1111,
+			total:    12221,
+		},
+		{
+			name:     "full voucher",
+			items:    []Item{{Price: 25000, Qty: 2}},
+			voucher:  100,
+			rules:    []model.TaxRule{ppn},
+			subtotal: 50000,
+			discount: 50000,
+			total:    0,
+		},
+		{
+			name:     "tax applies to the exclusive service charge",
+			items:    []Item{{Price: 15000, Qty: 3}},
+			rules:    []model.TaxRule{ppn, service},
+			subtotal: 45000,
+			tax:      6975,
+			total:    51975,
+		},
+		{
+			name:     "inclusive service charge does not raise the tax base",
+			items:    []Item{{Price: 15000, Qty: 3}},
+			rules:    []model.TaxRule{ppn, serviceIncl},
+			subtotal: 45000,
+			tax:      6643,
+			total:    49500,
+		},
+		{
+			name:        "delivery fee is neither discounted nor taxed",
+			items:       []Item{{Price: 25000, Qty: 2}},
+			voucher:     10,
+			deliveryFee: 10000,
+			rules:       []model.TaxRule{ppn},
+			subtotal:    50000,
+			discount:    5000,
+			tax:         4500,
+			total:       59500,
+		},
+		{
+			name:     "menu discount, surcharges, voucher, service and tax",
+			items:    []Item{{Price: 18500, Discount: 15, SizePrice: 3000, Qty: 2}, {Price: 12345, Discount: 10, TypePrice: 2000, Qty: 1}},
+			voucher:  5,
+			rules:    []model.TaxRule{service, ppn},
+			subtotal: 50560,
+			discount: 2528,
+			tax:      7445,
+			total:    55477,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PriceOrder(tt.items, tt.voucher, tt.deliveryFee, tt.rules)
+
+			if got.Subtotal != tt.subtotal || got.Discount != tt.discount || got.Tax != tt.tax || got.Total != tt.total {
+				t.Errorf("PriceOrder() subtotal %d, discount %d, tax %d, total %d, want %d, %d, %d, %d",
+					got.Subtotal, got.Discount, got.Tax, got.Total, tt.subtotal, tt.discount, tt.tax, tt.total)
+			}
+			if got.DeliveryFee != tt.deliveryFee {
+				t.Errorf("PriceOrder() delivery fee %d, want %d", got.DeliveryFee, tt.deliveryFee)
+			}
+			if len(got.Lines) != len(tt.items) {
+				t.Errorf("PriceOrder() has %d lines, want %d", len(got.Lines), len(tt.items))
+			}
+		})
+	}
+}
+
+func TestApplyTaxRules(t *testing.T) {
+	lines, extra := ApplyTaxRules([]model.TaxRule{ppn, service, pb1Included}, 45000)
+
+	want := []struct {
+		name   string
+		base   money.Amount
+		amount money.Amount
+	}{
+		{"Service", 45000, 2250},
+		{"PPN", 47250, 4725},
+		{"PB1", 47250, 4682},
+	}
+
+	if len(lines) != len(want) {
+		t.Fatalf("ApplyTaxRules() returned %d lines, want %d", len(lines), len(want))
+	}
+	for i, w := range want {
+		if lines[i].Name != w.name || lines[i].Base != w.base || lines[i].Amount != w.amount {
+			t.Errorf("line %d = %s base %d amount %d, want %s base %d amount %d",
+				i, lines[i].Name, lines[i].Base, lines[i].Amount, w.name, w.base, w.amount)
+		}
+	}
+	if extra != 6975 {
+		t.Errorf("ApplyTaxRules() extra = %d, want 6975", extra)
+	}
+}
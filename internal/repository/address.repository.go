@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type AddressRepo interface {
+	GetUserAddresses(ctx context.Context, db DBTX, userId int) ([]model.UserAddress, error)
+	GetUserAddress(ctx context.Context, db DBTX, userId, id int) (model.UserAddress, error)
+	CreateUserAddress(ctx context.Context, db DBTX, req dto.UserAddressRequest, userId int) (int, error)
+	UpdateUserAddress(ctx context.Context, db DBTX, req dto.UpdateUserAddressRequest, userId, id int) error
+	DeleteUserAddress(ctx context.Context, db DBTX, userId, id int) error
+	ClearDefaultAddress(ctx context.Context, db DBTX, userId int) error
+	EnsureDefaultAddress(ctx context.Context, db DBTX, userId int) error
+}
+
+type AddressRepository struct{}
+
+func NewAddressRepository() *AddressRepository {
+	return &AddressRepository{}
+}
+
+const userAddressColumns = `
+	a.id,
+	a.user_id,
+	a.zone_id,
+	COALESCE(z.name, ''),
+	COALESCE(z.fee, 0),
+	COALESCE(z.is_active AND z.deleted_at IS NULL, false) AS deliverable,
+	a.label,
+	a.recipient,
+	a.phone,
+	a.address,
+	a.is_default
+`
+
+func scanUserAddress(row pgx.Row) (model.UserAddress, error) {
+	var address model.UserAddress
+	err := row.Scan(
+		&address.ID,
+		&address.UserId,
+		&address.ZoneId,
+		&address.Zone,
+		&address.DeliveryFee,
+		&address.Deliverable,
+		&address.Label,
+		&address.Recipient,
+		&address.Phone,
+		&address.Address,
+		&address.IsDefault,
+	)
+	return address, err
+}
+
+func (ar *AddressRepository) GetUserAddresses(ctx context.Context, db DBTX, userId int) ([]model.UserAddress, error) {
+	query := `
+		SELECT ` + userAddressColumns + `
+		FROM user_addresses a
+		LEFT JOIN delivery_zones z ON z.id = a.zone_id
+		WHERE a.user_id = $1 AND a.deleted_at IS NULL
+		ORDER BY a.is_default DESC, a.id DESC
+	`
+
+	rows, err := db.Query(ctx, query, userId)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetAddress
+	}
+	defer rows.Close()
+
+	var addresses []model.UserAddress
+	for rows.Next() {
+		address, err := scanUserAddress(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetAddress
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, rows.Err()
+}
+
+// GetUserAddress only finds addresses of the given user, so another user's
+// address id behaves as if it did not exist.
+func (ar *AddressRepository) GetUserAddress(ctx context.Context, db DBTX, userId, id int) (model.UserAddress, error) {
+	query := `
+		SELECT ` + userAddressColumns + `
+		FROM user_addresses a
+		LEFT JOIN delivery_zones z ON z.id = a.zone_id
+		WHERE a.id = $1 AND a.user_id = $2 AND a.deleted_at IS NULL
+	`
+
+	address, err := scanUserAddress(db.QueryRow(ctx, query, id, userId))
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.UserAddress{}, apperror.ErrAddressNotFound
+		}
+		return model.UserAddress{}, apperror.ErrGetAddress
+	}
+
+	return address, nil
+}
+
+func (ar *AddressRepository) CreateUserAddress(ctx context.Context, db DBTX, req dto.UserAddressRequest, userId int) (int, error) {
+	query := `
+		INSERT INTO
+		    user_addresses (user_id, zone_id, label, recipient, phone, address, is_default)
+		VALUES
+		    ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	var id int
+	if err := db.QueryRow(ctx, query,
+		userId,
+		req.ZoneId,
+		req.Label,
+		req.Recipient,
+		req.Phone,
+		req.Address,
+		req.IsDefault,
+	).Scan(&id); err != nil {
+		log.Println(err.Error())
+		return 0, apperror.ErrCreateAddress
+	}
+
+	return id, nil
+}
+
+func (ar *AddressRepository) UpdateUserAddress(ctx context.Context, db DBTX, req dto.UpdateUserAddressRequest, userId, id int) error {
+	var sb strings.Builder
+	sb.WriteString("UPDATE user_addresses SET ")
+	args := []any{}
+
+	if req.Label != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "label = $%d", len(args)+1)
+		args = append(args, req.Label)
+	}
+
+	if req.Recipient != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "recipient = $%d", len(args)+1)
+		args = append(args, req.Recipient)
+	}
+
+	if req.Phone != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "phone = $%d", len(args)+1)
+		args = append(args, req.Phone)
+	}
+
+	if req.Address != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "address = $%d", len(args)+1)
+		args = append(args, req.Address)
+	}
+
+	if req.ZoneId != 0 {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "zone_id = $%d", len(args)+1)
+		args = append(args, req.ZoneId)
+	}
+
+	if req.IsDefault != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "is_default = $%d", len(args)+1)
+		args = append(args, *req.IsDefault)
+	}
+
+	if len(args) == 0 {
+		return apperror.ErrNoFieldsToUpdate
+	}
+
+	fmt.Fprintf(&sb, ", updated_at = NOW() WHERE id = $%d AND user_id = $%d AND deleted_at IS NULL", len(args)+1, len(args)+2)
+	args = append(args, id, userId)
+
+	ct, err := db.Exec(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateAddress
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrAddressNotFound
+	}
+
+	return nil
+}
+
+func (ar *AddressRepository) DeleteUserAddress(ctx context.Context, db DBTX, userId, id int) error {
+	query := `
+		UPDATE user_addresses
+		SET deleted_at = NOW(), is_default = false
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`
+
+	ct, err := db.Exec(ctx, query, id, userId)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteAddress
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrAddressNotFound
+	}
+
+	return nil
+}
+
+// ClearDefaultAddress drops the default flag of the user's addresses, so a
+// new default can be set without tripping the one-default unique index.
+func (ar *AddressRepository) ClearDefaultAddress(ctx context.Context, db DBTX, userId int) error {
+	query := "UPDATE user_addresses SET is_default = false WHERE user_id = $1 AND is_default AND deleted_at IS NULL"
+
+	if _, err := db.Exec(ctx, query, userId); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateAddress
+	}
+
+	return nil
+}
+
+// EnsureDefaultAddress makes the newest address the default when the user
+// has addresses but none of them is the default.
+func (ar *AddressRepository) EnsureDefaultAddress(ctx context.Context, db DBTX, userId int) error {
+	query := `
+		UPDATE user_addresses
+		SET is_default = true
+		WHERE id = (
+			SELECT id FROM user_addresses
+			WHERE user_id = $1 AND deleted_at IS NULL
+			ORDER BY id DESC
+			LIMIT 1
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM user_addresses
+			WHERE user_id = $1 AND is_default AND deleted_at IS NULL
+		)
+	`
+
+	if _, err := db.Exec(ctx, query, userId); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateAddress
+	}
+
+	return nil
+}
@@ -97,8 +97,10 @@ func (ar *AuthRepository) Register(ctx context.Context, db DBTX, req dto.Registe
 	return nil
 }
 
+// CheckEmailExists looks up an active user by the lowercased email, so
+// addresses registered with capitals are found as well.
 func (ar *AuthRepository) CheckEmailExists(ctx context.Context, db DBTX, email string) error {
-	query := "SELECT email FROM users WHERE email = $1 AND deleted_at IS NULL"
+	query := "SELECT email FROM users WHERE LOWER(email) = $1 AND deleted_at IS NULL"
 
 	var foundEmail string
 	err := db.QueryRow(ctx, query, email).Scan(&foundEmail)
@@ -112,22 +114,41 @@ func (ar *AuthRepository) CheckEmailExists(ctx context.Context, db DBTX, email s
 	return nil
 }
 
-func (ar *AuthRepository) UpdatePassword(ctx context.Context, db DBTX, email, password string) error {
+// UpdatePassword sets the password of the active user with the lowercased
+// email and returns the user's id.
+func (ar *AuthRepository) UpdatePassword(ctx context.Context, db DBTX, email, password string) (int, error) {
 	query := `
 		UPDATE users
 		SET password = $1, updated_at = NOW()
-		WHERE email = $2 AND deleted_at IS NULL
+		WHERE LOWER(email) = $2 AND deleted_at IS NULL
+		RETURNING id
 	`
 
-	ct, err := db.Exec(ctx, query, password, email)
-	if err != nil {
+	var id int
+	if err := db.QueryRow(ctx, query, password, email).Scan(&id); err != nil {
 		log.Println(err.Error())
-		return apperror.ErrUpdatePassword
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apperror.ErrUserNotFound
+		}
+		return 0, apperror.ErrUpdatePassword
 	}
 
-	if ct.RowsAffected() == 0 {
-		return apperror.ErrUserNotFound
+	return id, nil
+}
+
+// GetUserRole returns the current role of an active user, so refreshed
+// access tokens pick up role changes and deleted users cannot refresh.
+func (ar *AuthRepository) GetUserRole(ctx context.Context, db DBTX, id int) (string, error) {
+	query := "SELECT role FROM users WHERE id = $1 AND deleted_at IS NULL"
+
+	var role string
+	if err := db.QueryRow(ctx, query, id).Scan(&role); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperror.ErrUserNotFound
+		}
+		return "", err
 	}
 
-	return nil
+	return role, nil
 }
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+)
+
+type CartRepo interface {
+	GetCartItems(ctx context.Context, db DBTX, userID int) ([]model.CartItem, error)
+	AddCartItem(ctx context.Context, db DBTX, req dto.AddCartItemRequest, userID int) error
+	UpdateCartItem(ctx context.Context, db DBTX, qty, id, userID int) error
+	DeleteCartItem(ctx context.Context, db DBTX, id, userID int) error
+	ClearCart(ctx context.Context, db DBTX, userID int) error
+	GetCartOutletId(ctx context.Context, db DBTX, userID int) (int, error)
+	GetCartStock(ctx context.Context, db DBTX, userID int) (map[int]int, error)
+}
+
+type CartRepository struct{}
+
+func NewCartRepository() *CartRepository {
+	return &CartRepository{}
+}
+
+func (cr *CartRepository) GetCartItems(ctx context.Context, db DBTX, userID int) ([]model.CartItem, error) {
+	query := `
+		SELECT
+			ci.id,
+			ci.menu_id,
+			m.outlet_id,
+			p.name,
+			COALESCE((
+				SELECT pi.image
+				FROM product_images pi
+				WHERE pi.product_id = p.id AND pi.deleted_at IS NULL
+				ORDER BY pi.id
+				LIMIT 1
+			), ''),
+			ci.product_size_id,
+			ps.name,
+			COALESCE(pso.price, ps.price),
+			ci.product_type_id,
+			pt.name,
+			COALESCE(pto.price, pt.price),
+			ci.qty,
+			p.price,
+			COALESCE(m.discount, 0),
+			m.stock
+		FROM cart_items ci
+		JOIN menus m ON m.id = ci.menu_id
+		JOIN products p ON p.id = m.product_id
+		JOIN product_size ps ON ps.id = ci.product_size_id
+		JOIN product_type pt ON pt.id = ci.product_type_id
+		LEFT JOIN product_size_options pso ON pso.product_id = p.id AND pso.product_size_id = ps.id
+		LEFT JOIN product_type_options pto ON pto.product_id = p.id AND pto.product_type_id = pt.id
+		WHERE ci.user_id = $1 AND m.deleted_at IS NULL
+		ORDER BY ci.created_at, ci.id
+	`
+
+	rows, err := db.Query(ctx, query, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetCart
+	}
+	defer rows.Close()
+
+	var items []model.CartItem
+	for rows.Next() {
+		var item model.CartItem
+		if err := rows.Scan(
+			&item.ID,
+			&item.MenuID,
+			&item.OutletID,
+			&item.ProductName,
+			&item.Image,
+			&item.ProductSizeID,
+			&item.ProductSize,
+			&item.SizePrice,
+			&item.ProductTypeID,
+			&item.ProductType,
+			&item.TypePrice,
+			&item.Qty,
+			&item.Price,
+			&item.Discount,
+			&item.Stock,
+		); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetCart
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// AddCartItem merges the quantity into an existing line with the same
+// menu, size and type instead of creating a duplicate line.
+func (cr *CartRepository) AddCartItem(ctx context.Context, db DBTX, req dto.AddCartItemRequest, userID int) error {
+	query := `
+		INSERT INTO cart_items (user_id, menu_id, product_size_id, product_type_id, qty)
+		SELECT $1, m.id, $3, $4, $5
+		FROM menus m
+		WHERE m.id = $2 AND m.deleted_at IS NULL
+		ON CONFLICT (user_id, menu_id, product_size_id, product_type_id)
+		DO UPDATE SET qty = cart_items.qty + EXCLUDED.qty, updated_at = NOW()
+	`
+
+	ct, err := db.Exec(ctx, query, userID, req.MenuId, req.ProductSizeId, req.ProductTypeId, req.Qty)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "foreign key") {
+			return apperror.ErrCartInvalidItem
+		}
+		return apperror.ErrUpdateCart
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrMenuNotFound
+	}
+
+	return nil
+}
+
+func (cr *CartRepository) UpdateCartItem(ctx context.Context, db DBTX, qty, id, userID int) error {
+	query := "UPDATE cart_items SET qty = $1, updated_at = NOW() WHERE id = $2 AND user_id = $3"
+
+	ct, err := db.Exec(ctx, query, qty, id, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateCart
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrCartItemNotFound
+	}
+
+	return nil
+}
+
+func (cr *CartRepository) DeleteCartItem(ctx context.Context, db DBTX, id, userID int) error {
+	query := "DELETE FROM cart_items WHERE id = $1 AND user_id = $2"
+
+	ct, err := db.Exec(ctx, query, id, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateCart
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrCartItemNotFound
+	}
+
+	return nil
+}
+
+func (cr *CartRepository) ClearCart(ctx context.Context, db DBTX, userID int) error {
+	query := "DELETE FROM cart_items WHERE user_id = $1"
+
+	if _, err := db.Exec(ctx, query, userID); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateCart
+	}
+
+	return nil
+}
+
+// GetCartOutletId returns the outlet of the items already in the cart, or 0
+// when the cart is empty.
+func (cr *CartRepository) GetCartOutletId(ctx context.Context, db DBTX, userID int) (int, error) {
+	query := `
+		SELECT COALESCE(MIN(m.outlet_id), 0)
+		FROM cart_items ci
+		JOIN menus m ON m.id = ci.menu_id
+		WHERE ci.user_id = $1
+	`
+
+	var outletId int
+	if err := db.QueryRow(ctx, query, userID).Scan(&outletId); err != nil {
+		log.Println(err.Error())
+		return 0, apperror.ErrGetCart
+	}
+
+	return outletId, nil
+}
+
+// GetCartStock returns the current stock of every menu in the cart by cart
+// item id. Orders change stock all the time, so it is read fresh instead of
+// being served from the cached cart.
+func (cr *CartRepository) GetCartStock(ctx context.Context, db DBTX, userID int) (map[int]int, error) {
+	query := `
+		SELECT ci.id, m.stock
+		FROM cart_items ci
+		JOIN menus m ON m.id = ci.menu_id
+		WHERE ci.user_id = $1 AND m.deleted_at IS NULL
+	`
+
+	rows, err := db.Query(ctx, query, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetCart
+	}
+	defer rows.Close()
+
+	stock := map[int]int{}
+	for rows.Next() {
+		var id, qty int
+		if err := rows.Scan(&id, &qty); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetCart
+		}
+		stock[id] = qty
+	}
+
+	return stock, rows.Err()
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type CategoryRepo interface {
+	CreateCategory(ctx context.Context, db DBTX, req dto.CategoryRequest) (int, error)
+	GetCategory(ctx context.Context, db DBTX, id int) (model.Category, error)
+	GetCategories(ctx context.Context, db DBTX, req dto.CategoryParams) ([]model.Category, error)
+	GetAllCategories(ctx context.Context, db DBTX) ([]model.Category, error)
+	GetTotalPage(ctx context.Context, db DBTX, req dto.CategoryParams) (int, error)
+	UpdateCategory(ctx context.Context, db DBTX, req dto.CategoryRequest, id int) error
+	DeleteCategory(ctx context.Context, db DBTX, id int) error
+}
+
+type CategoryRepository struct{}
+
+// categoryProductsSQL selects the products linked to a category, including
+// links removed together with the category, for refreshProductSearch.
+const categoryProductsSQL = "p.id IN (SELECT product_id FROM product_categories WHERE category_id = $1)"
+
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{}
+}
+
+func (cr *CategoryRepository) CreateCategory(ctx context.Context, db DBTX, req dto.CategoryRequest) (int, error) {
+	query := "INSERT INTO categories (name) VALUES ($1) RETURNING id"
+
+	var id int
+	if err := db.QueryRow(ctx, query, req.Name).Scan(&id); err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return 0, apperror.ErrCategoryExists
+		}
+		return 0, apperror.ErrCreateCategory
+	}
+
+	return id, nil
+}
+
+func (cr *CategoryRepository) GetCategory(ctx context.Context, db DBTX, id int) (model.Category, error) {
+	query := `
+		SELECT c.id, c.name, COUNT(pc.id)
+		FROM categories c
+		LEFT JOIN product_categories pc ON pc.category_id = c.id AND pc.deleted_at IS NULL
+		WHERE c.id = $1 AND c.deleted_at IS NULL
+		GROUP BY c.id
+	`
+
+	var category model.Category
+	if err := db.QueryRow(ctx, query, id).Scan(&category.ID, &category.Name, &category.ProductCount); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Category{}, apperror.ErrCategoryNotFound
+		}
+		return model.Category{}, apperror.ErrGetCategory
+	}
+
+	return category, nil
+}
+
+func (cr *CategoryRepository) GetCategories(ctx context.Context, db DBTX, req dto.CategoryParams) ([]model.Category, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString(`
+		SELECT c.id, c.name, COUNT(pc.id)
+		FROM categories c
+		LEFT JOIN product_categories pc ON pc.category_id = c.id AND pc.deleted_at IS NULL
+		WHERE c.deleted_at IS NULL
+	`)
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND c.name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	limit := 5
+	offset := 0
+	if req.Page != "" {
+		page, _ := strconv.Atoi(req.Page)
+		if page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	fmt.Fprintf(&sb, " GROUP BY c.id ORDER BY c.id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.Query(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetCategory
+	}
+	defer rows.Close()
+
+	var categories []model.Category
+	for rows.Next() {
+		var category model.Category
+		if err := rows.Scan(&category.ID, &category.Name, &category.ProductCount); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetCategory
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+func (cr *CategoryRepository) GetAllCategories(ctx context.Context, db DBTX) ([]model.Category, error) {
+	query := "SELECT id, name FROM categories WHERE deleted_at IS NULL ORDER BY name"
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetCategory
+	}
+	defer rows.Close()
+
+	var categories []model.Category
+	for rows.Next() {
+		var category model.Category
+		if err := rows.Scan(&category.ID, &category.Name); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetCategory
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+func (cr *CategoryRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.CategoryParams) (int, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT COUNT(id) FROM categories WHERE deleted_at IS NULL")
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	var totalCategories int
+	if err := db.QueryRow(ctx, sb.String(), args...).Scan(&totalCategories); err != nil {
+		return 0, err
+	}
+
+	itemsPerPage := 5
+	totalPage := int(math.Ceil(float64(totalCategories) / float64(itemsPerPage)))
+
+	return totalPage, nil
+}
+
+func (cr *CategoryRepository) UpdateCategory(ctx context.Context, db DBTX, req dto.CategoryRequest, id int) error {
+	query := "UPDATE categories SET name = $1, updated_at = NOW() WHERE id = $2 AND deleted_at IS NULL"
+
+	ct, err := db.Exec(ctx, query, req.Name, id)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return apperror.ErrCategoryExists
+		}
+		return apperror.ErrUpdateCategory
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrCategoryNotFound
+	}
+
+	if err := refreshProductSearch(ctx, db, categoryProductsSQL, id); err != nil {
+		return apperror.ErrUpdateCategory
+	}
+
+	return nil
+}
+
+// DeleteCategory soft deletes the category together with its product links,
+// so deleted categories no longer show up on products or match filters.
+func (cr *CategoryRepository) DeleteCategory(ctx context.Context, db DBTX, id int) error {
+	query := "UPDATE categories SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	ct, err := db.Exec(ctx, query, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteCategory
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrCategoryNotFound
+	}
+
+	query = "UPDATE product_categories SET deleted_at = NOW() WHERE category_id = $1 AND deleted_at IS NULL"
+	if _, err := db.Exec(ctx, query, id); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteCategory
+	}
+
+	if err := refreshProductSearch(ctx, db, categoryProductsSQL, id); err != nil {
+		return apperror.ErrDeleteCategory
+	}
+
+	return nil
+}
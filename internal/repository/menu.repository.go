@@ -5,21 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math"
-	"strconv"
 	"strings"
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/jackc/pgx/v5"
 )
 
 type MenuRepo interface {
 	CreateMenu(ctx context.Context, db DBTX, req dto.MenuRequest) error
 	GetMenu(ctx context.Context, db DBTX, id int) (model.Menu, error)
-	GetMenus(ctx context.Context, db DBTX, req dto.MenuParams) ([]model.Menu, error)
-	GetTotalPage(ctx context.Context, db DBTX, req dto.MenuParams) (int, error)
+	GetMenus(ctx context.Context, db DBTX, req dto.MenuParams, page pagination.Page, outletIds []int) ([]model.Menu, string, error)
+	CountMenus(ctx context.Context, db DBTX, req dto.MenuParams, outletIds []int) (int, error)
 	UpdateMenu(ctx context.Context, db DBTX, req dto.UpdateMenuRequest, id int) error
 	DeleteMenu(ctx context.Context, db DBTX, id int) error
 }
@@ -33,12 +32,12 @@ func NewMenuRepository() *MenuRepository {
 func (mr *MenuRepository) CreateMenu(ctx context.Context, db DBTX, req dto.MenuRequest) error {
 	query := `
 		INSERT INTO
-		    menus (discount, stock, product_id)
+		    menus (discount, stock, product_id, outlet_id)
 		VALUES
-		    ($1, $2, $3)
+		    ($1, $2, $3, $4)
 	`
 
-	_, err := db.Exec(ctx, query, req.Discount, req.Stock, req.ProductID)
+	_, err := db.Exec(ctx, query, req.Discount, req.Stock, req.ProductID, req.OutletID)
 	if err != nil {
 		log.Println(err.Error())
 		return err
@@ -51,6 +50,8 @@ func (mr *MenuRepository) GetMenu(ctx context.Context, db DBTX, id int) (model.M
 	query := `
 		SELECT
 			m.id,
+			m.outlet_id,
+			o.name AS outlet,
 			m.discount,
 			m.product_id,
 			p.name AS product_name,
@@ -58,6 +59,7 @@ func (mr *MenuRepository) GetMenu(ctx context.Context, db DBTX, id int) (model.M
 		FROM
 			menus m
 		JOIN products p ON p.id = m.product_id
+		JOIN outlets o ON o.id = m.outlet_id
 		WHERE m.id = $1 AND m.deleted_at IS NULL
 	`
 
@@ -66,6 +68,8 @@ func (mr *MenuRepository) GetMenu(ctx context.Context, db DBTX, id int) (model.M
 	var menu model.Menu
 	err := row.Scan(
 		&menu.ID,
+		&menu.OutletID,
+		&menu.Outlet,
 		&menu.Discount,
 		&menu.ProductID,
 		&menu.ProductName,
@@ -83,68 +87,99 @@ func (mr *MenuRepository) GetMenu(ctx context.Context, db DBTX, id int) (model.M
 	return menu, nil
 }
 
-func (mr *MenuRepository) GetMenus(ctx context.Context, db DBTX, req dto.MenuParams) ([]model.Menu, error) {
+// menuOrder is the keyset order of the menu list.
+var menuOrder = pagination.Order{Name: "menus", Keys: []pagination.Key{{Expr: "m.id", Type: "int"}}}
+
+// GetMenus lists a page of the menus of every outlet and returns the cursor
+// of the next page, empty on the last page. A non-nil outletIds limits the
+// list to those outlets.
+func (mr *MenuRepository) GetMenus(ctx context.Context, db DBTX, req dto.MenuParams, page pagination.Page, outletIds []int) ([]model.Menu, string, error) {
 	var sb strings.Builder
 	args := []any{}
 
 	sb.WriteString(`
 		SELECT
 			m.id,
+			m.outlet_id,
+			o.name AS outlet,
 			m.discount,
 			m.product_id,
 			p.name AS product_name,
-			m.stock
+			m.stock,
+			` + menuOrder.Select() + `
 		FROM
 			menus m
 		JOIN products p ON p.id = m.product_id
+		JOIN outlets o ON o.id = m.outlet_id
 		WHERE m.deleted_at IS NULL
 	`)
 
+	if outletIds != nil {
+		fmt.Fprintf(&sb, " AND m.outlet_id = ANY($%d)", len(args)+1)
+		args = append(args, outletIds)
+	}
+
+	if req.OutletID != 0 {
+		fmt.Fprintf(&sb, " AND m.outlet_id = $%d", len(args)+1)
+		args = append(args, req.OutletID)
+	}
+
 	if req.Search != "" {
 		fmt.Fprintf(&sb, " AND p.name ILIKE $%d", len(args)+1)
 		args = append(args, "%"+req.Search+"%")
 	}
 
-	limit := 5
-	offset := 0
-	if req.Page != "" {
-		page, _ := strconv.Atoi(req.Page)
-		if page > 0 {
-			offset = (page - 1) * limit
-		}
+	seek, seekArgs, err := menuOrder.Seek(page, len(args)+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if seek != "" {
+		sb.WriteString(" AND " + seek)
+		args = append(args, seekArgs...)
 	}
 
-	fmt.Fprintf(&sb, " LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
-	args = append(args, limit, offset)
+	fmt.Fprintf(&sb, " ORDER BY %s LIMIT $%d OFFSET $%d", menuOrder.OrderBy(), len(args)+1, len(args)+2)
+	args = append(args, page.Fetch(), page.Offset())
 
 	rows, err := db.Query(ctx, sb.String(), args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer rows.Close()
 
 	var menus []model.Menu
+	var keys [][]string
 	for rows.Next() {
 		var menu model.Menu
+		var key []string
 
 		err := rows.Scan(
 			&menu.ID,
+			&menu.OutletID,
+			&menu.Outlet,
 			&menu.Discount,
 			&menu.ProductID,
 			&menu.ProductName,
 			&menu.Stock,
+			&key,
 		)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		menus = append(menus, menu)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
 	}
 
-	return menus, nil
+	menus, next := pagination.Trim(menuOrder, page, menus, keys)
+	return menus, next, nil
 }
 
-func (mr *MenuRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.MenuParams) (int, error) {
+// CountMenus counts the menus GetMenus lists with the same filters.
+func (mr *MenuRepository) CountMenus(ctx context.Context, db DBTX, req dto.MenuParams, outletIds []int) (int, error) {
 	var sb strings.Builder
 	args := []any{}
 
@@ -157,6 +192,16 @@ func (mr *MenuRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.Men
 		WHERE m.deleted_at IS NULL
 	`)
 
+	if outletIds != nil {
+		fmt.Fprintf(&sb, " AND m.outlet_id = ANY($%d)", len(args)+1)
+		args = append(args, outletIds)
+	}
+
+	if req.OutletID != 0 {
+		fmt.Fprintf(&sb, " AND m.outlet_id = $%d", len(args)+1)
+		args = append(args, req.OutletID)
+	}
+
 	if req.Search != "" {
 		fmt.Fprintf(&sb, " AND p.name ILIKE $%d", len(args)+1)
 		args = append(args, "%"+req.Search+"%")
@@ -168,10 +213,7 @@ func (mr *MenuRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.Men
 		return 0, err
 	}
 
-	itemsPerPage := 5
-	totalPage := int(math.Ceil(float64(totalMenus) / float64(itemsPerPage)))
-
-	return totalPage, nil
+	return totalMenus, nil
 }
 
 func (mr *MenuRepository) UpdateMenu(ctx context.Context, db DBTX, req dto.UpdateMenuRequest, id int) error {
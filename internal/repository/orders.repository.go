@@ -2,13 +2,17 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"math"
 	"strings"
 
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -22,26 +26,33 @@ func NewOrderRepository() *OrderRepository {
 }
 
 func (o OrderRepository) GetPriceByMenuId(ctx context.Context, db DBTX, menuId int) (dto.MenuPriceResponse, error) {
-	var price, discount float64
+	var price money.Amount
+	var discount float64
 	var stock int
+	var outletId int
 
 	sqlStr :=
 		` SELECT 
 				m.id, 
 				p.price, 
-				m.discount,
-				m.stock
+				COALESCE(m.discount, 0),
+				m.stock,
+				m.outlet_id
 			FROM menus m
 			JOIN products p ON p.id = m.product_id
-			WHERE m.id = $1
+			JOIN outlets ot ON ot.id = m.outlet_id
+			WHERE m.id = $1 AND m.deleted_at IS NULL AND ot.is_active AND ot.deleted_at IS NULL
 		`
 
 	values := []any{menuId}
 
 	row := db.QueryRow(ctx, sqlStr, values...)
 
-	if err := row.Scan(&menuId, &price, &discount, &stock); err != nil {
+	if err := row.Scan(&menuId, &price, &discount, &stock, &outletId); err != nil {
 		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.MenuPriceResponse{}, apperror.ErrMenuNotFound
+		}
 		return dto.MenuPriceResponse{}, err
 	}
 
@@ -50,16 +61,17 @@ func (o OrderRepository) GetPriceByMenuId(ctx context.Context, db DBTX, menuId i
 		Price:    price,
 		Discount: discount,
 		Stock:    stock,
+		OutletId: outletId,
 	}, nil
 }
 
-func (o OrderRepository) CreateOrder(ctx context.Context, db DBTX, post dto.CreateOrder, userID int) (dto.CreateOrderResponse, error) {
+func (o OrderRepository) CreateOrder(ctx context.Context, db DBTX, post dto.CreateOrder, userID, outletId int) (dto.CreateOrderResponse, error) {
 	var orderId string
-	var tax, total float64
+	var tax, total money.Amount
 
-	sqlStr := "INSERT INTO orders(shipping, tax, total, user_id, payment_id) VALUES (($1), ($2), ($3), ($4), ($5)) RETURNING id, tax, total"
+	sqlStr := "INSERT INTO orders(shipping, tax, total, user_id, payment_id, scheduled_for, outlet_id) VALUES (($1), ($2), ($3), ($4), ($5), ($6), ($7)) RETURNING id, tax, total"
 
-	values := []any{post.Shipping, 0, 0, userID, post.Payment_Id}
+	values := []any{post.Shipping, 0, 0, userID, post.Payment_Id, post.ScheduledFor, outletId}
 
 	row := db.QueryRow(ctx, sqlStr, values...)
 
@@ -78,7 +90,7 @@ func (o OrderRepository) CreateOrder(ctx context.Context, db DBTX, post dto.Crea
 func (o OrderRepository) CreateDetailOrder(ctx context.Context, db DBTX, dt dto.CreateDetailOrder) (dto.CreateDetailOrderResponse, error) {
 
 	var qty, menuId int
-	var subtotal float64
+	var subtotal money.Amount
 
 	sqlStr := "INSERT INTO dt_order(order_id, qty, subtotal, menu_id, product_size_id, product_type_id) VALUES (($1), ($2), ($3), ($4), ($5), ($6)) RETURNING qty, subtotal, menu_id"
 
@@ -98,22 +110,41 @@ func (o OrderRepository) CreateDetailOrder(ctx context.Context, db DBTX, dt dto.
 	}, nil
 }
 
-func (o OrderRepository) UpdateStockByIdMenu(ctx context.Context, db DBTX, updt dto.UpdateStock) (pgconn.CommandTag, error) {
+// DecrementStockByIdMenu takes qty from the menu only when enough stock is
+// left. Zero rows affected means the stock was insufficient.
+func (o OrderRepository) DecrementStockByIdMenu(ctx context.Context, db DBTX, menuId, qty int) (pgconn.CommandTag, error) {
 	sqlStr := `
-			UPDATE menus SET stock = $1 WHERE id = $2
+			UPDATE menus SET stock = stock - $1 WHERE id = $2 AND stock >= $1
 			`
-	values := []any{updt.Stock, updt.MenuId}
+	values := []any{qty, menuId}
 	return db.Exec(ctx, sqlStr, values...)
 }
 
 func (o OrderRepository) UpdateOrderById(ctx context.Context, db DBTX, updt dto.UpdateOrder) (pgconn.CommandTag, error) {
 	sqlStr := `
-			UPDATE orders SET tax = $1, total = $2 WHERE id = $3
+			UPDATE orders SET tax = $1, total = $2, discount = $3, voucher_id = $4, delivery_fee = $5 WHERE id = $6
 			`
-	values := []any{updt.Tax, updt.Total, updt.OrderId}
+	values := []any{updt.Tax, updt.Total, updt.Discount, updt.VoucherId, updt.DeliveryFee, updt.OrderId}
 	return db.Exec(ctx, sqlStr, values...)
 }
 
+// SetDeliveryAddress copies the address onto the order, so later edits to
+// the address book do not change where a past order was sent.
+func (o OrderRepository) SetDeliveryAddress(ctx context.Context, db DBTX, orderId string, address model.UserAddress) error {
+	sqlStr := `
+			UPDATE orders
+			SET address_id = $1, delivery_label = $2, delivery_recipient = $3, delivery_phone = $4, delivery_address = $5, delivery_zone = $6
+			WHERE id = $7
+			`
+	values := []any{address.ID, address.Label, address.Recipient, address.Phone, address.Address, address.Zone, orderId}
+	if _, err := db.Exec(ctx, sqlStr, values...); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
 func (o OrderRepository) UpdateStatusByOrderId(ctx context.Context, db DBTX, updt dto.UpdateStatusOrder) (pgconn.CommandTag, error) {
 	sqlStr := `
 			UPDATE orders SET status = $1 WHERE id = $2
@@ -122,21 +153,229 @@ func (o OrderRepository) UpdateStatusByOrderId(ctx context.Context, db DBTX, upd
 	return db.Exec(ctx, sqlStr, values...)
 }
 
-func (or *OrderRepository) AddReview(ctx context.Context, db DBTX, req dto.AddReview) error {
-	query := "INSERT INTO reviews (rating, dt_orderid) VALUES ($1, $2)"
+// GetOrderStatusForUpdate locks the order row so concurrent status changes
+// are applied one after another.
+func (o OrderRepository) GetOrderStatusForUpdate(ctx context.Context, db DBTX, orderId string) (string, error) {
+	sqlStr := "SELECT status FROM orders WHERE id::text = $1 FOR UPDATE"
+
+	var status string
+	if err := db.QueryRow(ctx, sqlStr, orderId).Scan(&status); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperror.ErrOrderNotFound
+		}
+		return "", apperror.ErrUpdateOrderStatus
+	}
+
+	return status, nil
+}
+
+// GetOrderOutletId returns the outlet the order was placed at.
+func (o OrderRepository) GetOrderOutletId(ctx context.Context, db DBTX, orderId string) (int, error) {
+	sqlStr := "SELECT outlet_id FROM orders WHERE id::text = $1"
+
+	var outletId int
+	if err := db.QueryRow(ctx, sqlStr, orderId).Scan(&outletId); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apperror.ErrOrderNotFound
+		}
+		return 0, apperror.ErrUpdateOrderStatus
+	}
+
+	return outletId, nil
+}
+
+// HasSettledCharge reports whether the latest charge of the order was paid
+// through its provider, or is being refunded, so cancelling the order has to
+// go through a refund.
+func (o OrderRepository) HasSettledCharge(ctx context.Context, db DBTX, orderId string) (bool, error) {
+	sqlStr := `
+		SELECT COALESCE((
+			SELECT status IN ('settled', 'refund_pending')
+			FROM payment_attempts
+			WHERE order_id::text = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT 1
+		), false)
+	`
+
+	var settled bool
+	if err := db.QueryRow(ctx, sqlStr, orderId).Scan(&settled); err != nil {
+		log.Println(err.Error())
+		return false, apperror.ErrUpdateOrderStatus
+	}
+
+	return settled, nil
+}
+
+// GetOrderEmail returns the email of the user who placed the order.
+func (o OrderRepository) GetOrderEmail(ctx context.Context, db DBTX, orderId string) (string, error) {
+	sqlStr := "SELECT u.email FROM orders o JOIN users u ON u.id = o.user_id WHERE o.id::text = $1"
+
+	var email string
+	if err := db.QueryRow(ctx, sqlStr, orderId).Scan(&email); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperror.ErrOrderNotFound
+		}
+		return "", apperror.ErrGetOrderHistory
+	}
+
+	return email, nil
+}
 
-	_, err := db.Exec(ctx, query, req.Rating, req.DtOrderId)
+// MarkRefunded records when the order's payment was refunded. A repeated
+// refund keeps the first time.
+func (o OrderRepository) MarkRefunded(ctx context.Context, db DBTX, orderId string) error {
+	sqlStr := "UPDATE orders SET refunded_at = COALESCE(refunded_at, NOW()) WHERE id::text = $1"
+
+	cmd, err := db.Exec(ctx, sqlStr, orderId)
 	if err != nil {
 		log.Println(err.Error())
-		return err
+		return apperror.ErrUpdateOrderStatus
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperror.ErrOrderNotFound
+	}
+
+	return nil
+}
+
+// MarkStockRestored flags the order as restocked and reports whether this
+// call did it, so a repeated cancel never returns the same stock twice.
+func (o OrderRepository) MarkStockRestored(ctx context.Context, db DBTX, orderId string) (bool, error) {
+	sqlStr := "UPDATE orders SET stock_restored_at = NOW() WHERE id::text = $1 AND stock_restored_at IS NULL"
+
+	cmd, err := db.Exec(ctx, sqlStr, orderId)
+	if err != nil {
+		log.Println(err.Error())
+		return false, apperror.ErrRestoreStock
+	}
+
+	return cmd.RowsAffected() > 0, nil
+}
+
+// RestoreStockByOrderId gives every line's qty back to its menu.
+func (o OrderRepository) RestoreStockByOrderId(ctx context.Context, db DBTX, orderId string) error {
+	sqlStr := `
+			UPDATE menus m
+			SET stock = m.stock + dt.qty
+			FROM (
+				SELECT menu_id, SUM(qty) AS qty
+				FROM dt_order
+				WHERE order_id::text = $1
+				GROUP BY menu_id
+			) dt
+			WHERE m.id = dt.menu_id
+			`
+
+	if _, err := db.Exec(ctx, sqlStr, orderId); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrRestoreStock
 	}
 
 	return nil
 }
 
-func (o *OrderRepository) GetAllOrderByAdmin(ctx context.Context, db DBTX, status string, orderId string, page int) ([]model.Order, error) {
+func (o OrderRepository) CreateStatusHistory(ctx context.Context, db DBTX, orderId, status string, actorId int, note string) error {
+	sqlStr := `
+			INSERT INTO order_status_history (order_id, status, actor_id, note)
+			VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, ''))
+			`
+
+	if _, err := db.Exec(ctx, sqlStr, orderId, status, actorId, note); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateOrderStatus
+	}
+
+	return nil
+}
+
+func (o OrderRepository) GetStatusHistory(ctx context.Context, db DBTX, orderId string) ([]model.OrderStatusHistory, error) {
+	sqlStr := `
+		SELECT
+		h.status,
+		h.actor_id,
+		COALESCE(u.fullname, ''),
+		COALESCE(h.note, ''),
+		TO_CHAR(h.created_at, 'DD FMMonth YYYY HH12:MI AM')
+		FROM order_status_history h
+		LEFT JOIN users u ON u.id = h.actor_id
+		WHERE h.order_id::text = $1
+		ORDER BY h.created_at, h.id
+	`
+
+	rows, err := db.Query(ctx, sqlStr, orderId)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetOrderHistory
+	}
+	defer rows.Close()
+
+	var histories []model.OrderStatusHistory
+	for rows.Next() {
+		var history model.OrderStatusHistory
+		if err := rows.Scan(&history.Status, &history.ActorId, &history.ActorName, &history.Note, &history.CreatedAt); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetOrderHistory
+		}
+		histories = append(histories, history)
+	}
+
+	return histories, rows.Err()
+}
+
+// adminOrderOrder is the keyset order of the admin order list for a sort.
+// Orders without a schedule sort after every scheduled one.
+func adminOrderOrder(sort string) pagination.Order {
+	latest := []pagination.Key{
+		{Expr: "o.created_at", Type: "timestamp", Desc: true},
+		{Expr: "o.id", Type: "uuid", Desc: true},
+	}
+
+	if sort == "scheduled" {
+		scheduled := pagination.Key{Expr: "COALESCE(o.scheduled_for, 'infinity'::timestamp)", Type: "timestamp"}
+		return pagination.Order{Name: "orders:scheduled", Keys: append([]pagination.Key{scheduled}, latest...)}
+	}
+
+	return pagination.Order{Name: "orders:latest", Keys: latest}
+}
+
+// writeAdminOrderFilters adds the filters of the admin order list to a query
+// on orders o that already has a WHERE clause.
+func writeAdminOrderFilters(sql *strings.Builder, values []any, status, orderId string, outletId int, outletIds []int) []any {
+	if status != "" {
+		fmt.Fprintf(sql, " AND o.status = $%d", len(values)+1)
+		values = append(values, status)
+	}
+
+	if orderId != "" {
+		fmt.Fprintf(sql, " AND o.id::text = $%d", len(values)+1)
+		values = append(values, orderId)
+	}
+
+	if outletId != 0 {
+		fmt.Fprintf(sql, " AND o.outlet_id = $%d", len(values)+1)
+		values = append(values, outletId)
+	}
+
+	if outletIds != nil {
+		fmt.Fprintf(sql, " AND o.outlet_id = ANY($%d)", len(values)+1)
+		values = append(values, outletIds)
+	}
+
+	return values
+}
+
+// GetAllOrderByAdmin lists orders newest first. With sort "scheduled" the
+// scheduled orders come first, soonest first, followed by the rest. A
+// non-nil outletIds limits the list to those outlets, outletId to one. It
+// also returns the cursor of the next page, empty on the last page.
+func (o *OrderRepository) GetAllOrderByAdmin(ctx context.Context, db DBTX, status string, orderId string, sort string, page pagination.Page, outletId int, outletIds []int) ([]model.Order, string, error) {
 	var sql strings.Builder
 	values := []any{}
+	order := adminOrderOrder(sort)
 
 	sqlStr := `
 		SELECT
@@ -144,141 +383,219 @@ func (o *OrderRepository) GetAllOrderByAdmin(ctx context.Context, db DBTX, statu
 			TO_CHAR(o.created_at, 'DD FMMonth YYYY') AS "date",
 			STRING_AGG(CONCAT('• ' ,p.name, ' - ', dt.qty, 'x'), ', '),
 			o.status,
-			o.total
+			ot.name,
+			o.scheduled_for,
+			o.total,
+			` + order.Select() + `
 		FROM orders o
+		JOIN outlets ot ON ot.id = o.outlet_id
 		JOIN dt_order dt ON dt.order_id = o.id
 		JOIN menus m ON dt.menu_id = m.id
 		JOIN products p ON p.id = m.product_id
+		WHERE TRUE
 	`
 
 	sql.WriteString(sqlStr)
 
-	if status != "" && orderId == "" {
-		fmt.Fprintf(&sql, " WHERE o.status = $%d", len(values)+1)
-		values = append(values, status)
-	}
+	values = writeAdminOrderFilters(&sql, values, status, orderId, outletId, outletIds)
 
-	if orderId != "" && status == "" {
-		fmt.Fprintf(&sql, " WHERE o.id::text = $%d", len(values)+1)
-		values = append(values, orderId)
+	seek, seekValues, err := order.Seek(page, len(values)+1)
+	if err != nil {
+		return nil, "", err
 	}
-
-	if orderId != "" && status != "" {
-		fmt.Fprintf(&sql, " WHERE o.id::text = $%d AND o.status=$%d", len(values)+1, len(values)+2)
-		values = append(values, orderId)
-		values = append(values, status)
+	if seek != "" {
+		sql.WriteString(" AND " + seek)
+		values = append(values, seekValues...)
 	}
 
-	offset := 0
-	if page > 0 {
-		offset = (page - 1) * 5
-	}
+	sql.WriteString(" GROUP BY o.id, ot.name")
+	sql.WriteString(" ORDER BY " + order.OrderBy())
 
-	fmt.Fprintf(&sql, " GROUP BY o.id LIMIT 5 OFFSET $%d", len(values)+1)
-	values = append(values, offset)
+	fmt.Fprintf(&sql, " LIMIT $%d OFFSET $%d", len(values)+1, len(values)+2)
+	values = append(values, page.Fetch(), page.Offset())
 
 	mySql := sql.String()
 
 	rows, err := db.Query(ctx, mySql, values...)
 
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer rows.Close()
 
 	var orders []model.Order
+	var keys [][]string
 	for rows.Next() {
 		var odr model.Order
-		if err := rows.Scan(&odr.Order_Id, &odr.Date, &odr.Item, &odr.Status, &odr.Total); err != nil {
-			return nil, err
+		var key []string
+		if err := rows.Scan(&odr.Order_Id, &odr.Date, &odr.Item, &odr.Status, &odr.Outlet, &odr.ScheduledFor, &odr.Total, &key); err != nil {
+			return nil, "", err
 		}
 		orders = append(orders, odr)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
 	}
 
-	return orders, rows.Err()
-
+	orders, next := pagination.Trim(order, page, orders, keys)
+	return orders, next, nil
 }
 
-func (o *OrderRepository) GetOrderTotalPages(ctx context.Context, db DBTX) (int, error) {
-	query := "SELECT COUNT(id) FROM orders"
+// CountOrdersByAdmin counts the orders GetAllOrderByAdmin lists with the
+// same filters.
+func (o *OrderRepository) CountOrdersByAdmin(ctx context.Context, db DBTX, status string, orderId string, outletId int, outletIds []int) (int, error) {
+	var sql strings.Builder
+	values := []any{}
+
+	sql.WriteString("SELECT COUNT(o.id) FROM orders o WHERE EXISTS (SELECT 1 FROM dt_order dt WHERE dt.order_id = o.id)")
+
+	values = writeAdminOrderFilters(&sql, values, status, orderId, outletId, outletIds)
 
 	var order int
-	err := db.QueryRow(ctx, query).Scan(&order)
+	err := db.QueryRow(ctx, sql.String(), values...).Scan(&order)
 	if err != nil {
 		return 0, err
 	}
 
-	totalPage := int(math.Ceil(float64(order) / float64(5)))
-	return totalPage, nil
+	return order, nil
 }
 
-func (o *OrderRepository) GetProductType(ctx context.Context, db DBTX, id int) (model.ProductType, error) {
-	sqlStr := `SELECT id, name, price FROM product_type WHERE id = $1`
+// GetProductType returns the type with the price that applies to the menu's
+// product. A product without any configured types accepts every active type.
+func (o *OrderRepository) GetProductType(ctx context.Context, db DBTX, menuId, typeId int) (model.ProductType, error) {
+	sqlStr := `
+		SELECT
+			pt.id,
+			pt.name,
+			COALESCE(pto.price, pt.price),
+			pto.id IS NOT NULL OR NOT EXISTS (
+				SELECT 1 FROM product_type_options x WHERE x.product_id = m.product_id
+			)
+		FROM product_type pt
+		LEFT JOIN menus m ON m.id = $1
+		LEFT JOIN product_type_options pto ON pto.product_id = m.product_id AND pto.product_type_id = pt.id
+		WHERE pt.id = $2 AND pt.deleted_at IS NULL
+	`
 
-	row := db.QueryRow(ctx, sqlStr, id)
+	row := db.QueryRow(ctx, sqlStr, menuId, typeId)
 	var pt model.ProductType
-	if err := row.Scan(&pt.Id, &pt.Name, &pt.Price); err != nil {
+	var allowed bool
+	if err := row.Scan(&pt.Id, &pt.Name, &pt.Price, &allowed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ProductType{}, apperror.ErrProductTypeNotFound
+		}
 		return model.ProductType{}, err
 	}
+
+	if !allowed {
+		return model.ProductType{}, apperror.ErrProductTypeNotAvailable
+	}
 	return pt, nil
 }
 
-func (o *OrderRepository) GetProductSize(ctx context.Context, db DBTX, id int) (model.ProductSize, error) {
-	sqlStr := `SELECT id, name, price FROM product_size WHERE id = $1`
+// GetProductSize returns the size with the price that applies to the menu's
+// product. A product without any configured sizes accepts every active size.
+func (o *OrderRepository) GetProductSize(ctx context.Context, db DBTX, menuId, sizeId int) (model.ProductSize, error) {
+	sqlStr := `
+		SELECT
+			ps.id,
+			ps.name,
+			COALESCE(pso.price, ps.price),
+			pso.id IS NOT NULL OR NOT EXISTS (
+				SELECT 1 FROM product_size_options x WHERE x.product_id = m.product_id
+			)
+		FROM product_size ps
+		LEFT JOIN menus m ON m.id = $1
+		LEFT JOIN product_size_options pso ON pso.product_id = m.product_id AND pso.product_size_id = ps.id
+		WHERE ps.id = $2 AND ps.deleted_at IS NULL
+	`
 
-	row := db.QueryRow(ctx, sqlStr, id)
+	row := db.QueryRow(ctx, sqlStr, menuId, sizeId)
 
 	var ps model.ProductSize
-	if err := row.Scan(&ps.Id, &ps.Name, &ps.Price); err != nil {
+	var allowed bool
+	if err := row.Scan(&ps.Id, &ps.Name, &ps.Price, &allowed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ProductSize{}, apperror.ErrProductSizeNotFound
+		}
 		return model.ProductSize{}, err
 	}
 
+	if !allowed {
+		return model.ProductSize{}, apperror.ErrProductSizeNotAvailable
+	}
 	return ps, nil
 }
 
-func (o *OrderRepository) GetHistoryByUser(ctx context.Context, db DBTX, page int, userId int) ([]model.History, error) {
+// historyOrder is the keyset order of a user's order history, newest first.
+var historyOrder = pagination.Order{
+	Name: "history",
+	Keys: []pagination.Key{
+		{Expr: "o.created_at", Type: "timestamp", Desc: true},
+		{Expr: "o.id", Type: "uuid", Desc: true},
+	},
+}
 
-	sqlStr := `
+// GetHistoryByUser lists a page of the user's orders, newest first, and
+// returns the cursor of the next page, empty on the last page.
+func (o *OrderRepository) GetHistoryByUser(ctx context.Context, db DBTX, page pagination.Page, userId int) ([]model.History, string, error) {
+	var sql strings.Builder
+	values := []any{userId}
+
+	sql.WriteString(`
 		SELECT
 		o.id,
 		TO_CHAR(o.created_at, 'DD FMMonth YYYY') AS "date",	
 		o.total,
-		o.status
+		o.status,
+		` + historyOrder.Select() + `
 		FROM orders o
-		JOIN dt_order dt ON dt.order_id = o.id
-		JOIN menus m ON dt.menu_id = m.id
-		JOIN products p ON p.id = m.product_id
-		WHERE user_id = $1
-		GROUP BY o.id LIMIT 5 OFFSET $2;
-	`
+		WHERE o.user_id = $1
+		AND EXISTS (SELECT 1 FROM dt_order dt WHERE dt.order_id = o.id)
+	`)
 
-	offset := 0
-	if page > 0 {
-		offset = (page - 1) * 5
+	seek, seekValues, err := historyOrder.Seek(page, len(values)+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if seek != "" {
+		sql.WriteString(" AND " + seek)
+		values = append(values, seekValues...)
 	}
 
-	rows, err := db.Query(ctx, sqlStr, userId, offset)
+	fmt.Fprintf(&sql, " ORDER BY %s LIMIT $%d OFFSET $%d", historyOrder.OrderBy(), len(values)+1, len(values)+2)
+	values = append(values, page.Fetch(), page.Offset())
+
+	rows, err := db.Query(ctx, sql.String(), values...)
 
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer rows.Close()
 
 	var histories []model.History
+	var keys [][]string
 	for rows.Next() {
 		var history model.History
-		if err := rows.Scan(&history.Order_Id, &history.Date, &history.Total, &history.Status); err != nil {
-			return nil, err
+		var key []string
+		if err := rows.Scan(&history.Order_Id, &history.Date, &history.Total, &history.Status, &key); err != nil {
+			return nil, "", err
 		}
 		histories = append(histories, history)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
 	}
 
-	return histories, rows.Err()
-
+	histories, next := pagination.Trim(historyOrder, page, histories, keys)
+	return histories, next, nil
 }
 
-func (o *OrderRepository) GetHistoryTotalPages(ctx context.Context, db DBTX, userId int) (int, error) {
-	query := "SELECT COUNT(id) FROM orders WHERE user_id = $1"
+// CountHistoryByUser counts the orders GetHistoryByUser lists.
+func (o *OrderRepository) CountHistoryByUser(ctx context.Context, db DBTX, userId int) (int, error) {
+	query := "SELECT COUNT(o.id) FROM orders o WHERE o.user_id = $1 AND EXISTS (SELECT 1 FROM dt_order dt WHERE dt.order_id = o.id)"
 
 	var hist int
 	err := db.QueryRow(ctx, query, userId).Scan(&hist)
@@ -286,25 +603,37 @@ func (o *OrderRepository) GetHistoryTotalPages(ctx context.Context, db DBTX, use
 		return 0, err
 	}
 
-	totalPage := int(math.Ceil(float64(hist) / float64(5)))
-	return totalPage, nil
+	return hist, nil
 }
 
 func (o OrderRepository) GetOrderHistoryById(ctx context.Context, db DBTX, idOrder string) (model.DetailOrder, error) {
 	sqlStr := `
 		SELECT
 		o.id,
+		o.user_id,
 		TO_CHAR(o.created_at, 'DD FMMonth YYYY HH12:MI AM') AS "date",
-		COALESCE(u.fullname, ''),
-		COALESCE(u.address, ','),
-		COALESCE(u.phone, ','),
+		o.outlet_id,
+		ot.name,
+		COALESCE(o.delivery_recipient, u.fullname, ''),
+		COALESCE(o.delivery_address, ''),
+		COALESCE(o.delivery_phone, u.phone, ''),
+		COALESCE(o.delivery_label, ''),
+		COALESCE(o.delivery_zone, ''),
 		py.name,
 		o.shipping,
 		o.status,
-		o.total
+		COALESCE(v.code, ''),
+		COALESCE(o.discount, 0),
+		COALESCE(o.tax, 0),
+		o.delivery_fee,
+		o.total,
+		o.scheduled_for,
+		o.refunded_at
 		FROM orders o
 		JOIN users u ON u.id = o.user_id
+		JOIN outlets ot ON ot.id = o.outlet_id
 		JOIN payments py ON py.id = o.payment_id
+		LEFT JOIN vouchers v ON v.id = o.voucher_id
 		WHERE o.id = $1
 	`
 
@@ -313,8 +642,11 @@ func (o OrderRepository) GetOrderHistoryById(ctx context.Context, db DBTX, idOrd
 
 	var ord model.DetailOrder
 
-	if err := row.Scan(&ord.Order_Id, &ord.DateOrder, &ord.FullName, &ord.Address, &ord.Phone, &ord.PaymentMethod, &ord.Shipping, &ord.Status, &ord.Total); err != nil {
+	if err := row.Scan(&ord.Order_Id, &ord.UserId, &ord.DateOrder, &ord.OutletId, &ord.Outlet, &ord.FullName, &ord.Address, &ord.Phone, &ord.DeliveryLabel, &ord.DeliveryZone, &ord.PaymentMethod, &ord.Shipping, &ord.Status, &ord.VoucherCode, &ord.Discount, &ord.Tax, &ord.DeliveryFee, &ord.Total, &ord.ScheduledFor, &ord.RefundedAt); err != nil {
 		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.DetailOrder{}, apperror.ErrOrderNotFound
+		}
 		return model.DetailOrder{}, err
 	}
 
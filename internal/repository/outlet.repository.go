@@ -0,0 +1,402 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type OutletRepo interface {
+	CreateOutlet(ctx context.Context, db DBTX, req dto.OutletRequest) (int, error)
+	GetOutlet(ctx context.Context, db DBTX, id int) (model.Outlet, error)
+	GetOutlets(ctx context.Context, db DBTX, req dto.OutletParams, outletIds []int) ([]model.Outlet, error)
+	GetTotalPage(ctx context.Context, db DBTX, req dto.OutletParams, outletIds []int) (int, error)
+	GetActiveOutlets(ctx context.Context, db DBTX) ([]model.Outlet, error)
+	UpdateOutlet(ctx context.Context, db DBTX, req dto.UpdateOutletRequest, id int) error
+	DeleteOutlet(ctx context.Context, db DBTX, id int) error
+	ManagesAllOutlets(ctx context.Context, db DBTX, userId int) (bool, error)
+	GetManagedOutletIds(ctx context.Context, db DBTX, userId int) ([]int, error)
+	GetOutletAdmins(ctx context.Context, db DBTX, outletId int) ([]model.OutletAdmin, error)
+	GetGlobalAdmins(ctx context.Context, db DBTX) ([]model.OutletAdmin, error)
+	SetAllOutlets(ctx context.Context, db DBTX, userId int, all bool) error
+	SetOutletAdmins(ctx context.Context, db DBTX, outletId int, userIds []int) error
+}
+
+type OutletRepository struct{}
+
+func NewOutletRepository() *OutletRepository {
+	return &OutletRepository{}
+}
+
+func scanOutlets(rows pgx.Rows) ([]model.Outlet, error) {
+	defer rows.Close()
+
+	var outlets []model.Outlet
+	for rows.Next() {
+		var outlet model.Outlet
+		if err := rows.Scan(&outlet.ID, &outlet.Name, &outlet.Address, &outlet.Phone, &outlet.IsActive); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetOutlet
+		}
+		outlets = append(outlets, outlet)
+	}
+
+	return outlets, rows.Err()
+}
+
+func (or *OutletRepository) CreateOutlet(ctx context.Context, db DBTX, req dto.OutletRequest) (int, error) {
+	query := "INSERT INTO outlets (name, address, phone, is_active) VALUES ($1, $2, $3, COALESCE($4, true)) RETURNING id"
+
+	var id int
+	if err := db.QueryRow(ctx, query, req.Name, req.Address, req.Phone, req.IsActive).Scan(&id); err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return 0, apperror.ErrOutletExists
+		}
+		return 0, apperror.ErrCreateOutlet
+	}
+
+	return id, nil
+}
+
+func (or *OutletRepository) GetOutlet(ctx context.Context, db DBTX, id int) (model.Outlet, error) {
+	query := "SELECT id, name, address, phone, is_active FROM outlets WHERE id = $1 AND deleted_at IS NULL"
+
+	var outlet model.Outlet
+	if err := db.QueryRow(ctx, query, id).Scan(&outlet.ID, &outlet.Name, &outlet.Address, &outlet.Phone, &outlet.IsActive); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Outlet{}, apperror.ErrOutletNotFound
+		}
+		return model.Outlet{}, apperror.ErrGetOutlet
+	}
+
+	return outlet, nil
+}
+
+// GetOutlets lists outlets including inactive ones. A non-nil outletIds
+// limits the list to those outlets.
+func (or *OutletRepository) GetOutlets(ctx context.Context, db DBTX, req dto.OutletParams, outletIds []int) ([]model.Outlet, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT id, name, address, phone, is_active FROM outlets WHERE deleted_at IS NULL")
+
+	if outletIds != nil {
+		fmt.Fprintf(&sb, " AND id = ANY($%d)", len(args)+1)
+		args = append(args, outletIds)
+	}
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	limit := 5
+	offset := 0
+	if req.Page != "" {
+		page, _ := strconv.Atoi(req.Page)
+		if page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	fmt.Fprintf(&sb, " ORDER BY id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.Query(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetOutlet
+	}
+
+	return scanOutlets(rows)
+}
+
+func (or *OutletRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.OutletParams, outletIds []int) (int, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT COUNT(id) FROM outlets WHERE deleted_at IS NULL")
+
+	if outletIds != nil {
+		fmt.Fprintf(&sb, " AND id = ANY($%d)", len(args)+1)
+		args = append(args, outletIds)
+	}
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	var totalOutlets int
+	if err := db.QueryRow(ctx, sb.String(), args...).Scan(&totalOutlets); err != nil {
+		return 0, err
+	}
+
+	itemsPerPage := 5
+	totalPage := int(math.Ceil(float64(totalOutlets) / float64(itemsPerPage)))
+
+	return totalPage, nil
+}
+
+// GetActiveOutlets lists the outlets customers can order from.
+func (or *OutletRepository) GetActiveOutlets(ctx context.Context, db DBTX) ([]model.Outlet, error) {
+	query := "SELECT id, name, address, phone, is_active FROM outlets WHERE deleted_at IS NULL AND is_active ORDER BY id"
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetOutlet
+	}
+
+	return scanOutlets(rows)
+}
+
+func (or *OutletRepository) UpdateOutlet(ctx context.Context, db DBTX, req dto.UpdateOutletRequest, id int) error {
+	var sb strings.Builder
+	sb.WriteString("UPDATE outlets SET ")
+	args := []any{}
+
+	if req.Name != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "name = $%d", len(args)+1)
+		args = append(args, req.Name)
+	}
+
+	if req.Address != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "address = $%d", len(args)+1)
+		args = append(args, req.Address)
+	}
+
+	if req.Phone != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "phone = $%d", len(args)+1)
+		args = append(args, req.Phone)
+	}
+
+	if req.IsActive != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "is_active = $%d", len(args)+1)
+		args = append(args, *req.IsActive)
+	}
+
+	if len(args) == 0 {
+		return apperror.ErrNoFieldsToUpdate
+	}
+
+	fmt.Fprintf(&sb, ", updated_at = NOW() WHERE id = $%d AND deleted_at IS NULL", len(args)+1)
+	args = append(args, id)
+
+	ct, err := db.Exec(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return apperror.ErrOutletExists
+		}
+		return apperror.ErrUpdateOutlet
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrOutletNotFound
+	}
+
+	return nil
+}
+
+// DeleteOutlet soft deletes the outlet together with its menus, so its
+// products stop showing up and can no longer be ordered.
+func (or *OutletRepository) DeleteOutlet(ctx context.Context, db DBTX, id int) error {
+	query := "UPDATE outlets SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	ct, err := db.Exec(ctx, query, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteOutlet
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrOutletNotFound
+	}
+
+	if _, err := db.Exec(ctx, "UPDATE menus SET deleted_at = NOW() WHERE outlet_id = $1 AND deleted_at IS NULL", id); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteOutlet
+	}
+
+	return nil
+}
+
+// ManagesAllOutlets reports whether the user was granted every outlet with
+// users.all_outlets.
+func (or *OutletRepository) ManagesAllOutlets(ctx context.Context, db DBTX, userId int) (bool, error) {
+	query := "SELECT all_outlets FROM users WHERE id = $1 AND deleted_at IS NULL"
+
+	var all bool
+	if err := db.QueryRow(ctx, query, userId).Scan(&all); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		log.Println(err.Error())
+		return false, apperror.ErrGetOutlet
+	}
+
+	return all, nil
+}
+
+// GetManagedOutletIds returns the outlets assigned to an admin.
+func (or *OutletRepository) GetManagedOutletIds(ctx context.Context, db DBTX, userId int) ([]int, error) {
+	query := "SELECT outlet_id FROM outlet_admins WHERE user_id = $1 ORDER BY outlet_id"
+
+	rows, err := db.Query(ctx, query, userId)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetOutlet
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetOutlet
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (or *OutletRepository) GetOutletAdmins(ctx context.Context, db DBTX, outletId int) ([]model.OutletAdmin, error) {
+	query := `
+		SELECT u.id, COALESCE(u.fullname, ''), COALESCE(u.email, '')
+		FROM outlet_admins oa
+		JOIN users u ON u.id = oa.user_id
+		WHERE oa.outlet_id = $1
+		ORDER BY u.id
+	`
+
+	rows, err := db.Query(ctx, query, outletId)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetOutlet
+	}
+	defer rows.Close()
+
+	var admins []model.OutletAdmin
+	for rows.Next() {
+		var admin model.OutletAdmin
+		if err := rows.Scan(&admin.UserId, &admin.Fullname, &admin.Email); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetOutlet
+		}
+		admins = append(admins, admin)
+	}
+
+	return admins, rows.Err()
+}
+
+// SetOutletAdmins replaces the admins of the outlet. Every id has to belong
+// to an active admin, otherwise nothing is assigned.
+func (or *OutletRepository) SetOutletAdmins(ctx context.Context, db DBTX, outletId int, userIds []int) error {
+	if _, err := db.Exec(ctx, "DELETE FROM outlet_admins WHERE outlet_id = $1", outletId); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateOutlet
+	}
+
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO outlet_admins (user_id, outlet_id)
+		SELECT DISTINCT u.id, $1::int
+		FROM users u
+		WHERE u.id = ANY($2) AND u.role = 'admin' AND u.deleted_at IS NULL
+	`
+
+	ct, err := db.Exec(ctx, query, outletId, userIds)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateOutlet
+	}
+
+	unique := map[int]bool{}
+	for _, id := range userIds {
+		unique[id] = true
+	}
+	if int(ct.RowsAffected()) != len(unique) {
+		return apperror.ErrOutletAdminInvalid
+	}
+
+	return nil
+}
+
+// GetGlobalAdmins lists the active admins granted every outlet.
+func (or *OutletRepository) GetGlobalAdmins(ctx context.Context, db DBTX) ([]model.OutletAdmin, error) {
+	query := `
+		SELECT id, COALESCE(fullname, ''), COALESCE(email, '')
+		FROM users
+		WHERE role = 'admin' AND all_outlets AND deleted_at IS NULL
+		ORDER BY id
+	`
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetOutlet
+	}
+	defer rows.Close()
+
+	var admins []model.OutletAdmin
+	for rows.Next() {
+		var admin model.OutletAdmin
+		if err := rows.Scan(&admin.UserId, &admin.Fullname, &admin.Email); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetOutlet
+		}
+		admins = append(admins, admin)
+	}
+
+	return admins, rows.Err()
+}
+
+// SetAllOutlets grants or revokes an admin's access to every outlet. The
+// user has to be an active admin.
+func (or *OutletRepository) SetAllOutlets(ctx context.Context, db DBTX, userId int, all bool) error {
+	query := `
+		UPDATE users
+		SET all_outlets = $2, updated_at = NOW()
+		WHERE id = $1 AND role = 'admin' AND deleted_at IS NULL
+	`
+
+	ct, err := db.Exec(ctx, query, userId, all)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateOutlet
+	}
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrOutletAdminInvalid
+	}
+
+	return nil
+}
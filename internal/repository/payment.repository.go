@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type PaymentRepo interface {
+	CreatePayment(ctx context.Context, db DBTX, req dto.PaymentMethodRequest) error
+	GetPayment(ctx context.Context, db DBTX, id int) (model.Payment, error)
+	GetPayments(ctx context.Context, db DBTX, req dto.PaymentParams) ([]model.Payment, error)
+	GetTotalPage(ctx context.Context, db DBTX, req dto.PaymentParams) (int, error)
+	UpdatePayment(ctx context.Context, db DBTX, req dto.UpdatePaymentMethodRequest, id int) error
+	DeletePayment(ctx context.Context, db DBTX, id int) error
+	GetPayableOrder(ctx context.Context, db DBTX, orderId string) (model.PayableOrder, error)
+	CreatePaymentAttempt(ctx context.Context, db DBTX, attempt model.PaymentAttempt) (int, error)
+	CreatePaymentEvent(ctx context.Context, db DBTX, provider, eventId, reference, status string, payload []byte) (bool, error)
+	GetAttemptByReference(ctx context.Context, db DBTX, provider, reference string) (model.PaymentAttempt, error)
+	GetLatestAttempt(ctx context.Context, db DBTX, orderId string) (model.PaymentAttempt, error)
+	UpdateAttemptStatus(ctx context.Context, db DBTX, id int, status string) error
+}
+
+type PaymentRepository struct{}
+
+func NewPaymentRepository() *PaymentRepository {
+	return &PaymentRepository{}
+}
+
+func (pr *PaymentRepository) CreatePayment(ctx context.Context, db DBTX, req dto.PaymentMethodRequest) error {
+	query := "INSERT INTO payments (name, provider, is_active) VALUES ($1, $2, COALESCE($3, true))"
+
+	if _, err := db.Exec(ctx, query, req.Name, req.Provider, req.IsActive); err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return apperror.ErrPaymentExists
+		}
+		return apperror.ErrCreatePayment
+	}
+
+	return nil
+}
+
+func (pr *PaymentRepository) GetPayment(ctx context.Context, db DBTX, id int) (model.Payment, error) {
+	query := "SELECT id, name, provider, is_active FROM payments WHERE id = $1 AND deleted_at IS NULL"
+
+	var payment model.Payment
+	if err := db.QueryRow(ctx, query, id).Scan(&payment.ID, &payment.Name, &payment.Provider, &payment.IsActive); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Payment{}, apperror.ErrPaymentNotFound
+		}
+		return model.Payment{}, apperror.ErrGetPayment
+	}
+
+	return payment, nil
+}
+
+func (pr *PaymentRepository) GetPayments(ctx context.Context, db DBTX, req dto.PaymentParams) ([]model.Payment, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT id, name, provider, is_active FROM payments WHERE deleted_at IS NULL")
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	limit := 5
+	offset := 0
+	if req.Page != "" {
+		page, _ := strconv.Atoi(req.Page)
+		if page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	fmt.Fprintf(&sb, " ORDER BY id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.Query(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetPayment
+	}
+	defer rows.Close()
+
+	var payments []model.Payment
+	for rows.Next() {
+		var payment model.Payment
+		if err := rows.Scan(&payment.ID, &payment.Name, &payment.Provider, &payment.IsActive); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetPayment
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, rows.Err()
+}
+
+func (pr *PaymentRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.PaymentParams) (int, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT COUNT(id) FROM payments WHERE deleted_at IS NULL")
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	var totalPayments int
+	if err := db.QueryRow(ctx, sb.String(), args...).Scan(&totalPayments); err != nil {
+		return 0, err
+	}
+
+	itemsPerPage := 5
+	totalPage := int(math.Ceil(float64(totalPayments) / float64(itemsPerPage)))
+
+	return totalPage, nil
+}
+
+func (pr *PaymentRepository) UpdatePayment(ctx context.Context, db DBTX, req dto.UpdatePaymentMethodRequest, id int) error {
+	var sb strings.Builder
+	sb.WriteString("UPDATE payments SET ")
+	args := []any{}
+
+	if req.Name != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "name = $%d", len(args)+1)
+		args = append(args, req.Name)
+	}
+
+	if req.Provider != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "provider = $%d", len(args)+1)
+		args = append(args, req.Provider)
+	}
+
+	if req.IsActive != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "is_active = $%d", len(args)+1)
+		args = append(args, *req.IsActive)
+	}
+
+	if len(args) == 0 {
+		return apperror.ErrNoFieldsToUpdate
+	}
+
+	fmt.Fprintf(&sb, ", updated_at = NOW() WHERE id = $%d AND deleted_at IS NULL", len(args)+1)
+	args = append(args, id)
+
+	ct, err := db.Exec(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdatePayment
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+func (pr *PaymentRepository) DeletePayment(ctx context.Context, db DBTX, id int) error {
+	query := "UPDATE payments SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	ct, err := db.Exec(ctx, query, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeletePayment
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+// GetPayableOrder locks the order together with its payment method, so two
+// pay requests for the same order are handled one at a time.
+func (pr *PaymentRepository) GetPayableOrder(ctx context.Context, db DBTX, orderId string) (model.PayableOrder, error) {
+	query := `
+		SELECT
+			o.id,
+			o.user_id,
+			o.status,
+			o.total,
+			o.payment_id,
+			py.provider,
+			py.is_active AND py.deleted_at IS NULL
+		FROM orders o
+		JOIN payments py ON py.id = o.payment_id
+		WHERE o.id::text = $1
+		FOR UPDATE OF o
+	`
+
+	var order model.PayableOrder
+	if err := db.QueryRow(ctx, query, orderId).Scan(
+		&order.OrderId,
+		&order.UserId,
+		&order.Status,
+		&order.Total,
+		&order.PaymentId,
+		&order.Provider,
+		&order.IsActive,
+	); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.PayableOrder{}, apperror.ErrOrderNotFound
+		}
+		return model.PayableOrder{}, apperror.ErrGetPayment
+	}
+
+	return order, nil
+}
+
+func (pr *PaymentRepository) CreatePaymentAttempt(ctx context.Context, db DBTX, attempt model.PaymentAttempt) (int, error) {
+	query := `
+		INSERT INTO payment_attempts (order_id, payment_id, provider, reference, amount, status, payment_url)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''))
+		RETURNING id
+	`
+
+	var id int
+	if err := db.QueryRow(ctx, query,
+		attempt.OrderId,
+		attempt.PaymentId,
+		attempt.Provider,
+		attempt.Reference,
+		attempt.Amount,
+		attempt.Status,
+		attempt.PaymentURL,
+	).Scan(&id); err != nil {
+		log.Println(err.Error())
+		return 0, apperror.ErrCreatePaymentAttempt
+	}
+
+	return id, nil
+}
+
+// CreatePaymentEvent stores a webhook event and reports whether it is new.
+// The unique (provider, event_id) key makes redelivered events a no-op.
+func (pr *PaymentRepository) CreatePaymentEvent(ctx context.Context, db DBTX, provider, eventId, reference, status string, payload []byte) (bool, error) {
+	query := `
+		INSERT INTO payment_events (provider, event_id, reference, status, payload)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (provider, event_id) DO NOTHING
+	`
+
+	ct, err := db.Exec(ctx, query, provider, eventId, reference, status, payload)
+	if err != nil {
+		log.Println(err.Error())
+		return false, apperror.ErrProcessWebhook
+	}
+
+	return ct.RowsAffected() > 0, nil
+}
+
+func (pr *PaymentRepository) GetAttemptByReference(ctx context.Context, db DBTX, provider, reference string) (model.PaymentAttempt, error) {
+	query := `
+		SELECT id, order_id, payment_id, provider, reference, amount, status, COALESCE(payment_url, '')
+		FROM payment_attempts
+		WHERE provider = $1 AND reference = $2
+		FOR UPDATE
+	`
+
+	var attempt model.PaymentAttempt
+	if err := db.QueryRow(ctx, query, provider, reference).Scan(
+		&attempt.ID,
+		&attempt.OrderId,
+		&attempt.PaymentId,
+		&attempt.Provider,
+		&attempt.Reference,
+		&attempt.Amount,
+		&attempt.Status,
+		&attempt.PaymentURL,
+	); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.PaymentAttempt{}, apperror.ErrChargeNotFound
+		}
+		return model.PaymentAttempt{}, apperror.ErrProcessWebhook
+	}
+
+	return attempt, nil
+}
+
+// GetLatestAttempt locks the most recent payment attempt of the order.
+func (pr *PaymentRepository) GetLatestAttempt(ctx context.Context, db DBTX, orderId string) (model.PaymentAttempt, error) {
+	query := `
+		SELECT id, order_id, payment_id, provider, COALESCE(reference, ''), amount, status, COALESCE(payment_url, '')
+		FROM payment_attempts
+		WHERE order_id::text = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+		FOR UPDATE
+	`
+
+	var attempt model.PaymentAttempt
+	if err := db.QueryRow(ctx, query, orderId).Scan(
+		&attempt.ID,
+		&attempt.OrderId,
+		&attempt.PaymentId,
+		&attempt.Provider,
+		&attempt.Reference,
+		&attempt.Amount,
+		&attempt.Status,
+		&attempt.PaymentURL,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.PaymentAttempt{}, apperror.ErrChargeNotFound
+		}
+		log.Println(err.Error())
+		return model.PaymentAttempt{}, apperror.ErrGetPayment
+	}
+
+	return attempt, nil
+}
+
+func (pr *PaymentRepository) UpdateAttemptStatus(ctx context.Context, db DBTX, id int, status string) error {
+	query := "UPDATE payment_attempts SET status = $1, updated_at = NOW() WHERE id = $2"
+
+	if _, err := db.Exec(ctx, query, status, id); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrProcessWebhook
+	}
+
+	return nil
+}
@@ -5,12 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math"
-	"strconv"
 	"strings"
 
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -19,6 +19,46 @@ type ProductRepo interface {
 	GetTotalPage(ctx context.Context, db DBTX) (int, error)
 	PostProduct(ctx context.Context, db DBTX, post dto.PostProductsRequest) (dto.PostProductResponse, error)
 }
+
+// discountedPriceSQL mirrors pricing.DiscountedPrice, so filtering and
+// sorting by price use the same rounded amount the listing shows.
+const discountedPriceSQL = "(p.price - ROUND(p.price * pm.discount::numeric / 100))"
+
+// productSearchSQL matches the search term, given by its placeholder number,
+// against the product's search vector. Terms the full-text search misses,
+// such as typos, still match names through trigram word similarity.
+const productSearchSQL = "(p.search_vector @@ websearch_to_tsquery('simple', $%[1]d) OR $%[1]d <%% p.name)"
+
+// productRelevanceSQL ranks products matched by productSearchSQL, full-text
+// hits first and close names after them.
+const productRelevanceSQL = "(ts_rank_cd(p.search_vector, websearch_to_tsquery('simple', $%[1]d)) + word_similarity($%[1]d, p.name))"
+
+// productSearchVectorSQL builds the search vector of product p from its name,
+// its category names and its description, weighted in that order.
+const productSearchVectorSQL = `
+	setweight(to_tsvector('simple', COALESCE(p.name, '')), 'A') ||
+	setweight(to_tsvector('simple', COALESCE((
+		SELECT STRING_AGG(c.name, ' ')
+		FROM product_categories pc
+		JOIN categories c ON c.id = pc.category_id AND c.deleted_at IS NULL
+		WHERE pc.product_id = p.id AND pc.deleted_at IS NULL
+	), '')), 'B') ||
+	setweight(to_tsvector('simple', COALESCE(p.description, '')), 'C')
+`
+
+// refreshProductSearch rebuilds the search vector of the products matched
+// by where, which refers to the products table as p.
+func refreshProductSearch(ctx context.Context, db DBTX, where string, args ...any) error {
+	query := "UPDATE products p SET search_vector = " + productSearchVectorSQL + " WHERE " + where
+
+	if _, err := db.Exec(ctx, query, args...); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
 type ProductRepository struct {
 }
 
@@ -26,7 +66,35 @@ func NewProductRepository() *ProductRepository {
 	return &ProductRepository{}
 }
 
-func (pr *ProductRepository) GetProducts(ctx context.Context, db DBTX, req dto.ProductQueries) ([]model.Products, error) {
+// productOrder is the keyset order of the product list for a sort. Ties are
+// broken by id, so every product has its own place in the list. Relevance
+// ranks against the search term given by its placeholder number.
+func productOrder(sortType string, searchArg int) pagination.Order {
+	id := pagination.Key{Expr: "p.id", Type: "int"}
+
+	switch sortType {
+	case "Priciest":
+		return pagination.Order{Name: "products:priciest", Keys: []pagination.Key{{Expr: discountedPriceSQL, Type: "float8", Desc: true}, id}}
+	case "Cheapest":
+		return pagination.Order{Name: "products:cheapest", Keys: []pagination.Key{{Expr: discountedPriceSQL, Type: "float8"}, id}}
+	case "Recommended":
+		return pagination.Order{Name: "products:recommended", Keys: []pagination.Key{{Expr: "p.rating_avg", Type: "float8", Desc: true}, id}}
+	case "Latest":
+		return pagination.Order{Name: "products:latest", Keys: []pagination.Key{{Expr: "p.created_at", Type: "timestamp", Desc: true}, {Expr: "p.id", Type: "int", Desc: true}}}
+	case "Relevance":
+		if searchArg == 0 {
+			break
+		}
+		relevance := fmt.Sprintf(productRelevanceSQL, searchArg)
+		return pagination.Order{Name: "products:relevance", Keys: []pagination.Key{{Expr: relevance, Type: "real", Desc: true}, id}}
+	}
+
+	return pagination.Order{Name: "products", Keys: []pagination.Key{id}}
+}
+
+// GetProducts lists a page of products and returns the cursor of the next
+// page, empty on the last page.
+func (pr *ProductRepository) GetProducts(ctx context.Context, db DBTX, req dto.ProductQueries, page pagination.Page) ([]model.Products, string, error) {
 	var sb strings.Builder
 	args := []any{}
 	argCount := 1
@@ -51,30 +119,44 @@ func (pr *ProductRepository) GetProducts(ctx context.Context, db DBTX, req dto.P
 		}
 	}
 
+	// sort takes precedence over the sorting categories. Searches are ranked
+	// by relevance unless another order is asked for.
+	if req.Sort != "" {
+		sortType = strings.ToUpper(req.Sort[:1]) + req.Sort[1:]
+	}
+	if sortType == "" && req.Title != "" {
+		sortType = "Relevance"
+	}
+
+	// The search term comes first, so the relevance order can refer to it
+	// before the filters are numbered.
+	searchArg := 0
+	if req.Title != "" {
+		args = append(args, req.Title)
+		searchArg = argCount
+		argCount++
+	}
+
+	order := productOrder(sortType, searchArg)
+
 	sb.WriteString(`
-		WITH avg_rating AS (
-			SELECT 
-				AVG(r.rating) AS rating_product,
-				d.menu_id AS idmenu
-			FROM reviews r
-			JOIN dt_order d ON d.id = r.dt_orderid
-			GROUP BY d.menu_id
-		),
-		product_avg_rating AS (
-			SELECT 
-				m.product_id,
-				AVG(ar.rating_product) AS avg_rating
-			FROM menus m
-			LEFT JOIN avg_rating ar ON ar.idmenu = m.id
-			WHERE m.deleted_at IS NULL
-			GROUP BY m.product_id
-		),
-		product_menu AS (
+		WITH product_menu AS (
 			SELECT DISTINCT ON (m.product_id)
+				m.id AS menu_id,
 				m.product_id,
-				m.discount
+				COALESCE(m.discount, 0) AS discount
 			FROM menus m
+			JOIN outlets o ON o.id = m.outlet_id AND o.is_active AND o.deleted_at IS NULL
 			WHERE m.deleted_at IS NULL
+	`)
+
+	if req.Outlet != 0 {
+		fmt.Fprintf(&sb, " AND m.outlet_id = $%d", argCount)
+		args = append(args, req.Outlet)
+		argCount++
+	}
+
+	sb.WriteString(`
 			ORDER BY m.product_id, m.created_at DESC
 		)
 	`)
@@ -83,8 +165,8 @@ func (pr *ProductRepository) GetProducts(ctx context.Context, db DBTX, req dto.P
 		sb.WriteString(`, filtered_products AS (
 			SELECT DISTINCT p.id
 			FROM products p
-			JOIN product_categories pc ON pc.product_id = p.id
-			JOIN categories c ON c.id = pc.category_id
+			JOIN product_categories pc ON pc.product_id = p.id AND pc.deleted_at IS NULL
+			JOIN categories c ON c.id = pc.category_id AND c.deleted_at IS NULL
 			WHERE c.name IN (`)
 
 		placeholders := []string{}
@@ -101,15 +183,16 @@ func (pr *ProductRepository) GetProducts(ctx context.Context, db DBTX, req dto.P
 	sb.WriteString(`
 		SELECT 
 			p.id,
+			pm.menu_id,
 			p.name,
 			COALESCE(STRING_AGG(DISTINCT pi.image, ','), '') AS image_products,
 			p.price,
 			pm.discount,
-			COALESCE(par.avg_rating, 0) AS rating_product,
-			MIN(p.created_at) AS created_at
+			p.rating_avg AS rating_product,
+			p.review_count,
+			` + order.Select() + `
 		FROM products p
 		JOIN product_menu pm ON pm.product_id = p.id
-		LEFT JOIN product_avg_rating par ON par.product_id = p.id
 		LEFT JOIN product_images pi ON pi.product_id = p.id
 	`)
 
@@ -123,20 +206,18 @@ func (pr *ProductRepository) GetProducts(ctx context.Context, db DBTX, req dto.P
 		WHERE p.deleted_at IS NULL
 	`)
 
-	if req.Title != "" {
-		fmt.Fprintf(&sb, " AND p.name ILIKE $%d", argCount)
-		args = append(args, "%"+req.Title+"%")
-		argCount++
+	if searchArg != 0 {
+		fmt.Fprintf(&sb, " AND "+productSearchSQL, searchArg)
 	}
 
 	if req.Min != "" {
-		fmt.Fprintf(&sb, " AND (p.price - (p.price * pm.discount / 100))::numeric >= $%d::numeric", argCount)
+		fmt.Fprintf(&sb, " AND "+discountedPriceSQL+" >= $%d::numeric", argCount)
 		args = append(args, req.Min)
 		argCount++
 	}
 
 	if req.Max != "" {
-		fmt.Fprintf(&sb, " AND (p.price - (p.price * pm.discount / 100))::numeric <= $%d::numeric", argCount)
+		fmt.Fprintf(&sb, " AND "+discountedPriceSQL+" <= $%d::numeric", argCount)
 		args = append(args, req.Max)
 		argCount++
 	}
@@ -147,66 +228,64 @@ func (pr *ProductRepository) GetProducts(ctx context.Context, db DBTX, req dto.P
 		argCount++
 	}
 
-	sb.WriteString(" GROUP BY p.id, p.name, p.price, pm.discount, par.avg_rating")
-
-	switch sortType {
-	case "Priciest":
-		sb.WriteString(" ORDER BY (p.price - (p.price * pm.discount / 100))::numeric DESC")
-	case "Cheapest":
-		sb.WriteString(" ORDER BY (p.price - (p.price * pm.discount / 100))::numeric ASC")
-	case "Recommended":
-		sb.WriteString(" ORDER BY rating_product DESC")
-	case "Latest":
-		sb.WriteString(" ORDER BY created_at DESC")
-	default:
-		sb.WriteString(" ORDER BY p.id")
+	seek, seekArgs, err := order.Seek(page, argCount)
+	if err != nil {
+		return nil, "", err
 	}
-
-	limit := 6
-	offset := 0
-	if req.Page != "" {
-		page, _ := strconv.Atoi(req.Page)
-		if page > 0 {
-			offset = (page - 1) * limit
-		}
+	if seek != "" {
+		sb.WriteString(" AND " + seek)
+		args = append(args, seekArgs...)
+		argCount += len(seekArgs)
 	}
 
+	sb.WriteString(" GROUP BY p.id, pm.menu_id, p.name, p.price, pm.discount")
+	sb.WriteString(" ORDER BY " + order.OrderBy())
+
 	fmt.Fprintf(&sb, " LIMIT $%d OFFSET $%d", argCount, argCount+1)
-	args = append(args, limit, offset)
+	args = append(args, page.Fetch(), page.Offset())
 
 	query := sb.String()
 
 	rows, err := db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer rows.Close()
 
 	var products []model.Products
+	var keys [][]string
 	for rows.Next() {
 		var p model.Products
-		var createdAt any
+		var key []string
 
 		err := rows.Scan(
 			&p.Id,
+			&p.MenuId,
 			&p.Name,
 			&p.Images_Name,
 			&p.Price,
 			&p.Discount,
 			&p.Rating,
-			&createdAt,
+			&p.ReviewCount,
+			&key,
 		)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		products = append(products, p)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
 	}
 
-	return products, rows.Err()
+	products, next := pagination.Trim(order, page, products, keys)
+	return products, next, nil
 }
 
-func (pr *ProductRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.ProductQueries) (int, error) {
+// CountProducts counts the products GetProducts lists with the same filters.
+func (pr *ProductRepository) CountProducts(ctx context.Context, db DBTX, req dto.ProductQueries) (int, error) {
 	var sb strings.Builder
 	args := []any{}
 	argCount := 1
@@ -229,16 +308,26 @@ func (pr *ProductRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.
 		WITH product_menu AS (
 			SELECT DISTINCT ON (m.product_id)
 				m.product_id,
-				m.discount
+				COALESCE(m.discount, 0) AS discount
 			FROM menus m
+			JOIN outlets o ON o.id = m.outlet_id AND o.is_active AND o.deleted_at IS NULL
 			WHERE m.deleted_at IS NULL
+	`)
+
+	if req.Outlet != 0 {
+		fmt.Fprintf(&sb, " AND m.outlet_id = $%d", argCount)
+		args = append(args, req.Outlet)
+		argCount++
+	}
+
+	sb.WriteString(`
 			ORDER BY m.product_id, m.created_at DESC
 		)
 		SELECT COUNT(DISTINCT p.id)
 		FROM products p
 		JOIN product_menu pm ON pm.product_id = p.id
-		LEFT JOIN product_categories pc ON pc.product_id = p.id
-		LEFT JOIN categories c ON c.id = pc.category_id
+		LEFT JOIN product_categories pc ON pc.product_id = p.id AND pc.deleted_at IS NULL
+		LEFT JOIN categories c ON c.id = pc.category_id AND c.deleted_at IS NULL
 		WHERE p.deleted_at IS NULL
 	`)
 
@@ -253,23 +342,29 @@ func (pr *ProductRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.
 	}
 
 	if req.Title != "" {
-		fmt.Fprintf(&sb, " AND p.name ILIKE $%d", argCount)
-		args = append(args, "%"+req.Title+"%")
+		fmt.Fprintf(&sb, " AND "+productSearchSQL, argCount)
+		args = append(args, req.Title)
 		argCount++
 	}
 
 	if req.Min != "" {
-		fmt.Fprintf(&sb, " AND (p.price - (p.price * pm.discount / 100))::numeric >= $%d::numeric", argCount)
+		fmt.Fprintf(&sb, " AND "+discountedPriceSQL+" >= $%d::numeric", argCount)
 		args = append(args, req.Min)
 		argCount++
 	}
 
 	if req.Max != "" {
-		fmt.Fprintf(&sb, " AND (p.price - (p.price * pm.discount / 100))::numeric <= $%d::numeric", argCount)
+		fmt.Fprintf(&sb, " AND "+discountedPriceSQL+" <= $%d::numeric", argCount)
 		args = append(args, req.Max)
 		argCount++
 	}
 
+	if req.ID != "" {
+		fmt.Fprintf(&sb, " AND p.id != $%d", argCount)
+		args = append(args, req.ID)
+		argCount++
+	}
+
 	query := sb.String()
 
 	var totalProducts int
@@ -278,10 +373,59 @@ func (pr *ProductRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.
 		return 0, err
 	}
 
-	itemsPerPage := 6
-	totalPage := int(math.Ceil(float64(totalProducts) / float64(itemsPerPage)))
+	return totalProducts, nil
+}
 
-	return totalPage, nil
+// RefreshSearchVector rebuilds the search vector of a product after its
+// name, description or categories changed.
+func (pr *ProductRepository) RefreshSearchVector(ctx context.Context, db DBTX, idProduct int) error {
+	return refreshProductSearch(ctx, db, "p.id = $1", idProduct)
+}
+
+// SuggestProducts returns up to 8 orderable products for autocomplete.
+// Names starting with the term come first, then names containing it, then
+// names that are close to it.
+func (pr *ProductRepository) SuggestProducts(ctx context.Context, db DBTX, q string) ([]model.ProductSuggestion, error) {
+	query := `
+		SELECT p.id, pm.menu_id, p.name
+		FROM products p
+		JOIN LATERAL (
+			SELECT m.id AS menu_id
+			FROM menus m
+			JOIN outlets o ON o.id = m.outlet_id AND o.is_active AND o.deleted_at IS NULL
+			WHERE m.product_id = p.id AND m.deleted_at IS NULL
+			ORDER BY m.created_at DESC
+			LIMIT 1
+		) pm ON true
+		WHERE p.deleted_at IS NULL
+			AND (p.name ILIKE '%' || $1 || '%' OR $1 <% p.name)
+		ORDER BY
+			p.name ILIKE $1 || '%' DESC,
+			p.name ILIKE '%' || $1 || '%' DESC,
+			word_similarity($1, p.name) DESC,
+			p.review_count DESC,
+			p.id
+		LIMIT 8
+	`
+
+	rows, err := db.Query(ctx, query, q)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []model.ProductSuggestion
+	for rows.Next() {
+		var suggestion model.ProductSuggestion
+		if err := rows.Scan(&suggestion.Id, &suggestion.MenuId, &suggestion.Name); err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, rows.Err()
 }
 
 func (p ProductRepository) PostProduct(ctx context.Context, db DBTX, post dto.PostProductsRequest) (dto.PostProductResponse, error) {
@@ -392,32 +536,98 @@ func (p ProductRepository) GetProductById(ctx context.Context, db DBTX, idProduc
 	return prdDetail, nil
 }
 
-func (p ProductRepository) GetDetailProductByUserWithId(ctx context.Context, db DBTX, idMenu int) (model.DetailProductUser, error) {
+// SetProductCategories makes the given ids the only active categories of the
+// product. Links that are dropped are soft deleted, ids that are already
+// linked are kept as they are.
+func (p ProductRepository) SetProductCategories(ctx context.Context, db DBTX, idProduct int, categoryIds []int) error {
+	var found int
+	checkStr := "SELECT COUNT(DISTINCT id) FROM categories WHERE id = ANY($1) AND deleted_at IS NULL"
+	if err := db.QueryRow(ctx, checkStr, categoryIds).Scan(&found); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrGetCategory
+	}
+
+	unique := map[int]struct{}{}
+	for _, id := range categoryIds {
+		unique[id] = struct{}{}
+	}
+	if found != len(unique) {
+		return apperror.ErrCategoryNotFound
+	}
+
+	deleteStr := `
+		UPDATE product_categories
+		SET deleted_at = NOW()
+		WHERE product_id = $1 AND deleted_at IS NULL AND category_id <> ALL($2)
+	`
+	if _, err := db.Exec(ctx, deleteStr, idProduct, categoryIds); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateCategory
+	}
+
+	insertStr := `
+		INSERT INTO product_categories (product_id, category_id)
+		SELECT $1, c.id
+		FROM unnest($2::int[]) AS c(id)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM product_categories pc
+			WHERE pc.product_id = $1 AND pc.category_id = c.id AND pc.deleted_at IS NULL
+		)
+		GROUP BY c.id
+	`
+	if _, err := db.Exec(ctx, insertStr, idProduct, categoryIds); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateCategory
+	}
+
+	return nil
+}
+
+func (p ProductRepository) GetProductCategories(ctx context.Context, db DBTX, idProduct int) ([]model.Category, error) {
 	sqlStr := `
-		WITH avg_rating AS (
-  			SELECT AVG(r.rating) AS "rating_product",
-  			d.menu_id AS "idmenu"
-  	FROM reviews r
-  	JOIN dt_order d ON d.id = r.id
-  	JOIN menus m ON m.id = d.menu_id
-  	GROUP BY d.menu_id
-	)
+		SELECT c.id, c.name
+		FROM product_categories pc
+		JOIN categories c ON c.id = pc.category_id
+		WHERE pc.product_id = $1 AND pc.deleted_at IS NULL AND c.deleted_at IS NULL
+		ORDER BY c.name
+	`
+
+	rows, err := db.Query(ctx, sqlStr, idProduct)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetCategory
+	}
+	defer rows.Close()
+
+	var categories []model.Category
+	for rows.Next() {
+		var c model.Category
+		if err := rows.Scan(&c.ID, &c.Name); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetCategory
+		}
+		categories = append(categories, c)
+	}
 
+	return categories, rows.Err()
+}
+
+func (p ProductRepository) GetDetailProductByUserWithId(ctx context.Context, db DBTX, idMenu int) (model.DetailProductUser, error) {
+	sqlStr := `
 		SELECT
 			p.id,
     	p.name,
     	string_agg(pi.image, ',') AS "image product",
     	p.price,
 			p.description,
-    	CAST(m.discount AS FLOAT4),
-    	COALESCE(ar."rating_product",0),
-			COUNT(ar."idmenu") AS "count reviews"
+    	CAST(COALESCE(m.discount, 0) AS FLOAT4),
+    	p.rating_avg,
+			p.review_count
   	FROM menus m
-  	LEFT JOIN avg_rating ar ON ar."idmenu"= m.id
   	LEFT JOIN products p ON p.id = m.product_id
   	LEFT JOIN product_images pi ON pi.product_id = m.product_id
 		WHERE m.id = $1
-  	GROUP BY p.id, m.id, ar."rating_product"
+  	GROUP BY p.id, m.id
 	`
 
 	values := []any{idMenu}
@@ -434,7 +644,7 @@ func (p ProductRepository) GetDetailProductByUserWithId(ctx context.Context, db
 }
 
 func (pr *ProductRepository) GetAllProductType(ctx context.Context, db DBTX) ([]model.ProductType, error) {
-	sqlStr := `SELECT id, name, price FROM product_type`
+	sqlStr := `SELECT id, name, price FROM product_type WHERE deleted_at IS NULL ORDER BY id`
 
 	rows, err := db.Query(ctx, sqlStr)
 	if err != nil {
@@ -455,7 +665,7 @@ func (pr *ProductRepository) GetAllProductType(ctx context.Context, db DBTX) ([]
 }
 
 func (pr *ProductRepository) GetAllProductSize(ctx context.Context, db DBTX) ([]model.ProductSize, error) {
-	sqlStr := `SELECT id, name, price FROM product_size`
+	sqlStr := `SELECT id, name, price FROM product_size WHERE deleted_at IS NULL ORDER BY id`
 
 	rows, err := db.Query(ctx, sqlStr)
 	if err != nil {
@@ -474,3 +684,288 @@ func (pr *ProductRepository) GetAllProductSize(ctx context.Context, db DBTX) ([]
 
 	return productSizes, rows.Err()
 }
+
+func (pr *ProductRepository) CreateProductSize(ctx context.Context, db DBTX, req dto.ProductOptionRequest) (model.ProductSize, error) {
+	sqlStr := `INSERT INTO product_size (name, price) VALUES ($1, $2) RETURNING id, name, price`
+
+	var ps model.ProductSize
+	if err := db.QueryRow(ctx, sqlStr, req.Name, req.Price).Scan(&ps.Id, &ps.Name, &ps.Price); err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return model.ProductSize{}, apperror.ErrProductSizeExists
+		}
+		return model.ProductSize{}, apperror.ErrCreateProductSize
+	}
+
+	return ps, nil
+}
+
+func (pr *ProductRepository) UpdateProductSize(ctx context.Context, db DBTX, req dto.UpdateProductOptionRequest, id int) error {
+	sqlStr, args, err := buildProductOptionUpdate("product_size", req, id)
+	if err != nil {
+		return err
+	}
+
+	ct, err := db.Exec(ctx, sqlStr, args...)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return apperror.ErrProductSizeExists
+		}
+		return apperror.ErrUpdateProductSize
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrProductSizeNotFound
+	}
+
+	return nil
+}
+
+// DeleteProductSize retires the size. The row stays so dt_order lines that
+// reference it keep their name in the order history.
+func (pr *ProductRepository) DeleteProductSize(ctx context.Context, db DBTX, id int) error {
+	sqlStr := `UPDATE product_size SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	ct, err := db.Exec(ctx, sqlStr, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteProductSize
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrProductSizeNotFound
+	}
+
+	return nil
+}
+
+func (pr *ProductRepository) CreateProductType(ctx context.Context, db DBTX, req dto.ProductOptionRequest) (model.ProductType, error) {
+	sqlStr := `INSERT INTO product_type (name, price) VALUES ($1, $2) RETURNING id, name, price`
+
+	var pt model.ProductType
+	if err := db.QueryRow(ctx, sqlStr, req.Name, req.Price).Scan(&pt.Id, &pt.Name, &pt.Price); err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return model.ProductType{}, apperror.ErrProductTypeExists
+		}
+		return model.ProductType{}, apperror.ErrCreateProductType
+	}
+
+	return pt, nil
+}
+
+func (pr *ProductRepository) UpdateProductType(ctx context.Context, db DBTX, req dto.UpdateProductOptionRequest, id int) error {
+	sqlStr, args, err := buildProductOptionUpdate("product_type", req, id)
+	if err != nil {
+		return err
+	}
+
+	ct, err := db.Exec(ctx, sqlStr, args...)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return apperror.ErrProductTypeExists
+		}
+		return apperror.ErrUpdateProductType
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrProductTypeNotFound
+	}
+
+	return nil
+}
+
+// DeleteProductType retires the type. The row stays so dt_order lines that
+// reference it keep their name in the order history.
+func (pr *ProductRepository) DeleteProductType(ctx context.Context, db DBTX, id int) error {
+	sqlStr := `UPDATE product_type SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	ct, err := db.Exec(ctx, sqlStr, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteProductType
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrProductTypeNotFound
+	}
+
+	return nil
+}
+
+// buildProductOptionUpdate builds the UPDATE shared by product_size and
+// product_type, which have the same columns.
+func buildProductOptionUpdate(table string, req dto.UpdateProductOptionRequest, id int) (string, []any, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "UPDATE %s SET ", table)
+	args := []any{}
+
+	if req.Name != "" {
+		fmt.Fprintf(&sb, "name = $%d", len(args)+1)
+		args = append(args, req.Name)
+	}
+
+	if req.Price != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "price = $%d", len(args)+1)
+		args = append(args, *req.Price)
+	}
+
+	if len(args) == 0 {
+		return "", nil, apperror.ErrNoFieldsToUpdate
+	}
+
+	fmt.Fprintf(&sb, ", updated_at = NOW() WHERE id = $%d AND deleted_at IS NULL", len(args)+1)
+	args = append(args, id)
+
+	return sb.String(), args, nil
+}
+
+func (pr *ProductRepository) ProductExists(ctx context.Context, db DBTX, idProduct int) error {
+	sqlStr := `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`
+
+	var exists bool
+	if err := db.QueryRow(ctx, sqlStr, idProduct).Scan(&exists); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrGetProductOptions
+	}
+
+	if !exists {
+		return apperror.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (pr *ProductRepository) SetProductSizeOptions(ctx context.Context, db DBTX, idProduct int, items []dto.ProductOptionItem) error {
+	return setProductOptions(ctx, db, "product_size", idProduct, items, apperror.ErrProductSizeNotFound)
+}
+
+func (pr *ProductRepository) SetProductTypeOptions(ctx context.Context, db DBTX, idProduct int, items []dto.ProductOptionItem) error {
+	return setProductOptions(ctx, db, "product_type", idProduct, items, apperror.ErrProductTypeNotFound)
+}
+
+// setProductOptions replaces the rows of product_size_options or
+// product_type_options for the product. Options that are kept get their
+// price override updated, a nil price falls back to the option's own price.
+func setProductOptions(ctx context.Context, db DBTX, table string, idProduct int, items []dto.ProductOptionItem, notFound error) error {
+	ids := make([]int, 0, len(items))
+	prices := make([]*int64, 0, len(items))
+	unique := map[int]struct{}{}
+	for _, item := range items {
+		if _, ok := unique[item.ID]; ok {
+			continue
+		}
+		unique[item.ID] = struct{}{}
+		ids = append(ids, item.ID)
+		prices = append(prices, (*int64)(item.Price))
+	}
+
+	if len(ids) > 0 {
+		var found int
+		checkStr := fmt.Sprintf("SELECT COUNT(id) FROM %s WHERE id = ANY($1) AND deleted_at IS NULL", table)
+		if err := db.QueryRow(ctx, checkStr, ids).Scan(&found); err != nil {
+			log.Println(err.Error())
+			return apperror.ErrUpdateProductOptions
+		}
+		if found != len(ids) {
+			return notFound
+		}
+	}
+
+	deleteStr := fmt.Sprintf("DELETE FROM %[1]s_options WHERE product_id = $1 AND %[1]s_id <> ALL($2)", table)
+	if _, err := db.Exec(ctx, deleteStr, idProduct, ids); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateProductOptions
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	upsertStr := fmt.Sprintf(`
+		INSERT INTO %[1]s_options (product_id, %[1]s_id, price)
+		SELECT $1, o.id, o.price
+		FROM unnest($2::int[], $3::int[]) AS o(id, price)
+		ON CONFLICT (product_id, %[1]s_id)
+		DO UPDATE SET price = EXCLUDED.price, updated_at = NOW()
+	`, table)
+	if _, err := db.Exec(ctx, upsertStr, idProduct, ids, prices); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateProductOptions
+	}
+
+	return nil
+}
+
+// GetProductSizeOptions lists the sizes the product offers with their
+// effective price. A product without configured sizes offers all of them.
+func (pr *ProductRepository) GetProductSizeOptions(ctx context.Context, db DBTX, idProduct int) ([]model.ProductSize, error) {
+	sqlStr := `
+		SELECT ps.id, ps.name, COALESCE(pso.price, ps.price)
+		FROM product_size ps
+		LEFT JOIN product_size_options pso ON pso.product_size_id = ps.id AND pso.product_id = $1
+		WHERE ps.deleted_at IS NULL
+			AND (pso.id IS NOT NULL OR NOT EXISTS (
+				SELECT 1 FROM product_size_options x WHERE x.product_id = $1
+			))
+		ORDER BY ps.id
+	`
+
+	rows, err := db.Query(ctx, sqlStr, idProduct)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetProductOptions
+	}
+	defer rows.Close()
+
+	var productSizes []model.ProductSize
+	for rows.Next() {
+		var ps model.ProductSize
+		if err := rows.Scan(&ps.Id, &ps.Name, &ps.Price); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetProductOptions
+		}
+		productSizes = append(productSizes, ps)
+	}
+
+	return productSizes, rows.Err()
+}
+
+// GetProductTypeOptions lists the types the product offers with their
+// effective price. A product without configured types offers all of them.
+func (pr *ProductRepository) GetProductTypeOptions(ctx context.Context, db DBTX, idProduct int) ([]model.ProductType, error) {
+	sqlStr := `
+		SELECT pt.id, pt.name, COALESCE(pto.price, pt.price)
+		FROM product_type pt
+		LEFT JOIN product_type_options pto ON pto.product_type_id = pt.id AND pto.product_id = $1
+		WHERE pt.deleted_at IS NULL
+			AND (pto.id IS NOT NULL OR NOT EXISTS (
+				SELECT 1 FROM product_type_options x WHERE x.product_id = $1
+			))
+		ORDER BY pt.id
+	`
+
+	rows, err := db.Query(ctx, sqlStr, idProduct)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetProductOptions
+	}
+	defer rows.Close()
+
+	var productTypes []model.ProductType
+	for rows.Next() {
+		var pt model.ProductType
+		if err := rows.Scan(&pt.Id, &pt.Name, &pt.Price); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetProductOptions
+		}
+		productTypes = append(productTypes, pt)
+	}
+
+	return productTypes, rows.Err()
+}
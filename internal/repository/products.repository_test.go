@@ -0,0 +1,90 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/testutil"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
+)
+
+// aggregateListing is the first page of the "Recommended" product listing as
+// it was computed before the rating_avg and review_count columns, joining
+// every review through dt_order and menus on each request.
+const aggregateListing = `
+	WITH avg_rating AS (
+		SELECT AVG(r.rating) AS rating_product, d.menu_id AS idmenu
+		FROM reviews r
+		JOIN dt_order d ON d.id = r.dt_orderid
+		WHERE r.deleted_at IS NULL AND r.hidden_at IS NULL
+		GROUP BY d.menu_id
+	),
+	product_avg_rating AS (
+		SELECT m.product_id, AVG(ar.rating_product) AS avg_rating
+		FROM menus m
+		LEFT JOIN avg_rating ar ON ar.idmenu = m.id
+		WHERE m.deleted_at IS NULL
+		GROUP BY m.product_id
+	),
+	product_menu AS (
+		SELECT DISTINCT ON (m.product_id) m.id AS menu_id, m.product_id, COALESCE(m.discount, 0) AS discount
+		FROM menus m
+		JOIN outlets o ON o.id = m.outlet_id AND o.is_active AND o.deleted_at IS NULL
+		WHERE m.deleted_at IS NULL
+		ORDER BY m.product_id, m.created_at DESC
+	)
+	SELECT p.id, pm.menu_id, p.name, COALESCE(STRING_AGG(DISTINCT pi.image, ','), ''), p.price, pm.discount,
+		COALESCE(par.avg_rating, 0) AS rating_product
+	FROM products p
+	JOIN product_menu pm ON pm.product_id = p.id
+	LEFT JOIN product_avg_rating par ON par.product_id = p.id
+	LEFT JOIN product_images pi ON pi.product_id = p.id
+	WHERE p.deleted_at IS NULL
+	GROUP BY p.id, pm.menu_id, p.name, p.price, pm.discount, par.avg_rating
+	ORDER BY rating_product DESC
+	LIMIT 6 OFFSET 0
+`
+
+// BenchmarkProductListing compares the "Recommended" listing read from the
+// materialized rating columns with the aggregate over all reviews it
+// replaced. Both return the first page of six products.
+func BenchmarkProductListing(b *testing.B) {
+	db := testutil.DB(b)
+	ctx := context.Background()
+
+	var reviews int
+	if err := db.QueryRow(ctx, "SELECT COUNT(id) FROM reviews WHERE deleted_at IS NULL").Scan(&reviews); err != nil {
+		b.Fatal(err)
+	}
+	b.Logf("reviews: %d", reviews)
+
+	b.Run("aggregate", func(b *testing.B) {
+		for b.Loop() {
+			rows, err := db.Query(ctx, aggregateListing)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for rows.Next() {
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("materialized", func(b *testing.B) {
+		productRepository := NewProductRepository()
+		req := dto.ProductQueries{Category: []string{"Recommended"}}
+		page := pagination.Page{Number: 1, Limit: 6}
+
+		for b.Loop() {
+			if _, _, err := productRepository.GetProducts(ctx, db, req, page); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
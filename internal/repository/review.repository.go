@@ -0,0 +1,440 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type ReviewRepo interface {
+	GetReviewableItem(ctx context.Context, db DBTX, dtOrderId int) (model.ReviewableItem, error)
+	CreateReview(ctx context.Context, db DBTX, req dto.AddReview, userId int, photo string) (int, error)
+	GetReview(ctx context.Context, db DBTX, id int) (model.Review, error)
+	LockReview(ctx context.Context, db DBTX, id int) (model.Review, error)
+	UpdateReview(ctx context.Context, db DBTX, req dto.UpdateReviewRequest, photo string, id int) error
+	DeleteReview(ctx context.Context, db DBTX, id int) error
+	GetProductReviews(ctx context.Context, db DBTX, productId int, req dto.ReviewParams) ([]model.Review, error)
+	GetRatingDistribution(ctx context.Context, db DBTX, productId int) ([]model.RatingCount, error)
+	GetReviews(ctx context.Context, db DBTX, req dto.AdminReviewParams) ([]model.Review, error)
+	GetTotalPage(ctx context.Context, db DBTX, req dto.AdminReviewParams) (int, error)
+	SetReviewHidden(ctx context.Context, db DBTX, id int, hidden bool, reason string, adminId int) error
+	AdjustProductRating(ctx context.Context, db DBTX, productId, countDelta, ratingDelta int) error
+	RebuildProductRatings(ctx context.Context, db DBTX) (int64, error)
+}
+
+type ReviewRepository struct{}
+
+func NewReviewRepository() *ReviewRepository {
+	return &ReviewRepository{}
+}
+
+const reviewSelect = `
+	SELECT
+		r.id,
+		r.dt_orderid,
+		m.product_id,
+		p.name,
+		COALESCE(r.user_id, 0),
+		COALESCE(u.fullname, ''),
+		r.rating,
+		r.comment,
+		r.photo,
+		r.created_at,
+		r.updated_at,
+		r.hidden_at,
+		r.hidden_reason
+	FROM reviews r
+	JOIN dt_order d ON d.id = r.dt_orderid
+	JOIN menus m ON m.id = d.menu_id
+	JOIN products p ON p.id = m.product_id
+	LEFT JOIN users u ON u.id = r.user_id
+	WHERE r.deleted_at IS NULL
+`
+
+func scanReview(row pgx.Row) (model.Review, error) {
+	var review model.Review
+	err := row.Scan(
+		&review.ID,
+		&review.DtOrderId,
+		&review.ProductId,
+		&review.ProductName,
+		&review.UserId,
+		&review.Fullname,
+		&review.Rating,
+		&review.Comment,
+		&review.Photo,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+		&review.HiddenAt,
+		&review.HiddenReason,
+	)
+	return review, err
+}
+
+func scanReviews(rows pgx.Rows) ([]model.Review, error) {
+	defer rows.Close()
+
+	var reviews []model.Review
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetReview
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
+
+// GetReviewableItem returns the order line with the owner and status of its
+// order, which decide whether it can be reviewed.
+func (rr *ReviewRepository) GetReviewableItem(ctx context.Context, db DBTX, dtOrderId int) (model.ReviewableItem, error) {
+	query := `
+		SELECT d.id, m.product_id, COALESCE(o.user_id, 0), COALESCE(o.status, '')
+		FROM dt_order d
+		JOIN orders o ON o.id = d.order_id
+		JOIN menus m ON m.id = d.menu_id
+		WHERE d.id = $1
+	`
+
+	var item model.ReviewableItem
+	if err := db.QueryRow(ctx, query, dtOrderId).Scan(&item.DtOrderId, &item.ProductId, &item.UserId, &item.Status); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ReviewableItem{}, apperror.ErrOrderItemNotFound
+		}
+		return model.ReviewableItem{}, apperror.ErrGetReview
+	}
+
+	return item, nil
+}
+
+func (rr *ReviewRepository) CreateReview(ctx context.Context, db DBTX, req dto.AddReview, userId int, photo string) (int, error) {
+	query := `
+		INSERT INTO reviews (dt_orderid, user_id, rating, comment, photo)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id
+	`
+
+	var id int
+	if err := db.QueryRow(ctx, query, req.DtOrderId, userId, req.Rating, req.Comment, photo).Scan(&id); err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return 0, apperror.ErrReviewExists
+		}
+		return 0, apperror.ErrCreateReview
+	}
+
+	return id, nil
+}
+
+// GetReview returns a review whether it is hidden or not.
+func (rr *ReviewRepository) GetReview(ctx context.Context, db DBTX, id int) (model.Review, error) {
+	review, err := scanReview(db.QueryRow(ctx, reviewSelect+" AND r.id = $1", id))
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Review{}, apperror.ErrReviewNotFound
+		}
+		return model.Review{}, apperror.ErrGetReview
+	}
+
+	return review, nil
+}
+
+// LockReview is GetReview for a transaction that changes the review. The
+// row stays locked until the transaction ends, so the rating it reads is the
+// one being replaced.
+func (rr *ReviewRepository) LockReview(ctx context.Context, db DBTX, id int) (model.Review, error) {
+	review, err := scanReview(db.QueryRow(ctx, reviewSelect+" AND r.id = $1 FOR UPDATE OF r", id))
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Review{}, apperror.ErrReviewNotFound
+		}
+		return model.Review{}, apperror.ErrGetReview
+	}
+
+	return review, nil
+}
+
+func (rr *ReviewRepository) UpdateReview(ctx context.Context, db DBTX, req dto.UpdateReviewRequest, photo string, id int) error {
+	var sb strings.Builder
+	sb.WriteString("UPDATE reviews SET ")
+	args := []any{}
+
+	if req.Rating != 0 {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "rating = $%d", len(args)+1)
+		args = append(args, req.Rating)
+	}
+
+	if req.Comment != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "comment = $%d", len(args)+1)
+		args = append(args, req.Comment)
+	}
+
+	if photo != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "photo = $%d", len(args)+1)
+		args = append(args, photo)
+	}
+
+	if len(args) == 0 {
+		return apperror.ErrNoFieldsToUpdate
+	}
+
+	fmt.Fprintf(&sb, ", updated_at = NOW() WHERE id = $%d AND deleted_at IS NULL", len(args)+1)
+	args = append(args, id)
+
+	ct, err := db.Exec(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateReview
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrReviewNotFound
+	}
+
+	return nil
+}
+
+func (rr *ReviewRepository) DeleteReview(ctx context.Context, db DBTX, id int) error {
+	query := "UPDATE reviews SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	ct, err := db.Exec(ctx, query, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteReview
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrReviewNotFound
+	}
+
+	return nil
+}
+
+// GetProductReviews lists the visible reviews of a product newest first,
+// over the menus of every outlet.
+func (rr *ReviewRepository) GetProductReviews(ctx context.Context, db DBTX, productId int, req dto.ReviewParams) ([]model.Review, error) {
+	limit := 5
+	offset := 0
+	if req.Page != "" {
+		page, _ := strconv.Atoi(req.Page)
+		if page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	query := reviewSelect + " AND r.hidden_at IS NULL AND m.product_id = $1 ORDER BY r.created_at DESC, r.id DESC LIMIT $2 OFFSET $3"
+
+	rows, err := db.Query(ctx, query, productId, limit, offset)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetReview
+	}
+
+	return scanReviews(rows)
+}
+
+// GetRatingDistribution counts the visible reviews of a product per rating.
+// Ratings nobody gave are left out.
+func (rr *ReviewRepository) GetRatingDistribution(ctx context.Context, db DBTX, productId int) ([]model.RatingCount, error) {
+	query := `
+		SELECT r.rating, COUNT(r.id)
+		FROM reviews r
+		JOIN dt_order d ON d.id = r.dt_orderid
+		JOIN menus m ON m.id = d.menu_id
+		WHERE r.deleted_at IS NULL AND r.hidden_at IS NULL AND m.product_id = $1
+		GROUP BY r.rating
+		ORDER BY r.rating DESC
+	`
+
+	rows, err := db.Query(ctx, query, productId)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetReview
+	}
+	defer rows.Close()
+
+	var counts []model.RatingCount
+	for rows.Next() {
+		var count model.RatingCount
+		if err := rows.Scan(&count.Rating, &count.Total); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetReview
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
+
+func reviewFilters(sb *strings.Builder, args []any, req dto.AdminReviewParams) []any {
+	if req.ProductId != 0 {
+		fmt.Fprintf(sb, " AND m.product_id = $%d", len(args)+1)
+		args = append(args, req.ProductId)
+	}
+
+	switch req.Hidden {
+	case "true":
+		sb.WriteString(" AND r.hidden_at IS NOT NULL")
+	case "false":
+		sb.WriteString(" AND r.hidden_at IS NULL")
+	}
+
+	return args
+}
+
+// GetReviews lists reviews for moderation, hidden ones included.
+func (rr *ReviewRepository) GetReviews(ctx context.Context, db DBTX, req dto.AdminReviewParams) ([]model.Review, error) {
+	var sb strings.Builder
+	sb.WriteString(reviewSelect)
+	args := reviewFilters(&sb, []any{}, req)
+
+	limit := 5
+	offset := 0
+	if req.Page != "" {
+		page, _ := strconv.Atoi(req.Page)
+		if page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	fmt.Fprintf(&sb, " ORDER BY r.created_at DESC, r.id DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.Query(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetReview
+	}
+
+	return scanReviews(rows)
+}
+
+func (rr *ReviewRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.AdminReviewParams) (int, error) {
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT COUNT(r.id)
+		FROM reviews r
+		JOIN dt_order d ON d.id = r.dt_orderid
+		JOIN menus m ON m.id = d.menu_id
+		WHERE r.deleted_at IS NULL
+	`)
+	args := reviewFilters(&sb, []any{}, req)
+
+	var totalReviews int
+	if err := db.QueryRow(ctx, sb.String(), args...).Scan(&totalReviews); err != nil {
+		return 0, err
+	}
+
+	itemsPerPage := 5
+	totalPage := int(math.Ceil(float64(totalReviews) / float64(itemsPerPage)))
+
+	return totalPage, nil
+}
+
+// SetReviewHidden hides a review from the product pages and ratings, or
+// shows it again. The moderator and reason are kept with the review.
+func (rr *ReviewRepository) SetReviewHidden(ctx context.Context, db DBTX, id int, hidden bool, reason string, adminId int) error {
+	query := `
+		UPDATE reviews
+		SET hidden_at = NULL, hidden_by = NULL, hidden_reason = NULL
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	args := []any{id}
+
+	if hidden {
+		query = `
+			UPDATE reviews
+			SET hidden_at = COALESCE(hidden_at, NOW()), hidden_by = $2, hidden_reason = NULLIF($3, '')
+			WHERE id = $1 AND deleted_at IS NULL
+		`
+		args = append(args, adminId, reason)
+	}
+
+	ct, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateReview
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrReviewNotFound
+	}
+
+	return nil
+}
+
+// AdjustProductRating applies a change of the product's visible reviews to
+// its rating columns, countDelta reviews and ratingDelta stars.
+func (rr *ReviewRepository) AdjustProductRating(ctx context.Context, db DBTX, productId, countDelta, ratingDelta int) error {
+	query := `
+		UPDATE products
+		SET review_count = review_count + $2,
+			rating_total = rating_total + $3,
+			rating_avg = CASE
+				WHEN review_count + $2 > 0 THEN (rating_total + $3)::float8 / (review_count + $2)
+				ELSE 0
+			END
+		WHERE id = $1
+	`
+
+	if _, err := db.Exec(ctx, query, productId, countDelta, ratingDelta); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateReview
+	}
+
+	return nil
+}
+
+// RebuildProductRatings recomputes the rating columns of every product from
+// the reviews table and returns the number of products that changed.
+func (rr *ReviewRepository) RebuildProductRatings(ctx context.Context, db DBTX) (int64, error) {
+	query := `
+		WITH stats AS (
+			SELECT m.product_id, SUM(r.rating) AS rating_total, COUNT(r.id) AS review_count
+			FROM reviews r
+			JOIN dt_order d ON d.id = r.dt_orderid
+			JOIN menus m ON m.id = d.menu_id
+			WHERE r.deleted_at IS NULL AND r.hidden_at IS NULL
+			GROUP BY m.product_id
+		)
+		UPDATE products p
+		SET rating_total = COALESCE(s.rating_total, 0),
+			review_count = COALESCE(s.review_count, 0),
+			rating_avg = COALESCE(s.rating_total::float8 / s.review_count, 0)
+		FROM products pp
+		LEFT JOIN stats s ON s.product_id = pp.id
+		WHERE pp.id = p.id
+			AND (p.rating_total, p.review_count) IS DISTINCT FROM (COALESCE(s.rating_total, 0), COALESCE(s.review_count, 0))
+	`
+
+	ct, err := db.Exec(ctx, query)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, apperror.ErrUpdateReview
+	}
+
+	return ct.RowsAffected(), nil
+}
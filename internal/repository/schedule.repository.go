@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type ScheduleRepo interface {
+	CreateOpeningHours(ctx context.Context, db DBTX, outletId int) error
+	GetOpeningHours(ctx context.Context, db DBTX, outletId int) ([]model.OpeningHours, error)
+	GetOpeningHoursByWeekday(ctx context.Context, db DBTX, outletId, weekday int) (model.OpeningHours, error)
+	GetOpeningHoursForUpdate(ctx context.Context, db DBTX, outletId, weekday int) (model.OpeningHours, error)
+	UpdateOpeningHours(ctx context.Context, db DBTX, req dto.OpeningHoursRequest, outletId, weekday int) error
+	GetScheduledCounts(ctx context.Context, db DBTX, outletId int, from, to time.Time) ([]model.ScheduledCount, error)
+	CountScheduledOrders(ctx context.Context, db DBTX, outletId int, from, to time.Time) (int, error)
+}
+
+type ScheduleRepository struct{}
+
+func NewScheduleRepository() *ScheduleRepository {
+	return &ScheduleRepository{}
+}
+
+const openingHoursColumns = `
+	outlet_id,
+	weekday,
+	TO_CHAR(opens_at, 'HH24:MI'),
+	TO_CHAR(closes_at, 'HH24:MI'),
+	slot_minutes,
+	slot_capacity,
+	is_open
+`
+
+func scanOpeningHours(row pgx.Row) (model.OpeningHours, error) {
+	var hours model.OpeningHours
+	err := row.Scan(&hours.OutletId, &hours.Weekday, &hours.OpensAt, &hours.ClosesAt, &hours.SlotMinutes, &hours.SlotCapacity, &hours.IsOpen)
+	return hours, err
+}
+
+// CreateOpeningHours gives a new outlet the same default week the first
+// outlet started with, 08:00 to 22:00 every day.
+func (sr *ScheduleRepository) CreateOpeningHours(ctx context.Context, db DBTX, outletId int) error {
+	query := `
+		INSERT INTO opening_hours (outlet_id, weekday, opens_at, closes_at)
+		SELECT $1, d, '08:00', '22:00'
+		FROM generate_series(0, 6) AS d
+	`
+
+	if _, err := db.Exec(ctx, query, outletId); err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateOpeningHours
+	}
+
+	return nil
+}
+
+func (sr *ScheduleRepository) GetOpeningHours(ctx context.Context, db DBTX, outletId int) ([]model.OpeningHours, error) {
+	query := "SELECT " + openingHoursColumns + " FROM opening_hours WHERE outlet_id = $1 ORDER BY weekday"
+
+	rows, err := db.Query(ctx, query, outletId)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetOpeningHours
+	}
+	defer rows.Close()
+
+	var days []model.OpeningHours
+	for rows.Next() {
+		hours, err := scanOpeningHours(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetOpeningHours
+		}
+		days = append(days, hours)
+	}
+
+	return days, rows.Err()
+}
+
+func (sr *ScheduleRepository) GetOpeningHoursByWeekday(ctx context.Context, db DBTX, outletId, weekday int) (model.OpeningHours, error) {
+	query := "SELECT " + openingHoursColumns + " FROM opening_hours WHERE outlet_id = $1 AND weekday = $2"
+	return sr.getOpeningHours(ctx, db, query, outletId, weekday)
+}
+
+// GetOpeningHoursForUpdate locks the outlet's weekday row until the
+// transaction ends. Scheduled orders take this lock before counting a slot,
+// so two orders cannot both take its last place.
+func (sr *ScheduleRepository) GetOpeningHoursForUpdate(ctx context.Context, db DBTX, outletId, weekday int) (model.OpeningHours, error) {
+	query := "SELECT " + openingHoursColumns + " FROM opening_hours WHERE outlet_id = $1 AND weekday = $2 FOR UPDATE"
+	return sr.getOpeningHours(ctx, db, query, outletId, weekday)
+}
+
+func (sr *ScheduleRepository) getOpeningHours(ctx context.Context, db DBTX, query string, outletId, weekday int) (model.OpeningHours, error) {
+	hours, err := scanOpeningHours(db.QueryRow(ctx, query, outletId, weekday))
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.OpeningHours{}, apperror.ErrOpeningHoursNotFound
+		}
+		return model.OpeningHours{}, apperror.ErrGetOpeningHours
+	}
+
+	return hours, nil
+}
+
+func (sr *ScheduleRepository) UpdateOpeningHours(ctx context.Context, db DBTX, req dto.OpeningHoursRequest, outletId, weekday int) error {
+	query := `
+		UPDATE opening_hours
+		SET opens_at = $1::time, closes_at = $2::time, slot_minutes = $3, slot_capacity = $4, is_open = $5, updated_at = NOW()
+		WHERE outlet_id = $6 AND weekday = $7
+	`
+
+	ct, err := db.Exec(ctx, query, req.OpensAt, req.ClosesAt, req.SlotMinutes, *req.SlotCapacity, *req.IsOpen, outletId, weekday)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "opening_hours_period_check") {
+			return apperror.ErrOpeningHoursInvalid
+		}
+		return apperror.ErrUpdateOpeningHours
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrOpeningHoursNotFound
+	}
+
+	return nil
+}
+
+// GetScheduledCounts counts the outlet's orders scheduled at each time in
+// [from, to), cancelled orders give their place back.
+func (sr *ScheduleRepository) GetScheduledCounts(ctx context.Context, db DBTX, outletId int, from, to time.Time) ([]model.ScheduledCount, error) {
+	query := `
+		SELECT scheduled_for, COUNT(id)
+		FROM orders
+		WHERE outlet_id = $1 AND scheduled_for >= $2 AND scheduled_for < $3 AND status <> 'cancelled'
+		GROUP BY scheduled_for
+	`
+
+	rows, err := db.Query(ctx, query, outletId, from, to)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetSlots
+	}
+	defer rows.Close()
+
+	var counts []model.ScheduledCount
+	for rows.Next() {
+		var c model.ScheduledCount
+		if err := rows.Scan(&c.ScheduledFor, &c.Count); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetSlots
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+func (sr *ScheduleRepository) CountScheduledOrders(ctx context.Context, db DBTX, outletId int, from, to time.Time) (int, error) {
+	query := "SELECT COUNT(id) FROM orders WHERE outlet_id = $1 AND scheduled_for >= $2 AND scheduled_for < $3 AND status <> 'cancelled'"
+
+	var count int
+	if err := db.QueryRow(ctx, query, outletId, from, to).Scan(&count); err != nil {
+		log.Println(err.Error())
+		return 0, apperror.ErrGetSlots
+	}
+
+	return count, nil
+}
@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type TaxRepo interface {
+	CreateTaxRule(ctx context.Context, db DBTX, req dto.TaxRuleRequest) (int, error)
+	GetTaxRule(ctx context.Context, db DBTX, id int) (model.TaxRule, error)
+	GetTaxRules(ctx context.Context, db DBTX, req dto.TaxRuleParams, outletIds []int) ([]model.TaxRule, error)
+	GetTotalPage(ctx context.Context, db DBTX, req dto.TaxRuleParams, outletIds []int) (int, error)
+	UpdateTaxRule(ctx context.Context, db DBTX, req dto.UpdateTaxRuleRequest, id int) error
+	DeleteTaxRule(ctx context.Context, db DBTX, id int) error
+	GetEffectiveRules(ctx context.Context, db DBTX, outletId int, shipping string, at time.Time) ([]model.TaxRule, error)
+	CreateOrderTaxes(ctx context.Context, db DBTX, orderId string, taxes []model.OrderTax) error
+	GetOrderTaxes(ctx context.Context, db DBTX, orderId string) ([]model.OrderTax, error)
+}
+
+type TaxRepository struct{}
+
+func NewTaxRepository() *TaxRepository {
+	return &TaxRepository{}
+}
+
+const taxRuleColumns = `
+	t.id,
+	t.name,
+	t.kind,
+	t.rate,
+	t.shipping,
+	t.outlet_id,
+	t.is_inclusive,
+	t.effective_from,
+	t.effective_to,
+	t.effective_from <= CURRENT_DATE
+		AND (t.effective_to IS NULL OR t.effective_to >= CURRENT_DATE) AS is_active
+`
+
+func scanTaxRule(row pgx.Row) (model.TaxRule, error) {
+	var rule model.TaxRule
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Kind,
+		&rule.Rate,
+		&rule.Shipping,
+		&rule.OutletID,
+		&rule.IsInclusive,
+		&rule.EffectiveFrom,
+		&rule.EffectiveTo,
+		&rule.IsActive,
+	)
+	return rule, err
+}
+
+// taxRuleWriteError maps the period check constraint onto its own error, so
+// an update that only moves one end of the period is still reported clearly.
+func taxRuleWriteError(err error, fallback error) error {
+	if strings.Contains(err.Error(), "tax_rules_effective_check") {
+		return apperror.ErrTaxRuleInvalidPeriod
+	}
+	if strings.Contains(err.Error(), "tax_rules_outlet_id_fkey") {
+		return apperror.ErrOutletNotFound
+	}
+	return fallback
+}
+
+func (tr *TaxRepository) CreateTaxRule(ctx context.Context, db DBTX, req dto.TaxRuleRequest) (int, error) {
+	query := `
+		INSERT INTO
+		    tax_rules (name, kind, rate, shipping, outlet_id, is_inclusive, effective_from, effective_to)
+		VALUES
+		    ($1, $2, $3, NULLIF(LOWER($4), ''), $5, $6, $7::date, NULLIF($8, '')::date)
+		RETURNING id
+	`
+
+	var id int
+	if err := db.QueryRow(ctx, query,
+		req.Name,
+		req.Kind,
+		*req.Rate,
+		req.Shipping,
+		req.OutletID,
+		req.IsInclusive,
+		req.EffectiveFrom,
+		req.EffectiveTo,
+	).Scan(&id); err != nil {
+		log.Println(err.Error())
+		return 0, taxRuleWriteError(err, apperror.ErrCreateTaxRule)
+	}
+
+	return id, nil
+}
+
+func (tr *TaxRepository) GetTaxRule(ctx context.Context, db DBTX, id int) (model.TaxRule, error) {
+	query := "SELECT " + taxRuleColumns + " FROM tax_rules t WHERE t.id = $1 AND t.deleted_at IS NULL"
+
+	rule, err := scanTaxRule(db.QueryRow(ctx, query, id))
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.TaxRule{}, apperror.ErrTaxRuleNotFound
+		}
+		return model.TaxRule{}, apperror.ErrGetTaxRule
+	}
+
+	return rule, nil
+}
+
+// taxRuleFilters narrows a rule listing. A non-nil outletIds limits it to
+// rules for those outlets and the ones that apply everywhere.
+func taxRuleFilters(sb *strings.Builder, args []any, req dto.TaxRuleParams, outletIds []int) []any {
+	if outletIds != nil {
+		fmt.Fprintf(sb, " AND (t.outlet_id IS NULL OR t.outlet_id = ANY($%d))", len(args)+1)
+		args = append(args, outletIds)
+	}
+
+	if req.Search != "" {
+		fmt.Fprintf(sb, " AND t.name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	if req.Shipping != "" {
+		fmt.Fprintf(sb, " AND (t.shipping IS NULL OR t.shipping = LOWER($%d))", len(args)+1)
+		args = append(args, req.Shipping)
+	}
+
+	if req.OutletID != 0 {
+		fmt.Fprintf(sb, " AND (t.outlet_id IS NULL OR t.outlet_id = $%d)", len(args)+1)
+		args = append(args, req.OutletID)
+	}
+
+	return args
+}
+
+func (tr *TaxRepository) GetTaxRules(ctx context.Context, db DBTX, req dto.TaxRuleParams, outletIds []int) ([]model.TaxRule, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT " + taxRuleColumns + " FROM tax_rules t WHERE t.deleted_at IS NULL")
+	args = taxRuleFilters(&sb, args, req, outletIds)
+
+	limit := 5
+	offset := 0
+	if req.Page != "" {
+		page, _ := strconv.Atoi(req.Page)
+		if page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	fmt.Fprintf(&sb, " ORDER BY t.effective_from DESC, t.id DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.Query(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetTaxRule
+	}
+	defer rows.Close()
+
+	var rules []model.TaxRule
+	for rows.Next() {
+		rule, err := scanTaxRule(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetTaxRule
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func (tr *TaxRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.TaxRuleParams, outletIds []int) (int, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT COUNT(t.id) FROM tax_rules t WHERE t.deleted_at IS NULL")
+	args = taxRuleFilters(&sb, args, req, outletIds)
+
+	var totalRules int
+	if err := db.QueryRow(ctx, sb.String(), args...).Scan(&totalRules); err != nil {
+		return 0, err
+	}
+
+	itemsPerPage := 5
+	totalPage := int(math.Ceil(float64(totalRules) / float64(itemsPerPage)))
+
+	return totalPage, nil
+}
+
+func (tr *TaxRepository) UpdateTaxRule(ctx context.Context, db DBTX, req dto.UpdateTaxRuleRequest, id int) error {
+	var sb strings.Builder
+	sb.WriteString("UPDATE tax_rules SET ")
+	args := []any{}
+
+	if req.Name != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "name = $%d", len(args)+1)
+		args = append(args, req.Name)
+	}
+
+	if req.Kind != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "kind = $%d", len(args)+1)
+		args = append(args, req.Kind)
+	}
+
+	if req.Rate != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "rate = $%d", len(args)+1)
+		args = append(args, *req.Rate)
+	}
+
+	if req.Shipping != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "shipping = NULLIF(LOWER($%d), '')", len(args)+1)
+		args = append(args, *req.Shipping)
+	}
+
+	if req.OutletID != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "outlet_id = NULLIF($%d, 0)", len(args)+1)
+		args = append(args, *req.OutletID)
+	}
+
+	if req.IsInclusive != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "is_inclusive = $%d", len(args)+1)
+		args = append(args, *req.IsInclusive)
+	}
+
+	if req.EffectiveFrom != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "effective_from = $%d::date", len(args)+1)
+		args = append(args, req.EffectiveFrom)
+	}
+
+	if req.EffectiveTo != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "effective_to = NULLIF($%d, '')::date", len(args)+1)
+		args = append(args, *req.EffectiveTo)
+	}
+
+	if len(args) == 0 {
+		return apperror.ErrNoFieldsToUpdate
+	}
+
+	fmt.Fprintf(&sb, ", updated_at = NOW() WHERE id = $%d AND deleted_at IS NULL", len(args)+1)
+	args = append(args, id)
+
+	ct, err := db.Exec(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return taxRuleWriteError(err, apperror.ErrUpdateTaxRule)
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrTaxRuleNotFound
+	}
+
+	return nil
+}
+
+func (tr *TaxRepository) DeleteTaxRule(ctx context.Context, db DBTX, id int) error {
+	query := "UPDATE tax_rules SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	ct, err := db.Exec(ctx, query, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteTaxRule
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrTaxRuleNotFound
+	}
+
+	return nil
+}
+
+// GetEffectiveRules returns the rules in force on the given day at an outlet
+// for a shipping mode. Rules without an outlet or shipping mode apply to
+// every order, so an outlet of 0 or an empty shipping returns only those.
+// Service charges come first because taxes are calculated on top of them.
+func (tr *TaxRepository) GetEffectiveRules(ctx context.Context, db DBTX, outletId int, shipping string, at time.Time) ([]model.TaxRule, error) {
+	query := `
+		SELECT ` + taxRuleColumns + `
+		FROM tax_rules t
+		WHERE t.deleted_at IS NULL
+		  AND (t.outlet_id IS NULL OR t.outlet_id = $1)
+		  AND (t.shipping IS NULL OR t.shipping = LOWER($2))
+		  AND t.effective_from <= $3::date
+		  AND (t.effective_to IS NULL OR t.effective_to >= $3::date)
+		ORDER BY t.kind = 'tax', t.id
+	`
+
+	rows, err := db.Query(ctx, query, outletId, shipping, at.Format("2006-01-02"))
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetTaxRule
+	}
+	defer rows.Close()
+
+	var rules []model.TaxRule
+	for rows.Next() {
+		rule, err := scanTaxRule(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetTaxRule
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// CreateOrderTaxes stores the breakdown with the rule's name and rate copied
+// in, so editing or deleting a rule never changes past orders.
+func (tr *TaxRepository) CreateOrderTaxes(ctx context.Context, db DBTX, orderId string, taxes []model.OrderTax) error {
+	query := `
+		INSERT INTO
+		    order_taxes (order_id, tax_rule_id, name, kind, rate, is_inclusive, base, amount)
+		VALUES
+		    ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	for _, t := range taxes {
+		if _, err := db.Exec(ctx, query, orderId, t.TaxRuleId, t.Name, t.Kind, t.Rate, t.IsInclusive, t.Base, t.Amount); err != nil {
+			log.Println(err.Error())
+			return apperror.ErrCreateOrderTax
+		}
+	}
+
+	return nil
+}
+
+func (tr *TaxRepository) GetOrderTaxes(ctx context.Context, db DBTX, orderId string) ([]model.OrderTax, error) {
+	query := `
+		SELECT tax_rule_id, name, kind, rate, is_inclusive, base, amount
+		FROM order_taxes
+		WHERE order_id::text = $1
+		ORDER BY id
+	`
+
+	rows, err := db.Query(ctx, query, orderId)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetOrderTax
+	}
+	defer rows.Close()
+
+	var taxes []model.OrderTax
+	for rows.Next() {
+		var t model.OrderTax
+		if err := rows.Scan(&t.TaxRuleId, &t.Name, &t.Kind, &t.Rate, &t.IsInclusive, &t.Base, &t.Amount); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetOrderTax
+		}
+		taxes = append(taxes, t)
+	}
+
+	return taxes, rows.Err()
+}
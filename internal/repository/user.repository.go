@@ -5,13 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math"
-	"strconv"
 	"strings"
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -201,38 +200,52 @@ func (ur *UserRepository) DeleteUser(ctx context.Context, db DBTX, id int) error
 	return nil
 }
 
-func (ur *UserRepository) GetUsers(ctx context.Context, db DBTX, req dto.UserQueries) ([]model.User, error) {
-	query := `
+// userOrder is the keyset order of the user list.
+var userOrder = pagination.Order{Name: "users", Keys: []pagination.Key{{Expr: "id", Type: "int"}}}
+
+// GetUsers lists a page of users and returns the cursor of the next page,
+// empty on the last page.
+func (ur *UserRepository) GetUsers(ctx context.Context, db DBTX, page pagination.Page) ([]model.User, string, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString(`
 		SELECT
 		    id,
 		    fullname,
 		    email,
 		    photo,
 		    phone,
-		    address
+		    address,
+		    ` + userOrder.Select() + `
 		FROM users
-		LIMIT 5
-		OFFSET $1
-	`
+		WHERE TRUE
+	`)
 
-	offset := 0
-	if req.Page != "" {
-		page, _ := strconv.Atoi(req.Page)
-		if page > 0 {
-			offset = (page - 1) * 5
-		}
+	seek, seekArgs, err := userOrder.Seek(page, len(args)+1)
+	if err != nil {
+		return nil, "", err
 	}
+	if seek != "" {
+		sb.WriteString(" AND " + seek)
+		args = append(args, seekArgs...)
+	}
+
+	fmt.Fprintf(&sb, " ORDER BY %s LIMIT $%d OFFSET $%d", userOrder.OrderBy(), len(args)+1, len(args)+2)
+	args = append(args, page.Fetch(), page.Offset())
 
-	rows, err := db.Query(ctx, query, offset)
+	rows, err := db.Query(ctx, sb.String(), args...)
 	if err != nil {
 		log.Println("GetUsers error:", err.Error())
-		return nil, apperror.ErrGetUsers
+		return nil, "", apperror.ErrGetUsers
 	}
 	defer rows.Close()
 
 	var users []model.User
+	var keys [][]string
 	for rows.Next() {
 		var u model.User
+		var key []string
 		if err := rows.Scan(
 			&u.ID,
 			&u.Fullname,
@@ -240,17 +253,21 @@ func (ur *UserRepository) GetUsers(ctx context.Context, db DBTX, req dto.UserQue
 			&u.Photo,
 			&u.Phone,
 			&u.Address,
+			&key,
 		); err != nil {
 			log.Println("Scan error:", err.Error())
-			return nil, apperror.ErrGetUsers
+			return nil, "", apperror.ErrGetUsers
 		}
 		users = append(users, u)
+		keys = append(keys, key)
 	}
 
-	return users, nil
+	users, next := pagination.Trim(userOrder, page, users, keys)
+	return users, next, nil
 }
 
-func (ur *UserRepository) GetUserTotalPages(ctx context.Context, db DBTX) (int, error) {
+// CountUsers counts the users GetUsers lists.
+func (ur *UserRepository) CountUsers(ctx context.Context, db DBTX) (int, error) {
 	query := "SELECT COUNT(DISTINCT id) FROM users"
 
 	var user int
@@ -259,6 +276,5 @@ func (ur *UserRepository) GetUserTotalPages(ctx context.Context, db DBTX) (int,
 		return 0, err
 	}
 
-	totalPage := int(math.Ceil(float64(user) / float64(5)))
-	return totalPage, nil
+	return user, nil
 }
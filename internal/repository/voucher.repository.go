@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type VoucherRepo interface {
+	CreateVoucher(ctx context.Context, db DBTX, req dto.VoucherRequest) error
+	GetVoucher(ctx context.Context, db DBTX, id int) (model.Voucher, error)
+	GetVoucherForUpdate(ctx context.Context, db DBTX, id int) (model.Voucher, error)
+	GetVouchers(ctx context.Context, db DBTX, req dto.VoucherParams) ([]model.Voucher, error)
+	GetTotalPage(ctx context.Context, db DBTX, req dto.VoucherParams) (int, error)
+	UpdateVoucher(ctx context.Context, db DBTX, req dto.UpdateVoucherRequest, id int) error
+	DeleteVoucher(ctx context.Context, db DBTX, id int) error
+	GetVoucherByCode(ctx context.Context, db DBTX, code string) (model.Voucher, error)
+	IncrementUsage(ctx context.Context, db DBTX, id int) error
+}
+
+type VoucherRepository struct{}
+
+func NewVoucherRepository() *VoucherRepository {
+	return &VoucherRepository{}
+}
+
+const voucherColumns = `
+	v.id,
+	v.code,
+	v.name,
+	v.description,
+	v.discount,
+	v.min_order,
+	v.start_date,
+	v.end_date,
+	v.usage_limit,
+	v.usage_count,
+	(v.start_date IS NULL OR v.start_date <= CURRENT_DATE)
+		AND (v.end_date IS NULL OR v.end_date >= CURRENT_DATE) AS is_active
+`
+
+func scanVoucher(row pgx.Row) (model.Voucher, error) {
+	var voucher model.Voucher
+	err := row.Scan(
+		&voucher.ID,
+		&voucher.Code,
+		&voucher.Name,
+		&voucher.Description,
+		&voucher.Discount,
+		&voucher.MinOrder,
+		&voucher.StartDate,
+		&voucher.EndDate,
+		&voucher.UsageLimit,
+		&voucher.UsageCount,
+		&voucher.IsActive,
+	)
+	return voucher, err
+}
+
+func (vr *VoucherRepository) CreateVoucher(ctx context.Context, db DBTX, req dto.VoucherRequest) error {
+	query := `
+		INSERT INTO
+		    vouchers (code, name, description, discount, min_order, start_date, end_date, usage_limit)
+		VALUES
+		    ($1, $2, $3, $4, $5, NULLIF($6, '')::date, NULLIF($7, '')::date, $8)
+	`
+
+	_, err := db.Exec(ctx, query,
+		strings.ToUpper(req.Code),
+		req.Name,
+		req.Description,
+		req.Discount,
+		req.MinOrder,
+		req.StartDate,
+		req.EndDate,
+		req.UsageLimit,
+	)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return apperror.ErrVoucherExists
+		}
+		return apperror.ErrCreateVoucher
+	}
+
+	return nil
+}
+
+func (vr *VoucherRepository) GetVoucher(ctx context.Context, db DBTX, id int) (model.Voucher, error) {
+	return vr.getVoucher(ctx, db, "SELECT "+voucherColumns+" FROM vouchers v WHERE v.id = $1 AND v.deleted_at IS NULL", id)
+}
+
+// GetVoucherForUpdate is GetVoucher locking the row until the transaction
+// ends, so an update can be checked against the values it changes.
+func (vr *VoucherRepository) GetVoucherForUpdate(ctx context.Context, db DBTX, id int) (model.Voucher, error) {
+	return vr.getVoucher(ctx, db, "SELECT "+voucherColumns+" FROM vouchers v WHERE v.id = $1 AND v.deleted_at IS NULL FOR UPDATE", id)
+}
+
+func (vr *VoucherRepository) getVoucher(ctx context.Context, db DBTX, query string, id int) (model.Voucher, error) {
+	voucher, err := scanVoucher(db.QueryRow(ctx, query, id))
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Voucher{}, apperror.ErrVoucherNotFound
+		}
+		return model.Voucher{}, apperror.ErrGetVoucher
+	}
+
+	return voucher, nil
+}
+
+func (vr *VoucherRepository) GetVouchers(ctx context.Context, db DBTX, req dto.VoucherParams) ([]model.Voucher, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT " + voucherColumns + " FROM vouchers v WHERE v.deleted_at IS NULL")
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND (v.code ILIKE $%d OR v.name ILIKE $%d)", len(args)+1, len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	limit := 5
+	offset := 0
+	if req.Page != "" {
+		page, _ := strconv.Atoi(req.Page)
+		if page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	fmt.Fprintf(&sb, " ORDER BY v.id DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.Query(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetVoucher
+	}
+	defer rows.Close()
+
+	var vouchers []model.Voucher
+	for rows.Next() {
+		voucher, err := scanVoucher(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetVoucher
+		}
+		vouchers = append(vouchers, voucher)
+	}
+
+	return vouchers, rows.Err()
+}
+
+func (vr *VoucherRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.VoucherParams) (int, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT COUNT(v.id) FROM vouchers v WHERE v.deleted_at IS NULL")
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND (v.code ILIKE $%d OR v.name ILIKE $%d)", len(args)+1, len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	var totalVouchers int
+	if err := db.QueryRow(ctx, sb.String(), args...).Scan(&totalVouchers); err != nil {
+		return 0, err
+	}
+
+	itemsPerPage := 5
+	totalPage := int(math.Ceil(float64(totalVouchers) / float64(itemsPerPage)))
+
+	return totalPage, nil
+}
+
+func (vr *VoucherRepository) UpdateVoucher(ctx context.Context, db DBTX, req dto.UpdateVoucherRequest, id int) error {
+	var sb strings.Builder
+	sb.WriteString("UPDATE vouchers SET ")
+	args := []any{}
+
+	if req.Code != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "code = $%d", len(args)+1)
+		args = append(args, strings.ToUpper(req.Code))
+	}
+
+	if req.Name != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "name = $%d", len(args)+1)
+		args = append(args, req.Name)
+	}
+
+	if req.Description != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "description = $%d", len(args)+1)
+		args = append(args, *req.Description)
+	}
+
+	if req.Discount != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "discount = $%d", len(args)+1)
+		args = append(args, *req.Discount)
+	}
+
+	if req.MinOrder != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "min_order = $%d", len(args)+1)
+		args = append(args, *req.MinOrder)
+	}
+
+	if req.StartDate != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "start_date = NULLIF($%d, '')::date", len(args)+1)
+		args = append(args, *req.StartDate)
+	}
+
+	if req.EndDate != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "end_date = NULLIF($%d, '')::date", len(args)+1)
+		args = append(args, *req.EndDate)
+	}
+
+	if req.UsageLimit != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "usage_limit = $%d", len(args)+1)
+		args = append(args, *req.UsageLimit)
+	}
+
+	if len(args) == 0 {
+		return apperror.ErrNoFieldsToUpdate
+	}
+
+	fmt.Fprintf(&sb, ", updated_at = NOW() WHERE id = $%d AND deleted_at IS NULL", len(args)+1)
+	args = append(args, id)
+
+	ct, err := db.Exec(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return apperror.ErrVoucherExists
+		}
+		return apperror.ErrUpdateVoucher
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrVoucherNotFound
+	}
+
+	return nil
+}
+
+func (vr *VoucherRepository) DeleteVoucher(ctx context.Context, db DBTX, id int) error {
+	query := "UPDATE vouchers SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	ct, err := db.Exec(ctx, query, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteVoucher
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrVoucherNotFound
+	}
+
+	return nil
+}
+
+// GetVoucherByCode locks the voucher row, so it must be called inside the
+// transaction that later increments its usage.
+func (vr *VoucherRepository) GetVoucherByCode(ctx context.Context, db DBTX, code string) (model.Voucher, error) {
+	query := "SELECT " + voucherColumns + " FROM vouchers v WHERE v.code = $1 AND v.deleted_at IS NULL FOR UPDATE"
+
+	voucher, err := scanVoucher(db.QueryRow(ctx, query, strings.ToUpper(code)))
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Voucher{}, apperror.ErrVoucherNotFound
+		}
+		return model.Voucher{}, apperror.ErrGetVoucher
+	}
+
+	return voucher, nil
+}
+
+func (vr *VoucherRepository) IncrementUsage(ctx context.Context, db DBTX, id int) error {
+	query := `
+		UPDATE vouchers
+		SET usage_count = usage_count + 1
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		  AND (usage_limit = 0 OR usage_count < usage_limit)
+	`
+
+	ct, err := db.Exec(ctx, query, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateVoucher
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrVoucherUsageLimit
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type ZoneRepo interface {
+	CreateDeliveryZone(ctx context.Context, db DBTX, req dto.DeliveryZoneRequest) (int, error)
+	GetDeliveryZone(ctx context.Context, db DBTX, id int) (model.DeliveryZone, error)
+	GetDeliveryZones(ctx context.Context, db DBTX, req dto.DeliveryZoneParams) ([]model.DeliveryZone, error)
+	GetTotalPage(ctx context.Context, db DBTX, req dto.DeliveryZoneParams) (int, error)
+	GetActiveDeliveryZones(ctx context.Context, db DBTX) ([]model.DeliveryZone, error)
+	UpdateDeliveryZone(ctx context.Context, db DBTX, req dto.UpdateDeliveryZoneRequest, id int) error
+	DeleteDeliveryZone(ctx context.Context, db DBTX, id int) error
+}
+
+type ZoneRepository struct{}
+
+func NewZoneRepository() *ZoneRepository {
+	return &ZoneRepository{}
+}
+
+func scanDeliveryZones(rows pgx.Rows) ([]model.DeliveryZone, error) {
+	defer rows.Close()
+
+	var zones []model.DeliveryZone
+	for rows.Next() {
+		var zone model.DeliveryZone
+		if err := rows.Scan(&zone.ID, &zone.Name, &zone.Fee, &zone.IsActive); err != nil {
+			log.Println(err.Error())
+			return nil, apperror.ErrGetDeliveryZone
+		}
+		zones = append(zones, zone)
+	}
+
+	return zones, rows.Err()
+}
+
+func (zr *ZoneRepository) CreateDeliveryZone(ctx context.Context, db DBTX, req dto.DeliveryZoneRequest) (int, error) {
+	query := "INSERT INTO delivery_zones (name, fee, is_active) VALUES ($1, $2, COALESCE($3, true)) RETURNING id"
+
+	var id int
+	if err := db.QueryRow(ctx, query, req.Name, *req.Fee, req.IsActive).Scan(&id); err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return 0, apperror.ErrDeliveryZoneExists
+		}
+		return 0, apperror.ErrCreateDeliveryZone
+	}
+
+	return id, nil
+}
+
+func (zr *ZoneRepository) GetDeliveryZone(ctx context.Context, db DBTX, id int) (model.DeliveryZone, error) {
+	query := "SELECT id, name, fee, is_active FROM delivery_zones WHERE id = $1 AND deleted_at IS NULL"
+
+	var zone model.DeliveryZone
+	if err := db.QueryRow(ctx, query, id).Scan(&zone.ID, &zone.Name, &zone.Fee, &zone.IsActive); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.DeliveryZone{}, apperror.ErrDeliveryZoneNotFound
+		}
+		return model.DeliveryZone{}, apperror.ErrGetDeliveryZone
+	}
+
+	return zone, nil
+}
+
+func (zr *ZoneRepository) GetDeliveryZones(ctx context.Context, db DBTX, req dto.DeliveryZoneParams) ([]model.DeliveryZone, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT id, name, fee, is_active FROM delivery_zones WHERE deleted_at IS NULL")
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	limit := 5
+	offset := 0
+	if req.Page != "" {
+		page, _ := strconv.Atoi(req.Page)
+		if page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	fmt.Fprintf(&sb, " ORDER BY name LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := db.Query(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetDeliveryZone
+	}
+
+	return scanDeliveryZones(rows)
+}
+
+func (zr *ZoneRepository) GetTotalPage(ctx context.Context, db DBTX, req dto.DeliveryZoneParams) (int, error) {
+	var sb strings.Builder
+	args := []any{}
+
+	sb.WriteString("SELECT COUNT(id) FROM delivery_zones WHERE deleted_at IS NULL")
+
+	if req.Search != "" {
+		fmt.Fprintf(&sb, " AND name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	var totalZones int
+	if err := db.QueryRow(ctx, sb.String(), args...).Scan(&totalZones); err != nil {
+		return 0, err
+	}
+
+	itemsPerPage := 5
+	totalPage := int(math.Ceil(float64(totalZones) / float64(itemsPerPage)))
+
+	return totalPage, nil
+}
+
+// GetActiveDeliveryZones lists the zones customers can pick for an address.
+func (zr *ZoneRepository) GetActiveDeliveryZones(ctx context.Context, db DBTX) ([]model.DeliveryZone, error) {
+	query := "SELECT id, name, fee, is_active FROM delivery_zones WHERE deleted_at IS NULL AND is_active ORDER BY name"
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, apperror.ErrGetDeliveryZone
+	}
+
+	return scanDeliveryZones(rows)
+}
+
+func (zr *ZoneRepository) UpdateDeliveryZone(ctx context.Context, db DBTX, req dto.UpdateDeliveryZoneRequest, id int) error {
+	var sb strings.Builder
+	sb.WriteString("UPDATE delivery_zones SET ")
+	args := []any{}
+
+	if req.Name != "" {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "name = $%d", len(args)+1)
+		args = append(args, req.Name)
+	}
+
+	if req.Fee != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "fee = $%d", len(args)+1)
+		args = append(args, *req.Fee)
+	}
+
+	if req.IsActive != nil {
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "is_active = $%d", len(args)+1)
+		args = append(args, *req.IsActive)
+	}
+
+	if len(args) == 0 {
+		return apperror.ErrNoFieldsToUpdate
+	}
+
+	fmt.Fprintf(&sb, ", updated_at = NOW() WHERE id = $%d AND deleted_at IS NULL", len(args)+1)
+	args = append(args, id)
+
+	ct, err := db.Exec(ctx, sb.String(), args...)
+	if err != nil {
+		log.Println(err.Error())
+		if strings.Contains(err.Error(), "duplicate") {
+			return apperror.ErrDeliveryZoneExists
+		}
+		return apperror.ErrUpdateDeliveryZone
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrDeliveryZoneNotFound
+	}
+
+	return nil
+}
+
+// DeleteDeliveryZone soft deletes the zone. Addresses keep pointing at it
+// but are no longer deliverable until they are moved to another zone.
+func (zr *ZoneRepository) DeleteDeliveryZone(ctx context.Context, db DBTX, id int) error {
+	query := "UPDATE delivery_zones SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	ct, err := db.Exec(ctx, query, id)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrDeleteDeliveryZone
+	}
+
+	if ct.RowsAffected() == 0 {
+		return apperror.ErrDeliveryZoneNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func AddressRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	addressRouter := app.Group("/user/addresses")
+	addressRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user"))
+
+	addressRepository := repository.NewAddressRepository()
+	zoneRepository := repository.NewZoneRepository()
+	addressService := service.NewAddressService(addressRepository, zoneRepository, rdb, db)
+	addressController := controller.NewAddressController(addressService)
+
+	addressRouter.GET("/", addressController.GetUserAddresses)
+	addressRouter.POST("/", addressController.CreateUserAddress)
+	addressRouter.PATCH("/:id", addressController.UpdateUserAddress)
+	addressRouter.DELETE("/:id", addressController.DeleteUserAddress)
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/mail"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
@@ -14,12 +15,18 @@ func AuthRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	authRouter := app.Group("/auth")
 
 	authRepository := repository.NewAuthRepository()
-	authService := service.NewAuthService(authRepository, rdb, db)
+	authService := service.NewAuthService(authRepository, mail.Default(), rdb, db)
 	authController := controller.NewAuthController(authService)
 
 	authRouter.POST("/", authController.Login)
 	authRouter.POST("/new", authController.Register)
-	authRouter.DELETE("/", middleware.AuthMiddleware(), middleware.RBACMiddleware("user", "admin"), authController.Logout)
+	authRouter.DELETE("/", middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user", "admin"), authController.Logout)
+	authRouter.POST("/refresh", authController.Refresh)
+	authRouter.GET("/sessions", middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user", "admin"), authController.GetSessions)
+	authRouter.DELETE("/sessions", middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user", "admin"), authController.RevokeSessions)
+	authRouter.DELETE("/sessions/:id", middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user", "admin"), authController.RevokeSession)
 	authRouter.POST("/forgot-password", authController.ForgotPassword)
 	authRouter.POST("/forgot-password/update", authController.UpdateForgotPassword)
+
+	app.GET("/.well-known/jwks.json", authController.GetJWKS)
 }
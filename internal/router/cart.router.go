@@ -0,0 +1,34 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/mail"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func CartRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	cartRouter := app.Group("/cart")
+	cartRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user"))
+
+	cartRepository := repository.NewCartRepository()
+	orderRepository := repository.NewOrderRepository()
+	voucherRepository := repository.NewVoucherRepository()
+	taxRepository := repository.NewTaxRepository()
+	addressRepository := repository.NewAddressRepository()
+	scheduleRepository := repository.NewScheduleRepository()
+	orderService := service.NewOrderService(orderRepository, voucherRepository, taxRepository, addressRepository, scheduleRepository, mail.Default(), db, rdb)
+	cartService := service.NewCartService(cartRepository, orderService, rdb, db)
+	cartController := controller.NewCartController(cartService)
+
+	cartRouter.GET("/", cartController.GetCart)
+	cartRouter.POST("/", cartController.AddItem)
+	cartRouter.POST("/checkout", cartController.Checkout)
+	cartRouter.PATCH("/:id", cartController.UpdateItem)
+	cartRouter.DELETE("/:id", cartController.RemoveItem)
+	cartRouter.DELETE("/", cartController.ClearCart)
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func CategoryRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	categoryRepository := repository.NewCategoryRepository()
+	categoryService := service.NewCategoryService(categoryRepository, rdb, db)
+	categoryController := controller.NewCategoryController(categoryService)
+
+	app.GET("/categories", categoryController.GetAllCategories)
+
+	categoryRouter := app.Group("/admin/categories")
+	categoryRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("admin"))
+
+	categoryRouter.GET("/", categoryController.GetCategories)
+	categoryRouter.GET("/:id", categoryController.GetCategory)
+	categoryRouter.POST("/", categoryController.CreateCategory)
+	categoryRouter.PATCH("/:id", categoryController.UpdateCategory)
+	categoryRouter.DELETE("/:id", categoryController.DeleteCategory)
+}
@@ -18,6 +18,16 @@ func Init(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	ProductRouter(app, db, rdb)
 	OrderRouter(app, db, rdb)
 	MenuRouter(app, db, rdb)
+	VoucherRouter(app, db, rdb)
+	CartRouter(app, db, rdb)
+	PaymentRouter(app, db, rdb)
+	CategoryRouter(app, db, rdb)
+	TaxRouter(app, db, rdb)
+	ZoneRouter(app, db, rdb)
+	AddressRouter(app, db, rdb)
+	ScheduleRouter(app, db, rdb)
+	OutletRouter(app, db, rdb)
+	ReviewRouter(app, db, rdb)
 
 	app.Static("/static/img", "public")
 
@@ -12,10 +12,11 @@ import (
 
 func MenuRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	menuRouter := app.Group("/admin/menu")
-	menuRouter.Use(middleware.AuthMiddleware(), middleware.RBACMiddleware("admin"))
+	menuRouter.Use(middleware.AuthMiddleware(rdb), middleware.OutletRBACMiddleware(db, "admin"))
 
 	menuRepository := repository.NewMenuRepository()
-	menuService := service.NewMenuService(menuRepository, rdb, db)
+	outletRepository := repository.NewOutletRepository()
+	menuService := service.NewMenuService(menuRepository, outletRepository, rdb, db)
 	menuController := controller.NewMenuController(menuService)
 
 	menuRouter.GET("/", menuController.GetMenus)
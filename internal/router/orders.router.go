@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/mail"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
@@ -12,18 +13,22 @@ import (
 
 func OrderRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	adminOrdersRouter := app.Group("/admin")
-	adminOrdersRouter.Use(middleware.AuthMiddleware())
+	adminOrdersRouter.Use(middleware.AuthMiddleware(rdb))
 
 	ordersRouter := app.Group("/orders")
 	ordersRepository := repository.NewOrderRepository()
-	ordersService := service.NewOrderService(ordersRepository, db, rdb)
+	voucherRepository := repository.NewVoucherRepository()
+	taxRepository := repository.NewTaxRepository()
+	addressRepository := repository.NewAddressRepository()
+	scheduleRepository := repository.NewScheduleRepository()
+	ordersService := service.NewOrderService(ordersRepository, voucherRepository, taxRepository, addressRepository, scheduleRepository, mail.Default(), db, rdb)
 	ordersController := controller.NewOrdersController(ordersService)
-	ordersRouter.Use(middleware.AuthMiddleware())
+	ordersRouter.Use(middleware.AuthMiddleware(rdb))
 
 	ordersRouter.GET("/history", middleware.RBACMiddleware("user"), ordersController.GetHistoryByUser)
 	ordersRouter.POST("/", middleware.RBACMiddleware("user"), ordersController.CreateOrder)
-	ordersRouter.POST("/review", middleware.RBACMiddleware("user"), ordersController.AddReview)
-	ordersRouter.GET("/history/:id", middleware.RBACMiddleware("user", "admin"), ordersController.GetDetailHistoryById)
-	adminOrdersRouter.PATCH("/orders/", middleware.RBACMiddleware("admin"), ordersController.UpdateStatusOrder)
-	adminOrdersRouter.GET("/orders/", middleware.RBACMiddleware("admin"), ordersController.GetAllOrderByAdmin)
+	ordersRouter.POST("/quote", middleware.RBACMiddleware("user"), ordersController.QuoteOrder)
+	ordersRouter.GET("/history/:id", middleware.OutletRBACMiddleware(db, "user", "admin"), ordersController.GetDetailHistoryById)
+	adminOrdersRouter.PATCH("/orders/", middleware.OutletRBACMiddleware(db, "admin"), ordersController.UpdateStatusOrder)
+	adminOrdersRouter.GET("/orders/", middleware.OutletRBACMiddleware(db, "admin"), ordersController.GetAllOrderByAdmin)
 }
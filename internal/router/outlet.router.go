@@ -0,0 +1,35 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func OutletRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	outletRouter := app.Group("/outlets")
+	adminOutletRouter := app.Group("/admin/outlets")
+	adminOutletRouter.Use(middleware.AuthMiddleware(rdb), middleware.OutletRBACMiddleware(db, "admin"))
+
+	outletRepository := repository.NewOutletRepository()
+	scheduleRepository := repository.NewScheduleRepository()
+	outletService := service.NewOutletService(outletRepository, scheduleRepository, rdb, db)
+	outletController := controller.NewOutletController(outletService)
+
+	outletRouter.GET("/", outletController.GetActiveOutlets)
+
+	adminOutletRouter.GET("/", outletController.GetOutlets)
+	adminOutletRouter.GET("/:outlet_id", outletController.GetOutlet)
+	adminOutletRouter.POST("/", outletController.CreateOutlet)
+	adminOutletRouter.PATCH("/:outlet_id", outletController.UpdateOutlet)
+	adminOutletRouter.DELETE("/:outlet_id", outletController.DeleteOutlet)
+	adminOutletRouter.GET("/:outlet_id/admins", outletController.GetOutletAdmins)
+	adminOutletRouter.PUT("/:outlet_id/admins", outletController.SetOutletAdmins)
+	adminOutletRouter.GET("/global-admins", outletController.GetGlobalAdmins)
+	adminOutletRouter.PUT("/global-admins/:user_id", outletController.GrantGlobalAdmin)
+	adminOutletRouter.DELETE("/global-admins/:user_id", outletController.RevokeGlobalAdmin)
+}
@@ -0,0 +1,47 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/mail"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/payment"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func PaymentRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	paymentRouter := app.Group("/admin/payments")
+	paymentRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("admin"))
+
+	orderPaymentRouter := app.Group("/orders")
+	orderPaymentRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user"))
+
+	adminOrderPaymentRouter := app.Group("/admin/orders")
+	adminOrderPaymentRouter.Use(middleware.AuthMiddleware(rdb), middleware.OutletRBACMiddleware(db, "admin"))
+
+	paymentRepository := repository.NewPaymentRepository()
+	orderRepository := repository.NewOrderRepository()
+	voucherRepository := repository.NewVoucherRepository()
+	taxRepository := repository.NewTaxRepository()
+	addressRepository := repository.NewAddressRepository()
+	scheduleRepository := repository.NewScheduleRepository()
+	orderService := service.NewOrderService(orderRepository, voucherRepository, taxRepository, addressRepository, scheduleRepository, mail.Default(), db, rdb)
+	paymentService := service.NewPaymentService(paymentRepository, orderService, payment.Default(), rdb, db)
+	paymentController := controller.NewPaymentController(paymentService)
+
+	paymentRouter.GET("/", paymentController.GetPayments)
+	paymentRouter.GET("/:id", paymentController.GetPayment)
+	paymentRouter.POST("/", paymentController.CreatePayment)
+	paymentRouter.PATCH("/:id", paymentController.UpdatePayment)
+	paymentRouter.DELETE("/:id", paymentController.DeletePayment)
+
+	orderPaymentRouter.POST("/:id/pay", paymentController.PayOrder)
+
+	adminOrderPaymentRouter.POST("/:id/refund", paymentController.RefundOrder)
+	adminOrderPaymentRouter.POST("/:id/reconcile", paymentController.ReconcileOrder)
+
+	app.POST("/webhooks/payments/:provider", paymentController.HandleWebhook)
+}
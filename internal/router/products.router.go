@@ -19,15 +19,23 @@ func ProductRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	productController := controller.NewProductsController(productService)
 
 	productsRouter.GET("", productController.GetAllProducts)
+	productsRouter.GET("/suggest", productController.SuggestProducts)
 	productsRouter.GET("/:id", productController.GetDetailProductByUserWithId)
 	productsRouter.GET("/product-sizes", productController.GetAllProductSize)
 	productsRouter.GET("/product-types", productController.GetAllProductType)
 
-	adminProductsRouter.Use(middleware.AuthMiddleware())
+	adminProductsRouter.Use(middleware.AuthMiddleware(rdb))
 	adminProductsRouter.GET("/products/:id", middleware.RBACMiddleware("admin"), productController.GetDetailProductById)
 	adminProductsRouter.POST("/products", middleware.RBACMiddleware("admin"), productController.PostProducts)
 	adminProductsRouter.PATCH("/products/:id", middleware.RBACMiddleware("admin"), productController.UpdateProduct)
 	adminProductsRouter.DELETE("/products/:id", middleware.RBACMiddleware("admin"), productController.DeleteProductById)
 	adminProductsRouter.DELETE("/products/image/:id", middleware.RBACMiddleware("admin"), productController.DeleteProductImageById)
+	adminProductsRouter.PUT("/products/:id/options", middleware.RBACMiddleware("admin"), productController.SetProductOptions)
+	adminProductsRouter.POST("/product-sizes", middleware.RBACMiddleware("admin"), productController.CreateProductSize)
+	adminProductsRouter.PATCH("/product-sizes/:id", middleware.RBACMiddleware("admin"), productController.UpdateProductSize)
+	adminProductsRouter.DELETE("/product-sizes/:id", middleware.RBACMiddleware("admin"), productController.DeleteProductSize)
+	adminProductsRouter.POST("/product-types", middleware.RBACMiddleware("admin"), productController.CreateProductType)
+	adminProductsRouter.PATCH("/product-types/:id", middleware.RBACMiddleware("admin"), productController.UpdateProductType)
+	adminProductsRouter.DELETE("/product-types/:id", middleware.RBACMiddleware("admin"), productController.DeleteProductType)
 
 }
@@ -0,0 +1,32 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func ReviewRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	reviewRouter := app.Group("/reviews")
+	reviewRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user"))
+	adminReviewRouter := app.Group("/admin/reviews")
+	adminReviewRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("admin"))
+
+	reviewRepository := repository.NewReviewRepository()
+	productRepository := repository.NewProductRepository()
+	reviewService := service.NewReviewService(reviewRepository, productRepository, rdb, db)
+	reviewController := controller.NewReviewController(reviewService)
+
+	app.GET("/products/:id/reviews", reviewController.GetProductReviews)
+	app.POST("/orders/review", middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user"), reviewController.AddReview)
+
+	reviewRouter.PATCH("/:id", reviewController.UpdateReview)
+	reviewRouter.DELETE("/:id", reviewController.DeleteReview)
+
+	adminReviewRouter.GET("/", reviewController.GetReviews)
+	adminReviewRouter.PATCH("/:id/visibility", reviewController.SetReviewHidden)
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func ScheduleRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	slotRouter := app.Group("/orders")
+	slotRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user"))
+
+	adminScheduleRouter := app.Group("/admin/outlets/:outlet_id/opening-hours")
+	adminScheduleRouter.Use(middleware.AuthMiddleware(rdb), middleware.OutletRBACMiddleware(db, "admin"))
+
+	scheduleRepository := repository.NewScheduleRepository()
+	outletRepository := repository.NewOutletRepository()
+	scheduleService := service.NewScheduleService(scheduleRepository, outletRepository, rdb, db)
+	scheduleController := controller.NewScheduleController(scheduleService)
+
+	slotRouter.GET("/slots", scheduleController.GetSlots)
+
+	adminScheduleRouter.GET("/", scheduleController.GetOpeningHours)
+	adminScheduleRouter.PUT("/:weekday", scheduleController.UpdateOpeningHours)
+}
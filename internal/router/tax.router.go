@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func TaxRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	taxRouter := app.Group("/admin/tax-rules")
+	taxRouter.Use(middleware.AuthMiddleware(rdb), middleware.OutletRBACMiddleware(db, "admin"))
+
+	taxRepository := repository.NewTaxRepository()
+	taxService := service.NewTaxService(taxRepository, rdb, db)
+	taxController := controller.NewTaxController(taxService)
+
+	taxRouter.GET("/", taxController.GetTaxRules)
+	taxRouter.GET("/:id", taxController.GetTaxRule)
+	taxRouter.POST("/", taxController.CreateTaxRule)
+	taxRouter.PATCH("/:id", taxController.UpdateTaxRule)
+	taxRouter.DELETE("/:id", taxController.DeleteTaxRule)
+}
@@ -13,8 +13,8 @@ import (
 func UserRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	adminUserRouter := app.Group("/admin/user")
 	userRouter := app.Group("/user")
-	adminUserRouter.Use(middleware.AuthMiddleware(), middleware.RBACMiddleware("admin"))
-	userRouter.Use(middleware.AuthMiddleware(), middleware.RBACMiddleware("user", "admin"))
+	adminUserRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("admin"))
+	userRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("user", "admin"))
 
 	userRepository := repository.NewUserRepository()
 	userService := service.NewUserService(userRepository, rdb, db)
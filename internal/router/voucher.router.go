@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func VoucherRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	voucherRouter := app.Group("/admin/vouchers")
+	voucherRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("admin"))
+
+	voucherRepository := repository.NewVoucherRepository()
+	voucherService := service.NewVoucherService(voucherRepository, rdb, db)
+	voucherController := controller.NewVoucherController(voucherService)
+
+	voucherRouter.GET("/", voucherController.GetVouchers)
+	voucherRouter.GET("/:id", voucherController.GetVoucher)
+	voucherRouter.POST("/", voucherController.CreateVoucher)
+	voucherRouter.PATCH("/:id", voucherController.UpdateVoucher)
+	voucherRouter.DELETE("/:id", voucherController.DeleteVoucher)
+}
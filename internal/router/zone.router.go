@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/controller"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/middleware"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func ZoneRouter(app *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+	zoneRouter := app.Group("/delivery-zones")
+	adminZoneRouter := app.Group("/admin/delivery-zones")
+	adminZoneRouter.Use(middleware.AuthMiddleware(rdb), middleware.RBACMiddleware("admin"))
+
+	zoneRepository := repository.NewZoneRepository()
+	zoneService := service.NewZoneService(zoneRepository, rdb, db)
+	zoneController := controller.NewZoneController(zoneService)
+
+	zoneRouter.GET("/", zoneController.GetActiveDeliveryZones)
+
+	adminZoneRouter.GET("/", zoneController.GetDeliveryZones)
+	adminZoneRouter.GET("/:id", zoneController.GetDeliveryZone)
+	adminZoneRouter.POST("/", zoneController.CreateDeliveryZone)
+	adminZoneRouter.PATCH("/:id", zoneController.UpdateDeliveryZone)
+	adminZoneRouter.DELETE("/:id", zoneController.DeleteDeliveryZone)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type AddressService struct {
+	addressRepository *repository.AddressRepository
+	zoneRepository    *repository.ZoneRepository
+	redis             *redis.Client
+	db                *pgxpool.Pool
+}
+
+func NewAddressService(addressRepository *repository.AddressRepository, zoneRepository *repository.ZoneRepository, rdb *redis.Client, db *pgxpool.Pool) *AddressService {
+	return &AddressService{addressRepository: addressRepository, zoneRepository: zoneRepository, redis: rdb, db: db}
+}
+
+// checkZone makes sure new addresses only point at zones we deliver to.
+func (as *AddressService) checkZone(ctx context.Context, zoneID int) error {
+	zone, err := as.zoneRepository.GetDeliveryZone(ctx, as.db, zoneID)
+	if err != nil {
+		return err
+	}
+
+	if !zone.IsActive {
+		return apperror.ErrDeliveryZoneInactive
+	}
+
+	return nil
+}
+
+func (as *AddressService) GetUserAddresses(ctx context.Context, userID int) ([]dto.UserAddress, error) {
+	data, err := as.addressRepository.GetUserAddresses(ctx, as.db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.UserAddress{}
+	for _, v := range data {
+		response = append(response, toUserAddressDTO(v))
+	}
+
+	return response, nil
+}
+
+func (as *AddressService) CreateUserAddress(ctx context.Context, req dto.UserAddressRequest, userID int) (dto.UserAddress, error) {
+	if err := as.checkZone(ctx, req.ZoneId); err != nil {
+		return dto.UserAddress{}, err
+	}
+
+	tx, err := as.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.UserAddress{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if req.IsDefault {
+		if err := as.addressRepository.ClearDefaultAddress(ctx, tx, userID); err != nil {
+			return dto.UserAddress{}, err
+		}
+	}
+
+	id, err := as.addressRepository.CreateUserAddress(ctx, tx, req, userID)
+	if err != nil {
+		return dto.UserAddress{}, err
+	}
+
+	// The first address in the book becomes the default one.
+	if err := as.addressRepository.EnsureDefaultAddress(ctx, tx, userID); err != nil {
+		return dto.UserAddress{}, err
+	}
+
+	data, err := as.addressRepository.GetUserAddress(ctx, tx, userID, id)
+	if err != nil {
+		return dto.UserAddress{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return dto.UserAddress{}, err
+	}
+
+	return toUserAddressDTO(data), nil
+}
+
+func (as *AddressService) UpdateUserAddress(ctx context.Context, req dto.UpdateUserAddressRequest, userID, addressID int) error {
+	if req.ZoneId != 0 {
+		if err := as.checkZone(ctx, req.ZoneId); err != nil {
+			return err
+		}
+	}
+
+	tx, err := as.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if req.IsDefault != nil && *req.IsDefault {
+		if _, err := as.addressRepository.GetUserAddress(ctx, tx, userID, addressID); err != nil {
+			return err
+		}
+
+		if err := as.addressRepository.ClearDefaultAddress(ctx, tx, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := as.addressRepository.UpdateUserAddress(ctx, tx, req, userID, addressID); err != nil {
+		return err
+	}
+
+	if err := as.addressRepository.EnsureDefaultAddress(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (as *AddressService) DeleteUserAddress(ctx context.Context, userID, addressID int) error {
+	tx, err := as.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := as.addressRepository.DeleteUserAddress(ctx, tx, userID, addressID); err != nil {
+		return err
+	}
+
+	if err := as.addressRepository.EnsureDefaultAddress(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func toUserAddressDTO(a model.UserAddress) dto.UserAddress {
+	return dto.UserAddress{
+		ID:          a.ID,
+		Label:       a.Label,
+		Recipient:   a.Recipient,
+		Phone:       a.Phone,
+		Address:     a.Address,
+		ZoneId:      a.ZoneId,
+		Zone:        a.Zone,
+		DeliveryFee: a.DeliveryFee,
+		Deliverable: a.Deliverable,
+		IsDefault:   a.IsDefault,
+	}
+}
@@ -3,16 +3,14 @@ package service
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"math/rand"
-	"os"
 	"regexp"
-	"time"
+	"strings"
 
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/cache"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/mail"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
 	hashutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/hash"
 	jwtutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/jwt"
@@ -22,12 +20,13 @@ import (
 
 type AuthService struct {
 	authRepository *repository.AuthRepository
+	mails          *mail.Queue
 	redis          *redis.Client
 	db             *pgxpool.Pool
 }
 
-func NewAuthService(authRepository *repository.AuthRepository, rdb *redis.Client, db *pgxpool.Pool) *AuthService {
-	return &AuthService{authRepository: authRepository, redis: rdb, db: db}
+func NewAuthService(authRepository *repository.AuthRepository, mails *mail.Queue, rdb *redis.Client, db *pgxpool.Pool) *AuthService {
+	return &AuthService{authRepository: authRepository, mails: mails, redis: rdb, db: db}
 }
 
 func (as *AuthService) Login(ctx context.Context, req dto.LoginRequest) (dto.User, error) {
@@ -82,13 +81,121 @@ func (as *AuthService) Login(ctx context.Context, req dto.LoginRequest) (dto.Use
 	return res, nil
 }
 
-func (as *AuthService) GenerateJWT(ctx context.Context, user dto.User) (string, error) {
-	claims := jwtutil.NewJWTClaims(user.ID, user.Role)
-	return claims.GenToken()
+// StartSession opens a session for the device the user logged in from and
+// returns its first access and refresh token.
+func (as *AuthService) StartSession(ctx context.Context, user dto.User, device, ip string) (dto.JWT, error) {
+	sessionID, err := cache.NewSessionID()
+	if err != nil {
+		log.Println(err.Error())
+		return dto.JWT{}, apperror.ErrCreateSession
+	}
+
+	refreshToken, err := cache.NewRefreshToken(sessionID)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.JWT{}, apperror.ErrCreateSession
+	}
+
+	accessToken, err := jwtutil.NewJWTClaims(user.ID, user.Role, sessionID).GenToken()
+	if err != nil {
+		return dto.JWT{}, err
+	}
+
+	session := cache.Session{ID: sessionID, UserID: user.ID, Device: device, IP: ip}
+	if err := cache.CreateSession(ctx, as.redis, session, accessToken, refreshToken); err != nil {
+		return dto.JWT{}, err
+	}
+
+	return dto.JWT{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(jwtutil.AccessTokenTTL.Seconds()),
+	}, nil
 }
 
-func (as *AuthService) WhitelistToken(ctx context.Context, id int, token string) {
-	cache.SetToken(ctx, as.redis, id, token)
+// Refresh trades a refresh token for a new access and refresh token of the
+// same session. Every refresh token works once, using one again revokes
+// the session.
+func (as *AuthService) Refresh(ctx context.Context, refreshToken string) (dto.JWT, error) {
+	sessionID, err := cache.RefreshTokenSession(refreshToken)
+	if err != nil {
+		return dto.JWT{}, err
+	}
+
+	session, err := cache.GetSession(ctx, as.redis, sessionID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrSessionNotFound) {
+			return dto.JWT{}, apperror.ErrRefreshTokenInvalid
+		}
+		return dto.JWT{}, err
+	}
+
+	role, err := as.authRepository.GetUserRole(ctx, as.db, session.UserID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrUserNotFound) {
+			if err := cache.RevokeSessions(ctx, as.redis, session.UserID); err != nil {
+				log.Println("failed to revoke sessions:", err.Error())
+			}
+			return dto.JWT{}, apperror.ErrRefreshTokenInvalid
+		}
+		return dto.JWT{}, err
+	}
+
+	newRefresh, err := cache.NewRefreshToken(sessionID)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.JWT{}, apperror.ErrCreateSession
+	}
+
+	accessToken, err := jwtutil.NewJWTClaims(session.UserID, role, sessionID).GenToken()
+	if err != nil {
+		return dto.JWT{}, err
+	}
+
+	if _, err := cache.RotateSession(ctx, as.redis, refreshToken, newRefresh, accessToken); err != nil {
+		return dto.JWT{}, err
+	}
+
+	return dto.JWT{
+		Token:        accessToken,
+		RefreshToken: newRefresh,
+		ExpiresIn:    int(jwtutil.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// GetSessions lists the devices the user is logged in on. current is the
+// session of the access token used for the request.
+func (as *AuthService) GetSessions(ctx context.Context, userID int, current string) ([]dto.Session, error) {
+	data, err := cache.GetSessions(ctx, as.redis, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.Session{}
+	for _, v := range data {
+		response = append(response, dto.Session{
+			ID:         v.ID,
+			Device:     v.Device,
+			IP:         v.IP,
+			CreatedAt:  v.CreatedAt,
+			LastUsedAt: v.LastUsedAt,
+			ExpiresAt:  v.ExpiresAt,
+			Current:    v.ID == current,
+		})
+	}
+
+	return response, nil
+}
+
+// RevokeSession logs the user out of one of their sessions.
+func (as *AuthService) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	return cache.RevokeSession(ctx, as.redis, userID, sessionID)
+}
+
+// RevokeSessions logs the user out of every session, including the one
+// making the request.
+func (as *AuthService) RevokeSessions(ctx context.Context, userID int) error {
+	return cache.RevokeSessions(ctx, as.redis, userID)
 }
 
 func (as *AuthService) Register(ctx context.Context, req dto.RegisterRequest) error {
@@ -111,44 +218,66 @@ func (as *AuthService) Register(ctx context.Context, req dto.RegisterRequest) er
 		return err
 	}
 
+	msg, err := mail.Welcome(req.Email, req.Fullname)
+	if err != nil {
+		log.Println("failed to render welcome mail:", err.Error())
+		return nil
+	}
+	as.mails.Enqueue(msg)
+
 	return nil
 }
 
-func (as *AuthService) Logout(ctx context.Context, userID int) error {
-	return cache.DeleteToken(ctx, as.redis, userID)
+// Logout ends the session of the access token.
+func (as *AuthService) Logout(ctx context.Context, userID int, sessionID string) error {
+	err := cache.RevokeSession(ctx, as.redis, userID, sessionID)
+	if errors.Is(err, apperror.ErrSessionNotFound) {
+		return apperror.ErrLogoutFailed
+	}
+	return err
+}
+
+// normalizeEmail is the form of an email the reset codes are keyed by and
+// accounts are looked up with, so the case a user types does not matter.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
 
+// ForgotPassword mails a reset code to the email. The cooldown starts before
+// the email is looked up, so unknown emails are answered the same way.
 func (as *AuthService) ForgotPassword(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+
+	if err := cache.ReserveOTP(ctx, as.redis, email); err != nil {
+		return err
+	}
+
 	err := as.authRepository.CheckEmailExists(ctx, as.db, email)
 	if err != nil {
 		return err
 	}
 
-	otp := make([]byte, 6)
-	for i := range otp {
-		otp[i] = byte('0' + rand.Intn(10))
+	otp, err := cache.CreateOTP(ctx, as.redis, email)
+	if err != nil {
+		return err
 	}
 
-	rkey := fmt.Sprintf("%s:forgot-password:%s", os.Getenv("RDB_KEY"), string(otp))
-
-	status := as.redis.Set(ctx, rkey, email, time.Minute*5)
-	log.Println(string(otp))
-	log.Println(status.Args()...)
-	if status.Err() != nil {
-		log.Println("caching failed:", status.Err())
+	msg, err := mail.PasswordReset(email, otp, cache.OTPTTL)
+	if err != nil {
+		log.Println("failed to render password reset mail:", err.Error())
+		return err
 	}
+	as.mails.Enqueue(msg)
 
 	return nil
 }
 
+// UpdatePassword resets the password with the code mailed to the email and
+// logs the user out of every session.
 func (as *AuthService) UpdatePassword(ctx context.Context, req dto.UpdateForgotPasswordRequest) error {
-	rkey := fmt.Sprintf("%s:forgot-password:%s", os.Getenv("RDB_KEY"), req.Otp)
+	req.Email = normalizeEmail(req.Email)
 
-	email, err := as.redis.Get(ctx, rkey).Result()
-	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return apperror.ErrOTPNotFound
-		}
+	if err := cache.VerifyOTP(ctx, as.redis, req.Email, req.Otp); err != nil {
 		return err
 	}
 
@@ -158,11 +287,18 @@ func (as *AuthService) UpdatePassword(ctx context.Context, req dto.UpdateForgotP
 		return err
 	}
 
-	if err := as.authRepository.UpdatePassword(ctx, as.db, email, newHashedPassword); err != nil {
+	userID, err := as.authRepository.UpdatePassword(ctx, as.db, req.Email, newHashedPassword)
+	if err != nil {
+		if errors.Is(err, apperror.ErrUserNotFound) {
+			return apperror.ErrOTPNotFound
+		}
 		return err
 	}
 
-	as.redis.Del(ctx, rkey)
+	if err := cache.RevokeSessions(ctx, as.redis, userID); err != nil {
+		log.Println("failed to revoke sessions after password reset:", err.Error())
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/cache"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/pricing"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type CartService struct {
+	cartRepository *repository.CartRepository
+	orderService   *OrderService
+	redis          *redis.Client
+	db             *pgxpool.Pool
+}
+
+func NewCartService(cartRepository *repository.CartRepository, orderService *OrderService, rdb *redis.Client, db *pgxpool.Pool) *CartService {
+	return &CartService{
+		cartRepository: cartRepository,
+		orderService:   orderService,
+		redis:          rdb,
+		db:             db,
+	}
+}
+
+// GetCart serves the priced cart from Redis when possible. Postgres stays the
+// source of truth, every write below drops the cached copy. Stock is not
+// part of what is cached, every order and cancellation changes it, so it is
+// always read fresh.
+func (cs *CartService) GetCart(ctx context.Context, userID int) (dto.Cart, error) {
+	rkey := cache.CartKey(userID)
+
+	rsc := cs.redis.Get(ctx, rkey)
+	if rsc.Err() == nil {
+		var result dto.Cart
+		cache, err := rsc.Bytes()
+		if err != nil {
+			log.Println(err)
+		} else {
+			if err := json.Unmarshal(cache, &result); err != nil {
+				log.Println(err.Error())
+			} else {
+				stock, err := cs.cartRepository.GetCartStock(ctx, cs.db, userID)
+				if err != nil {
+					return dto.Cart{}, err
+				}
+				for i, item := range result.Items {
+					result.Items[i].Stock = stock[item.ID]
+				}
+				return result, nil
+			}
+		}
+	}
+
+	if rsc.Err() == redis.Nil {
+		log.Println("cart cache miss")
+	}
+
+	data, err := cs.cartRepository.GetCartItems(ctx, cs.db, userID)
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	// The shipping mode is only known at checkout, so the estimate uses the
+	// rules of the cart's outlet that apply to every shipping mode. Carts
+	// never mix outlets.
+	outletId := 0
+	if len(data) > 0 {
+		outletId = data[0].OutletID
+	}
+
+	rules, err := cs.orderService.taxRepository.GetEffectiveRules(ctx, cs.db, outletId, "", time.Now())
+	if err != nil {
+		return dto.Cart{}, err
+	}
+
+	response := toCartDTO(data, rules)
+
+	cacheStr, err := json.Marshal(response)
+	if err != nil {
+		log.Println(err)
+		log.Println("failed to marshal")
+	}
+
+	rdsStatus := cs.redis.Set(ctx, rkey, string(cacheStr), time.Minute*10)
+	if rdsStatus.Err() != nil {
+		log.Println("caching failed")
+		log.Println(rdsStatus.Err().Error())
+	}
+
+	return response, nil
+}
+
+func (cs *CartService) AddItem(ctx context.Context, req dto.AddCartItemRequest, userID int) error {
+	// Retired sizes and types stay in the tables for order history, so the
+	// foreign keys alone do not keep them out of the cart. The lookups also
+	// reject options the product does not offer.
+	if _, err := cs.orderService.orderRepository.GetProductSize(ctx, cs.db, req.MenuId, req.ProductSizeId); err != nil {
+		return err
+	}
+	if _, err := cs.orderService.orderRepository.GetProductType(ctx, cs.db, req.MenuId, req.ProductTypeId); err != nil {
+		return err
+	}
+
+	// An order is fulfilled by a single outlet, so the cart only holds menus
+	// of one outlet at a time.
+	menu, err := cs.orderService.orderRepository.GetPriceByMenuId(ctx, cs.db, req.MenuId)
+	if err != nil {
+		return err
+	}
+	cartOutletId, err := cs.cartRepository.GetCartOutletId(ctx, cs.db, userID)
+	if err != nil {
+		return err
+	}
+	if cartOutletId != 0 && cartOutletId != menu.OutletId {
+		return apperror.ErrMixedOutlets
+	}
+
+	if err := cs.cartRepository.AddCartItem(ctx, cs.db, req, userID); err != nil {
+		return err
+	}
+
+	cs.invalidateCart(ctx, userID)
+	return nil
+}
+
+func (cs *CartService) UpdateItem(ctx context.Context, req dto.UpdateCartItemRequest, userID, itemID int) error {
+	if err := cs.cartRepository.UpdateCartItem(ctx, cs.db, req.Qty, itemID, userID); err != nil {
+		return err
+	}
+
+	cs.invalidateCart(ctx, userID)
+	return nil
+}
+
+func (cs *CartService) RemoveItem(ctx context.Context, userID, itemID int) error {
+	if err := cs.cartRepository.DeleteCartItem(ctx, cs.db, itemID, userID); err != nil {
+		return err
+	}
+
+	cs.invalidateCart(ctx, userID)
+	return nil
+}
+
+func (cs *CartService) ClearCart(ctx context.Context, userID int) error {
+	if err := cs.cartRepository.ClearCart(ctx, cs.db, userID); err != nil {
+		return err
+	}
+
+	cs.invalidateCart(ctx, userID)
+	return nil
+}
+
+// Checkout places an order from the stored cart and empties it in the same
+// transaction, so a failed order leaves the cart untouched.
+func (cs *CartService) Checkout(ctx context.Context, req dto.CheckoutCartRequest, userID int) (dto.CreateOrderResponse, error) {
+	tx, err := cs.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.CreateOrderResponse{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	items, err := cs.cartRepository.GetCartItems(ctx, tx, userID)
+	if err != nil {
+		return dto.CreateOrderResponse{}, err
+	}
+
+	if len(items) == 0 {
+		return dto.CreateOrderResponse{}, apperror.ErrCartEmpty
+	}
+
+	order := dto.CreateOrder{
+		Shipping:     req.Shipping,
+		AddressId:    req.AddressId,
+		Payment_Id:   req.Payment_Id,
+		VoucherCode:  req.VoucherCode,
+		ScheduledFor: req.ScheduledFor,
+	}
+	for _, v := range items {
+		order.Menus = append(order.Menus, dto.CreateMenuOrder{
+			MenuId:        v.MenuID,
+			Qty:           v.Qty,
+			ProductSizeId: v.ProductSizeID,
+			ProductTypeId: v.ProductTypeID,
+		})
+	}
+
+	response, err := cs.orderService.createOrder(ctx, tx, order, userID)
+	if err != nil {
+		return dto.CreateOrderResponse{}, err
+	}
+
+	if err := cs.cartRepository.ClearCart(ctx, tx, userID); err != nil {
+		return dto.CreateOrderResponse{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return dto.CreateOrderResponse{}, err
+	}
+
+	cs.invalidateCart(ctx, userID)
+	return response, nil
+}
+
+func (cs *CartService) invalidateCart(ctx context.Context, userID int) {
+	if err := cs.redis.Del(ctx, cache.CartKey(userID)).Err(); err != nil {
+		log.Println("failed to invalidate cart cache")
+		log.Println(err.Error())
+	}
+}
+
+func toCartDTO(items []model.CartItem, rules []model.TaxRule) dto.Cart {
+	lines := []pricing.Item{}
+	for _, v := range items {
+		lines = append(lines, pricing.Item{
+			Price:     v.Price,
+			Discount:  v.Discount,
+			SizePrice: v.SizePrice,
+			TypePrice: v.TypePrice,
+			Qty:       v.Qty,
+		})
+	}
+
+	priced := pricing.PriceOrder(lines, 0, 0, rules)
+
+	response := dto.Cart{
+		Items:    []dto.CartItem{},
+		Subtotal: priced.Subtotal,
+		Taxes:    toOrderTaxDTO(priced.Taxes),
+		Tax:      priced.Tax,
+		Total:    priced.Total,
+	}
+
+	for i, v := range items {
+		response.Items = append(response.Items, dto.CartItem{
+			ID:            v.ID,
+			MenuID:        v.MenuID,
+			ProductName:   v.ProductName,
+			Image:         v.Image,
+			ProductSizeID: v.ProductSizeID,
+			ProductSize:   v.ProductSize,
+			ProductTypeID: v.ProductTypeID,
+			ProductType:   v.ProductType,
+			Qty:           v.Qty,
+			Price:         v.Price,
+			Discount:      v.Discount,
+			SizePrice:     v.SizePrice,
+			TypePrice:     v.TypePrice,
+			UnitPrice:     priced.Lines[i].UnitPrice,
+			Stock:         v.Stock,
+			Subtotal:      priced.Lines[i].Subtotal,
+		})
+	}
+
+	return response
+}
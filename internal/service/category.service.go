@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type CategoryService struct {
+	categoryRepository *repository.CategoryRepository
+	redis              *redis.Client
+	db                 *pgxpool.Pool
+}
+
+func NewCategoryService(categoryRepository *repository.CategoryRepository, rdb *redis.Client, db *pgxpool.Pool) *CategoryService {
+	return &CategoryService{categoryRepository: categoryRepository, redis: rdb, db: db}
+}
+
+// invalidateCache drops the public category list and the cached product
+// lists, which are filtered by category name.
+func (cs *CategoryService) invalidateCache(ctx context.Context) {
+	if err := cs.redis.Del(ctx, fmt.Sprintf("%s:categories", os.Getenv("RDB_KEY"))).Err(); err != nil {
+		log.Printf("failed to invalidate cache: %v", err)
+	}
+
+	pattern := fmt.Sprintf("%s:products:*", os.Getenv("RDB_KEY"))
+	iter := cs.redis.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		if err := cs.redis.Del(ctx, iter.Val()).Err(); err != nil {
+			log.Printf("failed to delete cache key %s: %v", iter.Val(), err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		log.Printf("error during cache invalidation: %v", err)
+	}
+}
+
+func (cs *CategoryService) CreateCategory(ctx context.Context, req dto.CategoryRequest) (dto.Category, error) {
+	id, err := cs.categoryRepository.CreateCategory(ctx, cs.db, req)
+	if err != nil {
+		return dto.Category{}, err
+	}
+
+	cs.invalidateCache(ctx)
+
+	return dto.Category{ID: id, Name: req.Name}, nil
+}
+
+func (cs *CategoryService) GetCategory(ctx context.Context, categoryID int) (dto.Category, error) {
+	data, err := cs.categoryRepository.GetCategory(ctx, cs.db, categoryID)
+	if err != nil {
+		return dto.Category{}, err
+	}
+
+	return toCategoryDTO(data), nil
+}
+
+func (cs *CategoryService) GetCategories(ctx context.Context, req dto.CategoryParams) ([]dto.Category, int, error) {
+	totalPage, err := cs.categoryRepository.GetTotalPage(ctx, cs.db, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := cs.categoryRepository.GetCategories(ctx, cs.db, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response []dto.Category
+	for _, v := range data {
+		response = append(response, toCategoryDTO(v))
+	}
+
+	return response, totalPage, nil
+}
+
+func (cs *CategoryService) UpdateCategory(ctx context.Context, req dto.CategoryRequest, categoryID int) error {
+	if err := cs.categoryRepository.UpdateCategory(ctx, cs.db, req, categoryID); err != nil {
+		return err
+	}
+
+	cs.invalidateCache(ctx)
+
+	return nil
+}
+
+func (cs *CategoryService) DeleteCategory(ctx context.Context, categoryID int) error {
+	tx, err := cs.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := cs.categoryRepository.DeleteCategory(ctx, tx, categoryID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return err
+	}
+
+	cs.invalidateCache(ctx)
+
+	return nil
+}
+
+func (cs *CategoryService) GetAllCategories(ctx context.Context) ([]dto.Category, error) {
+	rkey := fmt.Sprintf("%s:categories", os.Getenv("RDB_KEY"))
+
+	rsc := cs.redis.Get(ctx, rkey)
+	if rsc.Err() == nil {
+		var result []dto.Category
+		cache, err := rsc.Bytes()
+		if err != nil {
+			log.Println(err)
+		} else {
+			if err := json.Unmarshal(cache, &result); err != nil {
+				log.Println(err.Error())
+			} else {
+				return result, nil
+			}
+		}
+	}
+
+	if rsc.Err() == redis.Nil {
+		log.Println("categories cache miss")
+	}
+
+	data, err := cs.categoryRepository.GetAllCategories(ctx, cs.db)
+	if err != nil {
+		return []dto.Category{}, err
+	}
+
+	response := []dto.Category{}
+	for _, v := range data {
+		response = append(response, dto.Category{ID: v.ID, Name: v.Name})
+	}
+
+	cacheStr, err := json.Marshal(response)
+	if err != nil {
+		log.Println(err)
+		log.Println("failed to marshal")
+	}
+
+	rdsStatus := cs.redis.Set(ctx, rkey, string(cacheStr), time.Minute*10)
+	if rdsStatus.Err() != nil {
+		log.Println("caching failed")
+		log.Println(rdsStatus.Err().Error())
+	}
+
+	return response, nil
+}
+
+func toCategoryDTO(c model.Category) dto.Category {
+	return dto.Category{
+		ID:           c.ID,
+		Name:         c.Name,
+		ProductCount: c.ProductCount,
+	}
+}
@@ -4,25 +4,33 @@ import (
 	"context"
 	"log"
 
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/cache"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
 type MenuService struct {
-	menuRepository *repository.MenuRepository
-	redis          *redis.Client
-	db             *pgxpool.Pool
+	menuRepository   *repository.MenuRepository
+	outletRepository *repository.OutletRepository
+	redis            *redis.Client
+	db               *pgxpool.Pool
 }
 
-func NewMenuService(menuRepository *repository.MenuRepository, rdb *redis.Client, db *pgxpool.Pool) *MenuService {
-	return &MenuService{menuRepository: menuRepository, redis: rdb, db: db}
+func NewMenuService(menuRepository *repository.MenuRepository, outletRepository *repository.OutletRepository, rdb *redis.Client, db *pgxpool.Pool) *MenuService {
+	return &MenuService{menuRepository: menuRepository, outletRepository: outletRepository, redis: rdb, db: db}
 }
 
-func (ms *MenuService) CreateMenu(ctx context.Context, req dto.MenuRequest, userID int, token string) error {
-	if err := cache.CheckToken(ctx, ms.redis, userID, token); err != nil {
+func (ms *MenuService) CreateMenu(ctx context.Context, req dto.MenuRequest, scope dto.OutletScope) error {
+	if !scope.Allows(req.OutletID) {
+		return apperror.ErrOutletForbidden
+	}
+
+	if _, err := ms.outletRepository.GetOutlet(ctx, ms.db, req.OutletID); err != nil {
 		return err
 	}
 
@@ -33,70 +41,52 @@ func (ms *MenuService) CreateMenu(ctx context.Context, req dto.MenuRequest, user
 	return nil
 }
 
-func (ms *MenuService) GetMenu(ctx context.Context, userID, menuID int, token string) (dto.Menu, error) {
-	if err := cache.CheckToken(ctx, ms.redis, userID, token); err != nil {
-		return dto.Menu{}, err
-	}
-
-	data, err := ms.menuRepository.GetMenu(ctx, ms.db, menuID)
+func (ms *MenuService) GetMenu(ctx context.Context, scope dto.OutletScope, menuID int) (dto.Menu, error) {
+	data, err := ms.scopedMenu(ctx, scope, menuID)
 	if err != nil {
 		return dto.Menu{}, err
 	}
 
-	res := dto.Menu{
-		ID:          data.ID,
-		Discount:    data.Discount,
-		ProductID:   data.ProductID,
-		ProductName: data.ProductName,
-		Stock:       data.Stock,
-	}
-
-	return res, nil
+	return toMenuDTO(data), nil
 }
 
-func (ms *MenuService) GetMenus(ctx context.Context, req dto.MenuParams, userID, menuID int, token string) ([]dto.Menu, int, error) {
-	if err := cache.CheckToken(ctx, ms.redis, userID, token); err != nil {
-		return nil, 0, err
+func (ms *MenuService) GetMenus(ctx context.Context, req dto.MenuParams, page pagination.Page, scope dto.OutletScope, menuID int) ([]dto.Menu, pagination.Result, error) {
+	if req.OutletID != 0 && !scope.Allows(req.OutletID) {
+		return nil, pagination.Result{}, apperror.ErrOutletForbidden
 	}
 
 	tx, err := ms.db.Begin(ctx)
 	if err != nil {
 		log.Println(err.Error())
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 
-	totalPage, err := ms.menuRepository.GetTotalPage(ctx, tx, req)
+	total, err := ms.menuRepository.CountMenus(ctx, tx, req, scope.Filter())
 	if err != nil {
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 	defer tx.Rollback(ctx)
 
-	data, err := ms.menuRepository.GetMenus(ctx, tx, req)
+	data, next, err := ms.menuRepository.GetMenus(ctx, tx, req, page, scope.Filter())
 	if err != nil {
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
 		log.Println("failed to commit", err.Error())
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 
 	var response []dto.Menu
 	for _, v := range data {
-		response = append(response, dto.Menu{
-			ID:          v.ID,
-			Discount:    v.Discount,
-			ProductID:   v.ProductID,
-			ProductName: v.ProductName,
-			Stock:       v.Stock,
-		})
+		response = append(response, toMenuDTO(v))
 	}
 
-	return response, totalPage, nil
+	return response, pagination.Result{Total: total, NextCursor: next}, nil
 }
 
-func (ms *MenuService) UpdateMenu(ctx context.Context, req dto.UpdateMenuRequest, userID, menuID int, token string) error {
-	if err := cache.CheckToken(ctx, ms.redis, userID, token); err != nil {
+func (ms *MenuService) UpdateMenu(ctx context.Context, req dto.UpdateMenuRequest, scope dto.OutletScope, menuID int) error {
+	if _, err := ms.scopedMenu(ctx, scope, menuID); err != nil {
 		return err
 	}
 
@@ -104,11 +94,13 @@ func (ms *MenuService) UpdateMenu(ctx context.Context, req dto.UpdateMenuRequest
 		return err
 	}
 
+	cache.InvalidateCarts(ctx, ms.redis)
+
 	return nil
 }
 
-func (ms *MenuService) DeleteMenu(ctx context.Context, userID, menuID int, token string) error {
-	if err := cache.CheckToken(ctx, ms.redis, userID, token); err != nil {
+func (ms *MenuService) DeleteMenu(ctx context.Context, scope dto.OutletScope, menuID int) error {
+	if _, err := ms.scopedMenu(ctx, scope, menuID); err != nil {
 		return err
 	}
 
@@ -116,5 +108,33 @@ func (ms *MenuService) DeleteMenu(ctx context.Context, userID, menuID int, token
 		return err
 	}
 
+	cache.InvalidateCarts(ctx, ms.redis)
+
 	return nil
 }
+
+// scopedMenu loads a menu and checks it belongs to an outlet in scope.
+func (ms *MenuService) scopedMenu(ctx context.Context, scope dto.OutletScope, menuID int) (model.Menu, error) {
+	data, err := ms.menuRepository.GetMenu(ctx, ms.db, menuID)
+	if err != nil {
+		return model.Menu{}, err
+	}
+
+	if !scope.Allows(data.OutletID) {
+		return model.Menu{}, apperror.ErrOutletForbidden
+	}
+
+	return data, nil
+}
+
+func toMenuDTO(m model.Menu) dto.Menu {
+	return dto.Menu{
+		ID:          m.ID,
+		OutletID:    m.OutletID,
+		Outlet:      m.Outlet,
+		Discount:    m.Discount,
+		ProductID:   m.ProductID,
+		ProductName: m.ProductName,
+		Stock:       m.Stock,
+	}
+}
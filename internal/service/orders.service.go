@@ -5,96 +5,151 @@ import (
 	"errors"
 	"log"
 	"slices"
+	"strings"
+	"time"
 
-	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/cache"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/mail"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/pricing"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/money"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
 type OrderService struct {
-	orderRepository *repository.OrderRepository
-	redis           *redis.Client
-	db              *pgxpool.Pool
+	orderRepository    *repository.OrderRepository
+	voucherRepository  *repository.VoucherRepository
+	taxRepository      *repository.TaxRepository
+	addressRepository  *repository.AddressRepository
+	scheduleRepository *repository.ScheduleRepository
+	mails              *mail.Queue
+	redis              *redis.Client
+	db                 *pgxpool.Pool
 }
 
-func NewOrderService(orderRepository *repository.OrderRepository, db *pgxpool.Pool, rdb *redis.Client) *OrderService {
+func NewOrderService(orderRepository *repository.OrderRepository, voucherRepository *repository.VoucherRepository, taxRepository *repository.TaxRepository, addressRepository *repository.AddressRepository, scheduleRepository *repository.ScheduleRepository, mails *mail.Queue, db *pgxpool.Pool, rdb *redis.Client) *OrderService {
 	return &OrderService{
-		orderRepository: orderRepository,
-		redis:           rdb,
-		db:              db,
+		orderRepository:    orderRepository,
+		voucherRepository:  voucherRepository,
+		taxRepository:      taxRepository,
+		addressRepository:  addressRepository,
+		scheduleRepository: scheduleRepository,
+		mails:              mails,
+		redis:              rdb,
+		db:                 db,
 	}
 }
 
+// Shipping modes. Only delivery orders are sent to an address and pay a
+// delivery fee.
+const (
+	ShippingDineIn   = "dine in"
+	ShippingPickup   = "pickup"
+	ShippingDelivery = "delivery"
+)
+
+// pricedOrder is a priced order along with the outlet its menus come from,
+// and the voucher and the delivery address it was priced with. Both are nil
+// when the order has none.
+type pricedOrder struct {
+	pricing.Order
+	OutletId int
+	Voucher  *model.Voucher
+	Address  *model.UserAddress
+}
+
 func (o OrderService) CreateOrder(ctx context.Context, order dto.CreateOrder, userID int) (dto.CreateOrderResponse, error) {
 	tx, err := o.db.Begin(ctx)
 	if err != nil {
 		log.Println(err)
 		return dto.CreateOrderResponse{}, err
 	}
+	defer tx.Rollback(ctx)
 
-	dataOrder, err := o.orderRepository.CreateOrder(ctx, tx, order, userID)
+	response, err := o.createOrder(ctx, tx, order, userID)
 	if err != nil {
 		return dto.CreateOrderResponse{}, err
 	}
-	defer tx.Rollback(ctx)
 
-	var totalSub float64
+	if e := tx.Commit(ctx); e != nil {
+		log.Println("failed to commit", e.Error())
+		return dto.CreateOrderResponse{}, e
+	}
 
-	for i := range len(order.Menus) {
+	return response, nil
+}
 
-		dataMenu, err := o.orderRepository.GetPriceByMenuId(ctx, tx, order.Menus[i].MenuId)
+// createOrder writes the order, its lines and the stock changes using the
+// caller's transaction, so checkout flows can commit other work alongside it.
+func (o OrderService) createOrder(ctx context.Context, tx pgx.Tx, order dto.CreateOrder, userID int) (dto.CreateOrderResponse, error) {
+	priced, err := o.priceOrder(ctx, tx, dto.OrderQuoteRequest{
+		Shipping:    order.Shipping,
+		AddressId:   order.AddressId,
+		VoucherCode: order.VoucherCode,
+		Menus:       order.Menus,
+	}, userID)
+	if err != nil {
+		return dto.CreateOrderResponse{}, err
+	}
 
+	if order.ScheduledFor != nil {
+		at, err := o.reserveSlot(ctx, tx, priced.OutletId, *order.ScheduledFor)
+		if err != nil {
+			return dto.CreateOrderResponse{}, err
+		}
+		order.ScheduledFor = &at
+	}
+
+	dataOrder, err := o.orderRepository.CreateOrder(ctx, tx, order, userID, priced.OutletId)
+	if err != nil {
+		return dto.CreateOrderResponse{}, err
+	}
+
+	for i, line := range priced.Lines {
 		var dt dto.CreateDetailOrder
 		dt.OrderId = dataOrder.Id_Order
 		dt.MenuId = order.Menus[i].MenuId
-		discount := dataMenu.Price * dataMenu.Discount
-
 		dt.ProductSizeId = order.Menus[i].ProductSizeId
 		dt.ProductTypeId = order.Menus[i].ProductTypeId
+		dt.Qty = line.Qty
+		dt.Subtotal = line.Subtotal
 
-		priceSize, err := o.orderRepository.GetProductSize(ctx, tx, dt.ProductSizeId)
-		priceType, err := o.orderRepository.GetProductType(ctx, tx, dt.ProductTypeId)
-
-		dt.Subtotal = ((dataMenu.Price - discount) * float64(order.Menus[i].Qty)) + float64(priceSize.Price) + float64(priceType.Price)
-		dt.Qty = order.Menus[i].Qty
-
-		currentStock := dataMenu.Stock - order.Menus[i].Qty
-
-		if currentStock < 0 {
-			return dto.CreateOrderResponse{}, errors.New("Stock Insufficient, Order Can't be Done !!")
-		}
-
-		stockUpdt := dataMenu.Stock - order.Menus[i].Qty
-		totalSub = totalSub + dt.Subtotal
-
-		var updtStock dto.UpdateStock
-		updtStock.MenuId = order.Menus[i].MenuId
-		updtStock.Stock = stockUpdt
-
-		cmdx, err := o.orderRepository.UpdateStockByIdMenu(ctx, tx, updtStock)
+		// The stock check happens inside the UPDATE itself, so two orders
+		// racing for the last cups cannot both pass a stale read.
+		cmdx, err := o.orderRepository.DecrementStockByIdMenu(ctx, tx, dt.MenuId, dt.Qty)
 		if err != nil {
 			return dto.CreateOrderResponse{}, err
 		}
 		if cmdx.RowsAffected() == 0 {
-			return dto.CreateOrderResponse{}, errors.New("no data updated")
+			return dto.CreateOrderResponse{}, apperror.ErrStockInsufficient
 		}
 
-		_, e := o.orderRepository.CreateDetailOrder(ctx, tx, dt)
-		if e != nil {
+		if _, err := o.orderRepository.CreateDetailOrder(ctx, tx, dt); err != nil {
 			return dto.CreateOrderResponse{}, err
 		}
 	}
 
-	tax := totalSub * 0.1
-	total := totalSub + tax
+	var voucherId *int
+	if priced.Voucher != nil {
+		if err := o.voucherRepository.IncrementUsage(ctx, tx, priced.Voucher.ID); err != nil {
+			return dto.CreateOrderResponse{}, err
+		}
+		voucherId = &priced.Voucher.ID
+	}
 
 	var updtOrder dto.UpdateOrder
 
 	updtOrder.OrderId = dataOrder.Id_Order
-	updtOrder.Tax = tax
-	updtOrder.Total = total
+	updtOrder.Tax = priced.Tax
+	updtOrder.Total = priced.Total
+	updtOrder.Discount = priced.Discount
+	updtOrder.DeliveryFee = priced.DeliveryFee
+	updtOrder.VoucherId = voucherId
 
 	cmd, err := o.orderRepository.UpdateOrderById(ctx, tx, updtOrder)
 	if err != nil {
@@ -104,9 +159,18 @@ func (o OrderService) CreateOrder(ctx context.Context, order dto.CreateOrder, us
 		return dto.CreateOrderResponse{}, errors.New("no data updated")
 	}
 
-	if e := tx.Commit(ctx); e != nil {
-		log.Println("failed to commit", e.Error())
-		return dto.CreateOrderResponse{}, e
+	if priced.Address != nil {
+		if err := o.orderRepository.SetDeliveryAddress(ctx, tx, dataOrder.Id_Order, *priced.Address); err != nil {
+			return dto.CreateOrderResponse{}, err
+		}
+	}
+
+	if err := o.taxRepository.CreateOrderTaxes(ctx, tx, dataOrder.Id_Order, priced.Taxes); err != nil {
+		return dto.CreateOrderResponse{}, err
+	}
+
+	if err := o.orderRepository.CreateStatusHistory(ctx, tx, dataOrder.Id_Order, OrderStatusPending, userID, "Order created"); err != nil {
+		return dto.CreateOrderResponse{}, err
 	}
 
 	response := dto.CreateOrderResponse{
@@ -116,71 +180,398 @@ func (o OrderService) CreateOrder(ctx context.Context, order dto.CreateOrder, us
 	return response, nil
 }
 
-func (o OrderService) UpdateStatusByOrderId(ctx context.Context, sts dto.UpdateStatusOrder) error {
-	status := []string{"pending", "done", "cancelled"}
-	isAvailable := slices.Contains(status, sts.Status)
+// reserveSlot checks that scheduledFor is the start of one of the outlet's
+// slots that has not begun yet and still has room, and returns it in store
+// local time. The weekday's opening hours stay locked until the order is
+// committed, which makes concurrent orders for that day count the slot one
+// after another.
+func (o OrderService) reserveSlot(ctx context.Context, tx pgx.Tx, outletId int, scheduledFor time.Time) (time.Time, error) {
+	at := scheduledFor.In(storeLocation())
+	if !at.After(time.Now()) {
+		return time.Time{}, apperror.ErrScheduleInPast
+	}
+
+	hours, err := o.scheduleRepository.GetOpeningHoursForUpdate(ctx, tx, outletId, int(at.Weekday()))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !hours.IsOpen {
+		return time.Time{}, apperror.ErrStoreClosed
+	}
+
+	for _, s := range daySlots(hours, at) {
+		if !s.Start.Equal(at) {
+			continue
+		}
+
+		booked, err := o.scheduleRepository.CountScheduledOrders(ctx, tx, outletId, s.Start, s.End)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if booked >= hours.SlotCapacity {
+			return time.Time{}, apperror.ErrSlotFull
+		}
+
+		return at, nil
+	}
+
+	return time.Time{}, apperror.ErrInvalidSlot
+}
+
+// QuoteOrder prices an order exactly like CreateOrder would, without writing
+// anything or consuming the voucher.
+func (o OrderService) QuoteOrder(ctx context.Context, req dto.OrderQuoteRequest, userID int) (dto.OrderQuote, error) {
+	priced, err := o.priceOrder(ctx, o.db, req, userID)
+	if err != nil {
+		return dto.OrderQuote{}, err
+	}
+
+	response := dto.OrderQuote{
+		Lines:       []dto.OrderQuoteLine{},
+		Subtotal:    priced.Subtotal,
+		Discount:    priced.Discount,
+		Taxes:       toOrderTaxDTO(priced.Taxes),
+		Tax:         priced.Tax,
+		DeliveryFee: priced.DeliveryFee,
+		Total:       priced.Total,
+	}
+
+	if priced.Voucher != nil {
+		response.VoucherCode = priced.Voucher.Code
+	}
+
+	for i, line := range priced.Lines {
+		response.Lines = append(response.Lines, dto.OrderQuoteLine{
+			MenuId:        req.Menus[i].MenuId,
+			ProductSizeId: req.Menus[i].ProductSizeId,
+			ProductTypeId: req.Menus[i].ProductTypeId,
+			Qty:           line.Qty,
+			Price:         line.Price,
+			Discount:      line.Discount,
+			UnitDiscount:  line.UnitDiscount,
+			SizePrice:     line.SizePrice,
+			TypePrice:     line.TypePrice,
+			UnitPrice:     line.UnitPrice,
+			Subtotal:      line.Subtotal,
+		})
+	}
+
+	return response, nil
+}
+
+// priceOrder looks up the current prices of the order lines, checks the
+// voucher, the delivery address and loads the tax rules for the shipping
+// mode, then prices it all through the pricing package. Every line has to
+// come from the same outlet, since stock and slots are kept per outlet. The
+// voucher is only checked here, consuming it is left to createOrder.
+func (o OrderService) priceOrder(ctx context.Context, db repository.DBTX, req dto.OrderQuoteRequest, userID int) (pricedOrder, error) {
+	items := []pricing.Item{}
+	outletId := 0
+
+	for _, m := range req.Menus {
+		dataMenu, err := o.orderRepository.GetPriceByMenuId(ctx, db, m.MenuId)
+		if err != nil {
+			return pricedOrder{}, err
+		}
+
+		if outletId != 0 && dataMenu.OutletId != outletId {
+			return pricedOrder{}, apperror.ErrMixedOutlets
+		}
+		outletId = dataMenu.OutletId
+
+		priceSize, err := o.orderRepository.GetProductSize(ctx, db, m.MenuId, m.ProductSizeId)
+		if err != nil {
+			return pricedOrder{}, err
+		}
+		priceType, err := o.orderRepository.GetProductType(ctx, db, m.MenuId, m.ProductTypeId)
+		if err != nil {
+			return pricedOrder{}, err
+		}
+
+		items = append(items, pricing.Item{
+			Price:     dataMenu.Price,
+			Discount:  dataMenu.Discount,
+			SizePrice: priceSize.Price,
+			TypePrice: priceType.Price,
+			Qty:       m.Qty,
+		})
+	}
+
+	var priced pricedOrder
+	var voucherDiscount float64
+
+	if req.VoucherCode != "" {
+		v, err := o.checkVoucher(ctx, db, req.VoucherCode, pricing.Subtotal(items))
+		if err != nil {
+			return pricedOrder{}, err
+		}
+		priced.Voucher = &v
+		voucherDiscount = v.Discount
+	}
+
+	var deliveryFee money.Amount
+	if req.Shipping == ShippingDelivery {
+		address, err := o.addressRepository.GetUserAddress(ctx, db, userID, req.AddressId)
+		if err != nil {
+			return pricedOrder{}, err
+		}
+		if !address.Deliverable {
+			return pricedOrder{}, apperror.ErrAddressNotDeliverable
+		}
+		priced.Address = &address
+		deliveryFee = address.DeliveryFee
+	}
+
+	rules, err := o.taxRepository.GetEffectiveRules(ctx, db, outletId, req.Shipping, time.Now())
+	if err != nil {
+		return pricedOrder{}, err
+	}
+
+	priced.Order = pricing.PriceOrder(items, voucherDiscount, deliveryFee, rules)
+	priced.OutletId = outletId
+	return priced, nil
+}
+
+// checkVoucher checks the voucher's date window and minimum spend. Inside a
+// transaction the voucher row stays locked until its usage is incremented.
+func (o OrderService) checkVoucher(ctx context.Context, db repository.DBTX, code string, subtotal money.Amount) (model.Voucher, error) {
+	voucher, err := o.voucherRepository.GetVoucherByCode(ctx, db, code)
+	if err != nil {
+		return model.Voucher{}, err
+	}
+
+	if !voucher.IsActive {
+		return model.Voucher{}, apperror.ErrVoucherNotActive
+	}
+
+	if subtotal < voucher.MinOrder {
+		return model.Voucher{}, apperror.ErrVoucherMinOrder
+	}
+
+	if voucher.UsageLimit > 0 && voucher.UsageCount >= voucher.UsageLimit {
+		return model.Voucher{}, apperror.ErrVoucherUsageLimit
+	}
+
+	return voucher, nil
+}
+
+// Order statuses, in the order a typical order moves through them.
+const (
+	OrderStatusPending    = "pending"
+	OrderStatusPaid       = "paid"
+	OrderStatusPreparing  = "preparing"
+	OrderStatusReady      = "ready"
+	OrderStatusOnDelivery = "on delivery"
+	OrderStatusPickedUp   = "picked up"
+	OrderStatusDone       = "done"
+	OrderStatusCancelled  = "cancelled"
+)
 
-	if !isAvailable {
-		return errors.New("status is not valid")
+// orderTransitions lists the statuses an order may move to from each status.
+// Cancellation is only possible before the kitchen starts on the order, and
+// done and cancelled are final.
+var orderTransitions = map[string][]string{
+	OrderStatusPending:    {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:       {OrderStatusPreparing, OrderStatusCancelled},
+	OrderStatusPreparing:  {OrderStatusReady},
+	OrderStatusReady:      {OrderStatusOnDelivery, OrderStatusPickedUp},
+	OrderStatusOnDelivery: {OrderStatusDone},
+	OrderStatusPickedUp:   {OrderStatusDone},
+}
+
+func isOrderStatus(status string) bool {
+	if status == OrderStatusDone || status == OrderStatusCancelled {
+		return true
 	}
+	_, ok := orderTransitions[status]
+	return ok
+}
 
-	cmd, err := o.orderRepository.UpdateStatusByOrderId(ctx, o.db, sts)
+// UpdateStatusByOrderId moves an order to a new status if the transition is
+// allowed and records it in the status history. actorID is the user making
+// the change, or 0 for system updates such as payment callbacks. Orders of
+// outlets outside the scope cannot be changed. Orders paid through a provider
+// are only cancelled by refunding them, so the money is returned as well.
+func (o OrderService) UpdateStatusByOrderId(ctx context.Context, sts dto.UpdateStatusOrder, scope dto.OutletScope, actorID int) error {
+	sts.Status = strings.ToLower(strings.TrimSpace(sts.Status))
+	if !isOrderStatus(sts.Status) {
+		return apperror.ErrInvalidOrderStatus
+	}
+
+	tx, err := o.db.Begin(ctx)
 	if err != nil {
+		log.Println(err)
 		return err
 	}
-	if cmd.RowsAffected() == 0 {
-		return errors.New("no data deleted")
+	defer tx.Rollback(ctx)
+
+	outletId, err := o.orderRepository.GetOrderOutletId(ctx, tx, sts.OrderId)
+	if err != nil {
+		return err
+	}
+	if !scope.Allows(outletId) {
+		return apperror.ErrOutletForbidden
 	}
+
+	if sts.Status == OrderStatusCancelled {
+		settled, err := o.orderRepository.HasSettledCharge(ctx, tx, sts.OrderId)
+		if err != nil {
+			return err
+		}
+		if settled {
+			return apperror.ErrRefundRequired
+		}
+	}
+
+	if err := o.updateStatus(ctx, tx, sts, actorID); err != nil {
+		return err
+	}
+
+	if e := tx.Commit(ctx); e != nil {
+		log.Println("failed to commit", e.Error())
+		return e
+	}
+
+	if sts.Status == OrderStatusPaid {
+		o.sendReceipt(ctx, sts.OrderId)
+	}
+
 	return nil
 }
 
-func (os *OrderService) AddReview(ctx context.Context, req dto.AddReview, id int, token string) error {
-	if err := cache.CheckToken(ctx, os.redis, id, token); err != nil {
+// sendReceipt queues the receipt of a paid order. Call it once the payment is
+// committed. The payment stands even when the receipt cannot be built, so
+// failures are only logged.
+func (o OrderService) sendReceipt(ctx context.Context, orderId string) {
+	email, err := o.orderRepository.GetOrderEmail(ctx, o.db, orderId)
+	if err != nil {
+		return
+	}
+
+	data, err := o.orderRepository.GetOrderHistoryById(ctx, o.db, orderId)
+	if err != nil {
+		log.Println("failed to load order for receipt:", err.Error())
+		return
+	}
+
+	order, err := o.detailOrder(ctx, data)
+	if err != nil {
+		log.Println("failed to load order for receipt:", err.Error())
+		return
+	}
+
+	msg, err := mail.OrderReceipt(email, order)
+	if err != nil {
+		log.Println("failed to render receipt mail:", err.Error())
+		return
+	}
+	o.mails.Enqueue(msg)
+}
+
+func (o OrderService) updateStatus(ctx context.Context, tx pgx.Tx, sts dto.UpdateStatusOrder, actorID int) error {
+	current, err := o.orderRepository.GetOrderStatusForUpdate(ctx, tx, sts.OrderId)
+	if err != nil {
 		return err
 	}
 
-	if err := os.orderRepository.AddReview(ctx, os.db, req); err != nil {
+	if !slices.Contains(orderTransitions[current], sts.Status) {
+		return apperror.ErrInvalidStatusTransition
+	}
+
+	cmd, err := o.orderRepository.UpdateStatusByOrderId(ctx, tx, sts)
+	if err != nil {
+		log.Println(err.Error())
+		return apperror.ErrUpdateOrderStatus
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperror.ErrOrderNotFound
+	}
+
+	if sts.Status == OrderStatusCancelled {
+		if err := o.restoreStock(ctx, tx, sts.OrderId); err != nil {
+			return err
+		}
+	}
+
+	return o.orderRepository.CreateStatusHistory(ctx, tx, sts.OrderId, sts.Status, actorID, sts.Note)
+}
+
+// recordRefund marks the order's payment as refunded. Orders that can still
+// be cancelled are cancelled, which returns their stock. Orders the kitchen
+// already started on keep their status and get the refund in their timeline.
+func (o OrderService) recordRefund(ctx context.Context, tx pgx.Tx, orderId, note string, actorID int) error {
+	current, err := o.orderRepository.GetOrderStatusForUpdate(ctx, tx, orderId)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	if err := o.orderRepository.MarkRefunded(ctx, tx, orderId); err != nil {
+		return err
+	}
+
+	if slices.Contains(orderTransitions[current], OrderStatusCancelled) {
+		sts := dto.UpdateStatusOrder{OrderId: orderId, Status: OrderStatusCancelled, Note: note}
+		return o.updateStatus(ctx, tx, sts, actorID)
+	}
+
+	return o.orderRepository.CreateStatusHistory(ctx, tx, orderId, current, actorID, note)
 }
 
-func (o *OrderService) GetAllOrderByAdmin(ctx context.Context, orderId string, status string, page int) ([]dto.Order, int, error) {
+// restoreStock returns the order's quantities to the menus inside the status
+// change transaction. The stock_restored_at marker makes it safe to call more
+// than once for the same order.
+func (o OrderService) restoreStock(ctx context.Context, tx pgx.Tx, orderId string) error {
+	restored, err := o.orderRepository.MarkStockRestored(ctx, tx, orderId)
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return nil
+	}
+
+	return o.orderRepository.RestoreStockByOrderId(ctx, tx, orderId)
+}
+
+func (o *OrderService) GetAllOrderByAdmin(ctx context.Context, orderId string, status string, sort string, page pagination.Page, outletId int, scope dto.OutletScope) ([]dto.Order, pagination.Result, error) {
+	if outletId != 0 && !scope.Allows(outletId) {
+		return nil, pagination.Result{}, apperror.ErrOutletForbidden
+	}
 
-	totalPage, err := o.orderRepository.GetOrderTotalPages(ctx, o.db)
+	total, err := o.orderRepository.CountOrdersByAdmin(ctx, o.db, status, orderId, outletId, scope.Filter())
 	if err != nil {
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 
-	data, err := o.orderRepository.GetAllOrderByAdmin(ctx, o.db, status, orderId, page)
+	data, next, err := o.orderRepository.GetAllOrderByAdmin(ctx, o.db, status, orderId, sort, page, outletId, scope.Filter())
 	if err != nil {
-		return []dto.Order{}, 0, err
+		return []dto.Order{}, pagination.Result{}, err
 	}
 
 	var response []dto.Order
 	for _, v := range data {
 		response = append(response, dto.Order{
-			Order_Id: v.Order_Id,
-			Date:     v.Date,
-			Item:     v.Item,
-			Status:   v.Status,
-			Total:    v.Total,
+			Order_Id:     v.Order_Id,
+			Date:         v.Date,
+			Item:         v.Item,
+			Status:       v.Status,
+			Outlet:       v.Outlet,
+			ScheduledFor: formatSchedule(v.ScheduledFor),
+			Total:        v.Total,
 		})
 	}
-	return response, totalPage, nil
+	return response, pagination.Result{Total: total, NextCursor: next}, nil
 }
 
-func (o *OrderService) GetHistoryByUser(ctx context.Context, page int, userId int) ([]dto.History, int, error) {
+func (o *OrderService) GetHistoryByUser(ctx context.Context, page pagination.Page, userId int) ([]dto.History, pagination.Result, error) {
 
-	totalPage, err := o.orderRepository.GetHistoryTotalPages(ctx, o.db, userId)
+	total, err := o.orderRepository.CountHistoryByUser(ctx, o.db, userId)
 	if err != nil {
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 
-	data, err := o.orderRepository.GetHistoryByUser(ctx, o.db, page, userId)
+	data, next, err := o.orderRepository.GetHistoryByUser(ctx, o.db, page, userId)
 	if err != nil {
-		return []dto.History{}, 0, err
+		return []dto.History{}, pagination.Result{}, err
 	}
 
 	var response []dto.History
@@ -192,18 +583,56 @@ func (o *OrderService) GetHistoryByUser(ctx context.Context, page int, userId in
 			Total:    v.Total,
 		})
 	}
-	return response, totalPage, nil
+	return response, pagination.Result{Total: total, NextCursor: next}, nil
 }
 
-func (o *OrderService) GetDetailHistoryById(ctx context.Context, idOrder string) (dto.DetailOrderResponse, error) {
-	var response dto.DetailOrderResponse
-
+// GetDetailHistoryById returns an order the user placed or, for admins
+// (userID 0), an order of an outlet inside their scope. Any other order is
+// reported as not found.
+func (o *OrderService) GetDetailHistoryById(ctx context.Context, idOrder string, userID int, scope dto.OutletScope) (dto.DetailOrderResponse, error) {
 	data, err := o.orderRepository.GetOrderHistoryById(ctx, o.db, idOrder)
 	if err != nil {
 		return dto.DetailOrderResponse{}, err
 	}
 
+	if (userID != 0 && data.UserId != userID) || !scope.Allows(data.OutletId) {
+		return dto.DetailOrderResponse{}, apperror.ErrOrderNotFound
+	}
+
+	return o.detailOrder(ctx, data)
+}
+
+// detailOrder completes an order with its items, taxes and status timeline.
+func (o *OrderService) detailOrder(ctx context.Context, data model.DetailOrder) (dto.DetailOrderResponse, error) {
+	var response dto.DetailOrderResponse
+	idOrder := data.Order_Id
+
 	dataDt, err := o.orderRepository.GetDetailOrderHistoryById(ctx, o.db, idOrder)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.DetailOrderResponse{}, err
+	}
+
+	dataHistory, err := o.orderRepository.GetStatusHistory(ctx, o.db, idOrder)
+	if err != nil {
+		return dto.DetailOrderResponse{}, err
+	}
+
+	dataTaxes, err := o.taxRepository.GetOrderTaxes(ctx, o.db, idOrder)
+	if err != nil {
+		return dto.DetailOrderResponse{}, err
+	}
+
+	timeline := []dto.OrderStatusHistory{}
+	for _, v := range dataHistory {
+		timeline = append(timeline, dto.OrderStatusHistory{
+			Status:    v.Status,
+			ActorId:   v.ActorId,
+			ActorName: v.ActorName,
+			Note:      v.Note,
+			CreatedAt: v.CreatedAt,
+		})
+	}
 	imgStr := &[]string{}
 
 	var resp []dto.DetailItemResponse
@@ -226,14 +655,26 @@ func (o *OrderService) GetDetailHistoryById(ctx context.Context, idOrder string)
 	response = dto.DetailOrderResponse{
 		Order_Id:      data.Order_Id,
 		DateOrder:     data.DateOrder,
+		OutletId:      data.OutletId,
+		Outlet:        data.Outlet,
 		FullName:      data.FullName,
 		Address:       data.Address,
 		Phone:         data.Phone,
+		DeliveryLabel: data.DeliveryLabel,
+		DeliveryZone:  data.DeliveryZone,
 		PaymentMethod: data.PaymentMethod,
 		Shipping:      data.Shipping,
 		Status:        data.Status,
+		VoucherCode:   data.VoucherCode,
+		Discount:      data.Discount,
+		Tax:           data.Tax,
+		Taxes:         toOrderTaxDTO(dataTaxes),
+		DeliveryFee:   data.DeliveryFee,
 		Total:         data.Total,
+		ScheduledFor:  formatSchedule(data.ScheduledFor),
+		RefundedAt:    formatSchedule(data.RefundedAt),
 		DetailItem:    resp,
+		Timeline:      timeline,
 	}
 
 	return response, nil
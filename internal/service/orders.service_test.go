@@ -0,0 +1,197 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/mail"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/testutil"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// testRedis connects to TEST_REDIS_URL when set. Placing and cancelling
+// orders does not use redis, so without it the client is never dialed.
+func testRedis(tb testing.TB) *redis.Client {
+	opt := &redis.Options{}
+	if url := os.Getenv("TEST_REDIS_URL"); url != "" {
+		var err error
+		if opt, err = redis.ParseURL(url); err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	rdb := redis.NewClient(opt)
+	tb.Cleanup(func() { rdb.Close() })
+	return rdb
+}
+
+// discardMailer stands in for the mail server.
+type discardMailer struct{}
+
+func (discardMailer) Send(ctx context.Context, msg mail.Message) error {
+	return nil
+}
+
+// stockFixture is a menu with its own product, size and type, ordered by its
+// own user, so the test neither depends on nor changes existing data.
+type stockFixture struct {
+	userId, paymentId, productId, sizeId, typeId, menuId int
+}
+
+func newStockFixture(t *testing.T, db *pgxpool.Pool, stock int) stockFixture {
+	t.Helper()
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+
+	insert := func(query string, args ...any) int {
+		t.Helper()
+		var id int
+		if err := db.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+			t.Fatalf("fixture %q: %v", query, err)
+		}
+		return id
+	}
+
+	var f stockFixture
+	f.userId = insert("INSERT INTO users (fullname, email, password) VALUES ('Stock Race', $1, '') RETURNING id", fmt.Sprintf("stockrace-%d@test.local", suffix))
+	f.paymentId = insert("INSERT INTO payments (name) VALUES ('Stock Race') RETURNING id")
+	f.productId = insert("INSERT INTO products (name, price) VALUES ($1, 20000) RETURNING id", fmt.Sprintf("Stock Race %d", suffix))
+	f.sizeId = insert("INSERT INTO product_size (name, price) VALUES ('Regular', 0) RETURNING id")
+	f.typeId = insert("INSERT INTO product_type (name, price) VALUES ('Hot', 0) RETURNING id")
+	f.menuId = insert(`
+		INSERT INTO menus (product_id, stock, outlet_id)
+		VALUES ($1, $2, (SELECT MIN(id) FROM outlets WHERE is_active AND deleted_at IS NULL))
+		RETURNING id
+	`, f.productId, stock)
+
+	t.Cleanup(func() {
+		for table, id := range map[string]int{
+			"menus":        f.menuId,
+			"products":     f.productId,
+			"product_size": f.sizeId,
+			"product_type": f.typeId,
+			"payments":     f.paymentId,
+			"users":        f.userId,
+		} {
+			if _, err := db.Exec(ctx, "UPDATE "+table+" SET deleted_at = NOW() WHERE id = $1", id); err != nil {
+				t.Logf("cleanup %s %d: %v", table, id, err)
+			}
+		}
+	})
+
+	return f
+}
+
+func readStock(t *testing.T, db *pgxpool.Pool, menuId int) int {
+	t.Helper()
+	var stock int
+	if err := db.QueryRow(context.Background(), "SELECT stock FROM menus WHERE id = $1", menuId).Scan(&stock); err != nil {
+		t.Fatal(err)
+	}
+	return stock
+}
+
+// TestCreateOrderStockRace places more orders for one menu than it has stock
+// for, all at once, then cancels every placed order twice in parallel. Stock
+// may never go negative, every cup sold has to be accounted for by an order
+// line, and the cancellations have to return the stock exactly once.
+func TestCreateOrderStockRace(t *testing.T) {
+	const (
+		stock  = 10
+		orders = 50
+	)
+
+	db := testutil.DB(t)
+	ctx := context.Background()
+	f := newStockFixture(t, db, stock)
+
+	orderService := NewOrderService(repository.NewOrderRepository(), repository.NewVoucherRepository(), repository.NewTaxRepository(), repository.NewAddressRepository(), repository.NewScheduleRepository(), mail.NewQueue(discardMailer{}, 1, orders), db, testRedis(t))
+
+	order := dto.CreateOrder{
+		Shipping:   ShippingDineIn,
+		Payment_Id: f.paymentId,
+		Menus: []dto.CreateMenuOrder{{
+			MenuId:        f.menuId,
+			Qty:           1,
+			ProductSizeId: f.sizeId,
+			ProductTypeId: f.typeId,
+		}},
+	}
+
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		created      []string
+		insufficient int
+	)
+
+	start := make(chan struct{})
+	for range orders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			res, err := orderService.CreateOrder(ctx, order, f.userId)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created = append(created, res.Id_Order)
+			case errors.Is(err, apperror.ErrStockInsufficient):
+				insufficient++
+			default:
+				t.Errorf("CreateOrder: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(created) != stock || insufficient != orders-stock {
+		t.Fatalf("placed %d and rejected %d orders, want %d and %d", len(created), insufficient, stock, orders-stock)
+	}
+
+	if got := readStock(t, db, f.menuId); got != 0 {
+		t.Fatalf("stock after ordering = %d, want 0", got)
+	}
+
+	var sold int
+	if err := db.QueryRow(ctx, "SELECT COALESCE(SUM(qty), 0) FROM dt_order WHERE menu_id = $1", f.menuId).Scan(&sold); err != nil {
+		t.Fatal(err)
+	}
+	if sold != stock {
+		t.Fatalf("order lines hold %d cups, want %d", sold, stock)
+	}
+
+	for _, id := range created {
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sts := dto.UpdateStatusOrder{OrderId: id, Status: OrderStatusCancelled, Note: "stock race"}
+				err := orderService.UpdateStatusByOrderId(ctx, sts, dto.OutletScope{All: true}, 0)
+				if err != nil && !errors.Is(err, apperror.ErrInvalidStatusTransition) {
+					t.Errorf("cancel %s: %v", id, err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	if got := readStock(t, db, f.menuId); got != stock {
+		t.Fatalf("stock after cancelling = %d, want %d", got, stock)
+	}
+}
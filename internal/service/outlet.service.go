@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type OutletService struct {
+	outletRepository   *repository.OutletRepository
+	scheduleRepository *repository.ScheduleRepository
+	redis              *redis.Client
+	db                 *pgxpool.Pool
+}
+
+func NewOutletService(outletRepository *repository.OutletRepository, scheduleRepository *repository.ScheduleRepository, rdb *redis.Client, db *pgxpool.Pool) *OutletService {
+	return &OutletService{outletRepository: outletRepository, scheduleRepository: scheduleRepository, redis: rdb, db: db}
+}
+
+// invalidateProductsCache drops the cached product lists, since they only
+// show products of active outlets.
+func (ols *OutletService) invalidateProductsCache(ctx context.Context) {
+	pattern := fmt.Sprintf("%s:products:*", os.Getenv("RDB_KEY"))
+	iter := ols.redis.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		if err := ols.redis.Del(ctx, iter.Val()).Err(); err != nil {
+			log.Printf("failed to delete cache key %s: %v", iter.Val(), err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		log.Printf("error during cache invalidation: %v", err)
+	}
+}
+
+func (ols *OutletService) GetActiveOutlets(ctx context.Context) ([]dto.Outlet, error) {
+	data, err := ols.outletRepository.GetActiveOutlets(ctx, ols.db)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.Outlet{}
+	for _, v := range data {
+		response = append(response, toOutletDTO(v))
+	}
+
+	return response, nil
+}
+
+// CreateOutlet adds an outlet with the default opening hours. Only admins
+// who manage every outlet can add new ones.
+func (ols *OutletService) CreateOutlet(ctx context.Context, req dto.OutletRequest, scope dto.OutletScope) (dto.Outlet, error) {
+	if !scope.All {
+		return dto.Outlet{}, apperror.ErrOutletForbidden
+	}
+
+	tx, err := ols.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.Outlet{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := ols.outletRepository.CreateOutlet(ctx, tx, req)
+	if err != nil {
+		return dto.Outlet{}, err
+	}
+
+	if err := ols.scheduleRepository.CreateOpeningHours(ctx, tx, id); err != nil {
+		return dto.Outlet{}, err
+	}
+
+	data, err := ols.outletRepository.GetOutlet(ctx, tx, id)
+	if err != nil {
+		return dto.Outlet{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return dto.Outlet{}, err
+	}
+
+	return toOutletDTO(data), nil
+}
+
+func (ols *OutletService) GetOutlet(ctx context.Context, scope dto.OutletScope, outletID int) (dto.Outlet, error) {
+	if !scope.Allows(outletID) {
+		return dto.Outlet{}, apperror.ErrOutletForbidden
+	}
+
+	data, err := ols.outletRepository.GetOutlet(ctx, ols.db, outletID)
+	if err != nil {
+		return dto.Outlet{}, err
+	}
+
+	return toOutletDTO(data), nil
+}
+
+func (ols *OutletService) GetOutlets(ctx context.Context, req dto.OutletParams, scope dto.OutletScope) ([]dto.Outlet, int, error) {
+	totalPage, err := ols.outletRepository.GetTotalPage(ctx, ols.db, req, scope.Filter())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := ols.outletRepository.GetOutlets(ctx, ols.db, req, scope.Filter())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response []dto.Outlet
+	for _, v := range data {
+		response = append(response, toOutletDTO(v))
+	}
+
+	return response, totalPage, nil
+}
+
+func (ols *OutletService) UpdateOutlet(ctx context.Context, req dto.UpdateOutletRequest, scope dto.OutletScope, outletID int) error {
+	if !scope.Allows(outletID) {
+		return apperror.ErrOutletForbidden
+	}
+
+	if err := ols.outletRepository.UpdateOutlet(ctx, ols.db, req, outletID); err != nil {
+		return err
+	}
+
+	ols.invalidateProductsCache(ctx)
+	return nil
+}
+
+// DeleteOutlet removes the outlet and its menus. Orders placed at the outlet
+// are kept for the order history.
+func (ols *OutletService) DeleteOutlet(ctx context.Context, scope dto.OutletScope, outletID int) error {
+	if !scope.All {
+		return apperror.ErrOutletForbidden
+	}
+
+	tx, err := ols.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := ols.outletRepository.DeleteOutlet(ctx, tx, outletID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return err
+	}
+
+	ols.invalidateProductsCache(ctx)
+	return nil
+}
+
+func (ols *OutletService) GetOutletAdmins(ctx context.Context, scope dto.OutletScope, outletID int) ([]dto.OutletAdmin, error) {
+	if !scope.Allows(outletID) {
+		return nil, apperror.ErrOutletForbidden
+	}
+
+	if _, err := ols.outletRepository.GetOutlet(ctx, ols.db, outletID); err != nil {
+		return nil, err
+	}
+
+	data, err := ols.outletRepository.GetOutletAdmins(ctx, ols.db, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOutletAdminDTO(data), nil
+}
+
+// SetOutletAdmins replaces who manages the outlet. Only admins who manage
+// every outlet can hand outlets out, so nobody can widen their own scope.
+func (ols *OutletService) SetOutletAdmins(ctx context.Context, req dto.OutletAdminsRequest, scope dto.OutletScope, outletID int) ([]dto.OutletAdmin, error) {
+	if !scope.All {
+		return nil, apperror.ErrOutletForbidden
+	}
+
+	tx, err := ols.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := ols.outletRepository.GetOutlet(ctx, tx, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := ols.outletRepository.SetOutletAdmins(ctx, tx, outletID, req.UserIds); err != nil {
+		return nil, err
+	}
+
+	data, err := ols.outletRepository.GetOutletAdmins(ctx, tx, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return nil, err
+	}
+
+	return toOutletAdminDTO(data), nil
+}
+
+// GetGlobalAdmins lists the admins granted every outlet.
+func (ols *OutletService) GetGlobalAdmins(ctx context.Context, scope dto.OutletScope) ([]dto.OutletAdmin, error) {
+	if !scope.All {
+		return nil, apperror.ErrOutletForbidden
+	}
+
+	data, err := ols.outletRepository.GetGlobalAdmins(ctx, ols.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOutletAdminDTO(data), nil
+}
+
+// SetGlobalAdmin grants or revokes an admin's access to every outlet. Only
+// admins who manage every outlet can change it, and none of them can revoke
+// their own, so there is always one left to hand out access.
+func (ols *OutletService) SetGlobalAdmin(ctx context.Context, scope dto.OutletScope, actorID, userID int, all bool) ([]dto.OutletAdmin, error) {
+	if !scope.All {
+		return nil, apperror.ErrOutletForbidden
+	}
+
+	if !all && userID == actorID {
+		return nil, apperror.ErrRevokeOwnOutlets
+	}
+
+	if err := ols.outletRepository.SetAllOutlets(ctx, ols.db, userID, all); err != nil {
+		return nil, err
+	}
+
+	data, err := ols.outletRepository.GetGlobalAdmins(ctx, ols.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOutletAdminDTO(data), nil
+}
+
+func toOutletAdminDTO(admins []model.OutletAdmin) []dto.OutletAdmin {
+	response := []dto.OutletAdmin{}
+	for _, v := range admins {
+		response = append(response, dto.OutletAdmin{
+			UserId:   v.UserId,
+			Fullname: v.Fullname,
+			Email:    v.Email,
+		})
+	}
+	return response
+}
+
+func toOutletDTO(o model.Outlet) dto.Outlet {
+	return dto.Outlet{
+		ID:       o.ID,
+		Name:     o.Name,
+		Address:  o.Address,
+		Phone:    o.Phone,
+		IsActive: o.IsActive,
+	}
+}
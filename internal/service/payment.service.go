@@ -0,0 +1,492 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/payment"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type PaymentService struct {
+	paymentRepository *repository.PaymentRepository
+	orderService      *OrderService
+	providers         *payment.Registry
+	redis             *redis.Client
+	db                *pgxpool.Pool
+}
+
+func NewPaymentService(paymentRepository *repository.PaymentRepository, orderService *OrderService, providers *payment.Registry, rdb *redis.Client, db *pgxpool.Pool) *PaymentService {
+	return &PaymentService{
+		paymentRepository: paymentRepository,
+		orderService:      orderService,
+		providers:         providers,
+		redis:             rdb,
+		db:                db,
+	}
+}
+
+func (ps *PaymentService) CreatePayment(ctx context.Context, req dto.PaymentMethodRequest) error {
+	if _, err := ps.providers.Get(req.Provider); err != nil {
+		return err
+	}
+
+	return ps.paymentRepository.CreatePayment(ctx, ps.db, req)
+}
+
+func (ps *PaymentService) GetPayment(ctx context.Context, paymentID int) (dto.PaymentMethod, error) {
+	data, err := ps.paymentRepository.GetPayment(ctx, ps.db, paymentID)
+	if err != nil {
+		return dto.PaymentMethod{}, err
+	}
+
+	return toPaymentMethodDTO(data), nil
+}
+
+func (ps *PaymentService) GetPayments(ctx context.Context, req dto.PaymentParams) ([]dto.PaymentMethod, int, error) {
+	totalPage, err := ps.paymentRepository.GetTotalPage(ctx, ps.db, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := ps.paymentRepository.GetPayments(ctx, ps.db, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response []dto.PaymentMethod
+	for _, v := range data {
+		response = append(response, toPaymentMethodDTO(v))
+	}
+
+	return response, totalPage, nil
+}
+
+func (ps *PaymentService) UpdatePayment(ctx context.Context, req dto.UpdatePaymentMethodRequest, paymentID int) error {
+	if req.Provider != "" {
+		if _, err := ps.providers.Get(req.Provider); err != nil {
+			return err
+		}
+	}
+
+	return ps.paymentRepository.UpdatePayment(ctx, ps.db, req, paymentID)
+}
+
+func (ps *PaymentService) DeletePayment(ctx context.Context, paymentID int) error {
+	return ps.paymentRepository.DeletePayment(ctx, ps.db, paymentID)
+}
+
+// PayOrder charges the order through the provider of its payment method and
+// records the attempt. The order row stays locked while the charge is created,
+// and a charge still waiting for the customer is returned again instead of
+// opening another one, so a double click or a retry cannot produce two
+// charges. created reports whether a new charge was made. A charge that
+// settles right away moves the order to paid in the same transaction.
+func (ps *PaymentService) PayOrder(ctx context.Context, orderID string, userID int) (dto.PaymentAttempt, bool, error) {
+	tx, err := ps.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.PaymentAttempt{}, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	order, err := ps.paymentRepository.GetPayableOrder(ctx, tx, orderID)
+	if err != nil {
+		return dto.PaymentAttempt{}, false, err
+	}
+
+	if order.UserId != userID {
+		return dto.PaymentAttempt{}, false, apperror.ErrOrderNotFound
+	}
+
+	if order.Status != OrderStatusPending {
+		return dto.PaymentAttempt{}, false, apperror.ErrOrderNotPayable
+	}
+
+	latest, err := ps.paymentRepository.GetLatestAttempt(ctx, tx, order.OrderId)
+	if err != nil && !errors.Is(err, apperror.ErrChargeNotFound) {
+		return dto.PaymentAttempt{}, false, err
+	}
+	if err == nil && latest.Status == string(payment.StatusPending) {
+		return toPaymentAttemptDTO(latest), false, nil
+	}
+
+	if !order.IsActive {
+		return dto.PaymentAttempt{}, false, apperror.ErrPaymentInactive
+	}
+
+	provider, err := ps.providers.Get(order.Provider)
+	if err != nil {
+		return dto.PaymentAttempt{}, false, err
+	}
+
+	charge, err := provider.CreateCharge(ctx, payment.ChargeRequest{
+		OrderId: order.OrderId,
+		Amount:  order.Total,
+	})
+	if err != nil {
+		log.Println(err.Error())
+		return dto.PaymentAttempt{}, false, err
+	}
+
+	attempt := model.PaymentAttempt{
+		OrderId:    order.OrderId,
+		PaymentId:  order.PaymentId,
+		Provider:   provider.Name(),
+		Reference:  charge.Reference,
+		Amount:     order.Total,
+		Status:     string(charge.Status),
+		PaymentURL: charge.PaymentURL,
+	}
+
+	attempt.ID, err = ps.paymentRepository.CreatePaymentAttempt(ctx, tx, attempt)
+	if err != nil {
+		return dto.PaymentAttempt{}, false, err
+	}
+
+	if charge.Status == payment.StatusSettled {
+		sts := dto.UpdateStatusOrder{
+			OrderId: order.OrderId,
+			Status:  OrderStatusPaid,
+			Note:    fmt.Sprintf("Paid via %s (%s)", provider.Name(), charge.Reference),
+		}
+		if err := ps.orderService.updateStatus(ctx, tx, sts, 0); err != nil {
+			return dto.PaymentAttempt{}, false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return dto.PaymentAttempt{}, false, err
+	}
+
+	if charge.Status == payment.StatusSettled {
+		ps.orderService.sendReceipt(ctx, order.OrderId)
+	}
+
+	return toPaymentAttemptDTO(attempt), true, nil
+}
+
+// RefundOrder refunds the settled charge of the order through its provider
+// and applies the refund to the order like a gateway event would. The charge
+// is marked refund_pending and committed before the provider is called, so a
+// refund the gateway made is never lost to a failed commit. A refund that did
+// not finish can be sent again, providers refund idempotently by reference.
+// Orders of outlets outside the scope cannot be refunded.
+func (ps *PaymentService) RefundOrder(ctx context.Context, orderID string, scope dto.OutletScope, actorID int) (dto.PaymentAttempt, error) {
+	attempt, provider, err := ps.reserveRefund(ctx, orderID, scope)
+	if err != nil {
+		return dto.PaymentAttempt{}, err
+	}
+
+	if err := provider.Refund(ctx, attempt.Reference, attempt.Amount); err != nil {
+		log.Println(err.Error())
+		return dto.PaymentAttempt{}, err
+	}
+
+	tx, err := ps.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.PaymentAttempt{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// A refund webhook may have finished the refund in the meantime, which
+	// applyChargeStatus then skips.
+	attempt, err = ps.paymentRepository.GetAttemptByReference(ctx, tx, attempt.Provider, attempt.Reference)
+	if err != nil {
+		return dto.PaymentAttempt{}, err
+	}
+
+	note := fmt.Sprintf("Refunded via %s (%s)", provider.Name(), attempt.Reference)
+	if _, err := ps.applyChargeStatus(ctx, tx, attempt, payment.StatusRefunded, note, actorID); err != nil {
+		return dto.PaymentAttempt{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return dto.PaymentAttempt{}, err
+	}
+
+	attempt.Status = string(payment.StatusRefunded)
+	return toPaymentAttemptDTO(attempt), nil
+}
+
+// reserveRefund marks the latest charge of the order as refund_pending. Only
+// settled charges, or ones whose refund did not finish, can be refunded.
+func (ps *PaymentService) reserveRefund(ctx context.Context, orderID string, scope dto.OutletScope) (model.PaymentAttempt, payment.PaymentProvider, error) {
+	tx, err := ps.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return model.PaymentAttempt{}, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	attempt, provider, err := ps.getOrderAttempt(ctx, tx, orderID, scope)
+	if err != nil {
+		return model.PaymentAttempt{}, nil, err
+	}
+
+	if attempt.Status == string(payment.StatusRefundPending) {
+		return attempt, provider, nil
+	}
+	if attempt.Status != string(payment.StatusSettled) {
+		return model.PaymentAttempt{}, nil, apperror.ErrRefundNotAllowed
+	}
+
+	if err := ps.paymentRepository.UpdateAttemptStatus(ctx, tx, attempt.ID, string(payment.StatusRefundPending)); err != nil {
+		return model.PaymentAttempt{}, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return model.PaymentAttempt{}, nil, err
+	}
+
+	attempt.Status = string(payment.StatusRefundPending)
+	return attempt, provider, nil
+}
+
+// ReconcileOrder asks the provider for the current status of the order's
+// latest charge and applies it when the gateway moved on, which recovers
+// orders whose webhook never arrived.
+func (ps *PaymentService) ReconcileOrder(ctx context.Context, orderID string, scope dto.OutletScope, actorID int) (dto.PaymentAttempt, error) {
+	tx, err := ps.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.PaymentAttempt{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	attempt, provider, err := ps.getOrderAttempt(ctx, tx, orderID, scope)
+	if err != nil {
+		return dto.PaymentAttempt{}, err
+	}
+
+	status, err := provider.GetStatus(ctx, attempt.Reference)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.PaymentAttempt{}, err
+	}
+
+	if status == payment.Status(attempt.Status) {
+		return toPaymentAttemptDTO(attempt), nil
+	}
+
+	note := fmt.Sprintf("Payment %s via %s (%s), reconciled", status, provider.Name(), attempt.Reference)
+	paidOrderId, err := ps.applyChargeStatus(ctx, tx, attempt, status, note, actorID)
+	if err != nil {
+		return dto.PaymentAttempt{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return dto.PaymentAttempt{}, err
+	}
+
+	if paidOrderId != "" {
+		ps.orderService.sendReceipt(ctx, paidOrderId)
+	}
+
+	attempt.Status = string(status)
+	return toPaymentAttemptDTO(attempt), nil
+}
+
+// getOrderAttempt locks the latest payment attempt of an order inside the
+// scope and looks up the provider that made it.
+func (ps *PaymentService) getOrderAttempt(ctx context.Context, tx pgx.Tx, orderID string, scope dto.OutletScope) (model.PaymentAttempt, payment.PaymentProvider, error) {
+	outletId, err := ps.orderService.orderRepository.GetOrderOutletId(ctx, tx, orderID)
+	if err != nil {
+		return model.PaymentAttempt{}, nil, err
+	}
+	if !scope.Allows(outletId) {
+		return model.PaymentAttempt{}, nil, apperror.ErrOutletForbidden
+	}
+
+	attempt, err := ps.paymentRepository.GetLatestAttempt(ctx, tx, orderID)
+	if err != nil {
+		return model.PaymentAttempt{}, nil, err
+	}
+
+	provider, err := ps.providers.Get(attempt.Provider)
+	if err != nil {
+		return model.PaymentAttempt{}, nil, err
+	}
+
+	return attempt, provider, nil
+}
+
+// attemptTransitions lists the statuses a charge may move to from each status.
+// Gateways deliver events late and out of order, so anything else, such as a
+// redelivered pending after the settlement, is ignored.
+var attemptTransitions = map[payment.Status][]payment.Status{
+	payment.StatusPending:       {payment.StatusSettled, payment.StatusFailed, payment.StatusExpired},
+	payment.StatusSettled:       {payment.StatusRefunded},
+	payment.StatusRefundPending: {payment.StatusRefunded},
+}
+
+// chargeOrderStatus maps a charge status onto the order status it leads to.
+// It only applies while the order is still pending, statuses without an
+// entry leave the order as it is. Refunds are recorded on the order
+// separately, since they can arrive at any status.
+var chargeOrderStatus = map[payment.Status]string{
+	payment.StatusSettled: OrderStatusPaid,
+	payment.StatusFailed:  OrderStatusCancelled,
+	payment.StatusExpired: OrderStatusCancelled,
+}
+
+// HandleWebhook verifies and applies a gateway event. Events are deduplicated
+// by id twice: a Redis marker answers redeliveries without touching Postgres,
+// and the payment_events unique key stays authoritative when Redis forgets.
+func (ps *PaymentService) HandleWebhook(ctx context.Context, providerName string, body []byte, signature string) error {
+	provider, err := ps.providers.Get(providerName)
+	if err != nil {
+		return err
+	}
+
+	webhookProvider, ok := provider.(payment.WebhookProvider)
+	if !ok {
+		return apperror.ErrPaymentProviderNotFound
+	}
+
+	if !payment.VerifySignature(payment.WebhookSecret(providerName), body, signature) {
+		return apperror.ErrInvalidSignature
+	}
+
+	event, err := webhookProvider.ParseEvent(body)
+	if err != nil {
+		return err
+	}
+
+	rkey := fmt.Sprintf("%s:payment_event:%s:%s", os.Getenv("RDB_KEY"), providerName, event.ID)
+	if n, err := ps.redis.Exists(ctx, rkey).Result(); err == nil && n > 0 {
+		log.Println("payment event already processed:", event.ID)
+		return nil
+	}
+
+	tx, err := ps.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	isNew, err := ps.paymentRepository.CreatePaymentEvent(ctx, tx, providerName, event.ID, event.Reference, string(event.Status), body)
+	if err != nil {
+		return err
+	}
+
+	paidOrderId := ""
+	if isNew {
+		if paidOrderId, err = ps.applyChargeEvent(ctx, tx, providerName, event); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return err
+	}
+
+	if paidOrderId != "" {
+		ps.orderService.sendReceipt(ctx, paidOrderId)
+	}
+
+	if err := ps.redis.Set(ctx, rkey, 1, time.Hour*24).Err(); err != nil {
+		log.Println("caching failed")
+		log.Println(err.Error())
+	}
+
+	return nil
+}
+
+// applyChargeEvent records the event on its payment attempt and moves the
+// order along. It returns the order id when the event paid the order.
+func (ps *PaymentService) applyChargeEvent(ctx context.Context, tx pgx.Tx, providerName string, event payment.Event) (string, error) {
+	attempt, err := ps.paymentRepository.GetAttemptByReference(ctx, tx, providerName, event.Reference)
+	if err != nil {
+		return "", err
+	}
+
+	note := fmt.Sprintf("Payment %s via %s (%s)", event.Status, providerName, event.Reference)
+	return ps.applyChargeStatus(ctx, tx, attempt, event.Status, note, 0)
+}
+
+// applyChargeStatus stores the new status of a charge on its attempt and moves
+// the order along. It returns the order id when the charge paid the order.
+// Gateways retry until they get a 2xx, so a status the charge or the order can
+// no longer take stays recorded in payment_events and is otherwise skipped.
+func (ps *PaymentService) applyChargeStatus(ctx context.Context, tx pgx.Tx, attempt model.PaymentAttempt, status payment.Status, note string, actorID int) (string, error) {
+	if !slices.Contains(attemptTransitions[payment.Status(attempt.Status)], status) {
+		log.Printf("charge %s is %s, ignoring %s", attempt.Reference, attempt.Status, status)
+		return "", nil
+	}
+
+	if err := ps.paymentRepository.UpdateAttemptStatus(ctx, tx, attempt.ID, string(status)); err != nil {
+		return "", err
+	}
+
+	if status == payment.StatusRefunded {
+		return "", ps.orderService.recordRefund(ctx, tx, attempt.OrderId, note, actorID)
+	}
+
+	target, ok := chargeOrderStatus[status]
+	if !ok {
+		return "", nil
+	}
+
+	current, err := ps.orderService.orderRepository.GetOrderStatusForUpdate(ctx, tx, attempt.OrderId)
+	if err != nil {
+		return "", err
+	}
+	if current != OrderStatusPending {
+		log.Printf("charge %s is %s, order %s is already %s", attempt.Reference, status, attempt.OrderId, current)
+		return "", nil
+	}
+
+	sts := dto.UpdateStatusOrder{
+		OrderId: attempt.OrderId,
+		Status:  target,
+		Note:    note,
+	}
+	if err := ps.orderService.updateStatus(ctx, tx, sts, actorID); err != nil {
+		return "", err
+	}
+
+	if target != OrderStatusPaid {
+		return "", nil
+	}
+	return attempt.OrderId, nil
+}
+
+func toPaymentAttemptDTO(a model.PaymentAttempt) dto.PaymentAttempt {
+	return dto.PaymentAttempt{
+		ID:         a.ID,
+		OrderId:    a.OrderId,
+		Provider:   a.Provider,
+		Reference:  a.Reference,
+		Amount:     a.Amount,
+		Status:     a.Status,
+		PaymentURL: a.PaymentURL,
+	}
+}
+
+func toPaymentMethodDTO(p model.Payment) dto.PaymentMethod {
+	return dto.PaymentMethod{
+		ID:       p.ID,
+		Name:     p.Name,
+		Provider: p.Provider,
+		IsActive: p.IsActive,
+	}
+}
@@ -0,0 +1,117 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/mail"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/payment"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/testutil"
+)
+
+// TestHandleWebhookLateEvents settles a charge through the webhook and then
+// replays events a gateway may deliver late or out of order. None of them may
+// move the charge back or cancel the paid order, and the charge has to stay
+// refundable.
+func TestHandleWebhookLateEvents(t *testing.T) {
+	const secret = "webhook-test-secret"
+	t.Setenv("PAYMENT_WEBHOOK_SECRET_FAKE", secret)
+
+	db := testutil.DB(t)
+	ctx := context.Background()
+	f := newStockFixture(t, db, 5)
+
+	if _, err := db.Exec(ctx, "UPDATE payments SET provider = 'fake' WHERE id = $1", f.paymentId); err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := testRedis(t)
+	orderService := NewOrderService(repository.NewOrderRepository(), repository.NewVoucherRepository(), repository.NewTaxRepository(), repository.NewAddressRepository(), repository.NewScheduleRepository(), mail.NewQueue(discardMailer{}, 1, 10), db, rdb)
+	paymentService := NewPaymentService(repository.NewPaymentRepository(), orderService, payment.NewRegistry(payment.NewFakeProvider(payment.StatusPending)), rdb, db)
+
+	order, err := orderService.CreateOrder(ctx, dto.CreateOrder{
+		Shipping:   ShippingDineIn,
+		Payment_Id: f.paymentId,
+		Menus: []dto.CreateMenuOrder{{
+			MenuId:        f.menuId,
+			Qty:           1,
+			ProductSizeId: f.sizeId,
+			ProductTypeId: f.typeId,
+		}},
+	}, f.userId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attempt, _, err := paymentService.PayOrder(ctx, order.Id_Order, f.userId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(t *testing.T, status string) {
+		t.Helper()
+		body, err := json.Marshal(payment.FakeEvent{
+			EventId:           attempt.Reference + "-" + status,
+			OrderId:           order.Id_Order,
+			Reference:         attempt.Reference,
+			TransactionStatus: status,
+			Amount:            attempt.Amount,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := paymentService.HandleWebhook(ctx, "fake", body, payment.Sign(secret, body)); err != nil {
+			t.Fatalf("webhook %s: %v", status, err)
+		}
+	}
+
+	expect := func(t *testing.T, attemptStatus, orderStatus string, stock int) {
+		t.Helper()
+		var gotAttempt, gotOrder string
+		if err := db.QueryRow(ctx, "SELECT status FROM payment_attempts WHERE id = $1", attempt.ID).Scan(&gotAttempt); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.QueryRow(ctx, "SELECT status FROM orders WHERE id::text = $1", order.Id_Order).Scan(&gotOrder); err != nil {
+			t.Fatal(err)
+		}
+		if gotAttempt != attemptStatus || gotOrder != orderStatus {
+			t.Fatalf("charge %s and order %s, want %s and %s", gotAttempt, gotOrder, attemptStatus, orderStatus)
+		}
+		if got := readStock(t, db, f.menuId); got != stock {
+			t.Fatalf("stock = %d, want %d", got, stock)
+		}
+	}
+
+	send(t, "settlement")
+	expect(t, "settled", OrderStatusPaid, 4)
+
+	for _, status := range []string{"expire", "pending", "deny"} {
+		t.Run(status+" after settlement", func(t *testing.T) {
+			send(t, status)
+			expect(t, "settled", OrderStatusPaid, 4)
+		})
+	}
+
+	cancel := dto.UpdateStatusOrder{OrderId: order.Id_Order, Status: OrderStatusCancelled}
+	if err := orderService.UpdateStatusByOrderId(ctx, cancel, dto.OutletScope{All: true}, 0); !errors.Is(err, apperror.ErrRefundRequired) {
+		t.Fatalf("cancelling the paid order = %v, want %v", err, apperror.ErrRefundRequired)
+	}
+	expect(t, "settled", OrderStatusPaid, 4)
+
+	if _, err := paymentService.RefundOrder(ctx, order.Id_Order, dto.OutletScope{All: true}, 0); err != nil {
+		t.Fatalf("RefundOrder: %v", err)
+	}
+	expect(t, "refunded", OrderStatusCancelled, 5)
+
+	if _, err := paymentService.RefundOrder(ctx, order.Id_Order, dto.OutletScope{All: true}, 0); !errors.Is(err, apperror.ErrRefundNotAllowed) {
+		t.Fatalf("second RefundOrder = %v, want %v", err, apperror.ErrRefundNotAllowed)
+	}
+	expect(t, "refunded", OrderStatusCancelled, 5)
+}
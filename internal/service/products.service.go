@@ -10,8 +10,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/cache"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/pricing"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
@@ -63,15 +66,15 @@ func (ps *ProductService) invalidateCache(ctx context.Context, keys ...string) e
 	return nil
 }
 
-func (ps ProductService) GetAllProducts(ctx context.Context, req dto.ProductQueries) ([]dto.Products, int, error) {
-	rkey := fmt.Sprintf("%s:products:page=%s:title=%s:min=%s:max=%s:categories=%s",
-		os.Getenv("RDB_KEY"), req.Page, req.Title, req.Min, req.Max, strings.Join(req.Category, ","))
+func (ps ProductService) GetAllProducts(ctx context.Context, req dto.ProductQueries, page pagination.Page) ([]dto.Products, pagination.Result, error) {
+	rkey := fmt.Sprintf("%s:products:page=%d:limit=%d:cursor=%s:id=%s:title=%s:min=%s:max=%s:categories=%s:outlet=%d:sort=%s",
+		os.Getenv("RDB_KEY"), page.Number, page.Limit, req.Cursor, req.ID, req.Title, req.Min, req.Max, strings.Join(req.Category, ","), req.Outlet, req.Sort)
 
 	rsc := ps.redis.Get(ctx, rkey)
 	if rsc.Err() == nil {
 		var result struct {
-			Products  []dto.Products `json:"products"`
-			TotalPage int            `json:"total_page"`
+			Products []dto.Products    `json:"products"`
+			Result   pagination.Result `json:"result"`
 		}
 		cache, err := rsc.Bytes()
 		if err != nil {
@@ -80,7 +83,7 @@ func (ps ProductService) GetAllProducts(ctx context.Context, req dto.ProductQuer
 			if err := json.Unmarshal(cache, &result); err != nil {
 				log.Println(err.Error())
 			} else {
-				return result.Products, result.TotalPage, nil
+				return result.Products, result.Result, nil
 			}
 		}
 	}
@@ -89,34 +92,39 @@ func (ps ProductService) GetAllProducts(ctx context.Context, req dto.ProductQuer
 		log.Println("products cache miss")
 	}
 
-	totalPage, err := ps.productRepository.GetTotalPage(ctx, ps.db, req)
+	total, err := ps.productRepository.CountProducts(ctx, ps.db, req)
 	if err != nil {
-		return []dto.Products{}, 0, err
+		return []dto.Products{}, pagination.Result{}, err
 	}
 
-	data, err := ps.productRepository.GetProducts(ctx, ps.db, req)
+	data, next, err := ps.productRepository.GetProducts(ctx, ps.db, req, page)
 	if err != nil {
-		return []dto.Products{}, 0, err
+		return []dto.Products{}, pagination.Result{}, err
 	}
 
 	var response []dto.Products
 	for _, v := range data {
 		response = append(response, dto.Products{
 			Id:          v.Id,
+			MenuId:      v.MenuId,
 			Name:        v.Name,
 			Images_Name: v.Images_Name,
 			Price:       v.Price,
 			Discount:    v.Discount,
+			FinalPrice:  pricing.DiscountedPrice(v.Price, v.Discount),
 			Rating:      v.Rating,
+			ReviewCount: v.ReviewCount,
 		})
 	}
 
+	result := pagination.Result{Total: total, NextCursor: next}
+
 	cacheData := struct {
-		Products  []dto.Products `json:"products"`
-		TotalPage int            `json:"total_page"`
+		Products []dto.Products    `json:"products"`
+		Result   pagination.Result `json:"result"`
 	}{
-		Products:  response,
-		TotalPage: totalPage,
+		Products: response,
+		Result:   result,
 	}
 
 	cacheStr, err := json.Marshal(cacheData)
@@ -131,7 +139,26 @@ func (ps ProductService) GetAllProducts(ctx context.Context, req dto.ProductQuer
 		log.Println(rdsStatus.Err().Error())
 	}
 
-	return response, totalPage, nil
+	return response, result, nil
+}
+
+// SuggestProducts autocompletes product names for the search box.
+func (ps ProductService) SuggestProducts(ctx context.Context, req dto.ProductSuggestQueries) ([]dto.ProductSuggestion, error) {
+	data, err := ps.productRepository.SuggestProducts(ctx, ps.db, strings.TrimSpace(req.Q))
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.ProductSuggestion{}
+	for _, v := range data {
+		response = append(response, dto.ProductSuggestion{
+			Id:     v.Id,
+			MenuId: v.MenuId,
+			Name:   v.Name,
+		})
+	}
+
+	return response, nil
 }
 
 func (ps ProductService) PostProduct(ctx context.Context, post dto.PostProductsRequest, images dto.PostImagesRequest) (dto.PostProductResponse, error) {
@@ -154,6 +181,16 @@ func (ps ProductService) PostProduct(ctx context.Context, post dto.PostProductsR
 		}
 	}
 
+	if len(post.CategoryIds) > 0 {
+		if err := ps.productRepository.SetProductCategories(ctx, tx, data.Id, post.CategoryIds); err != nil {
+			return dto.PostProductResponse{}, err
+		}
+	}
+
+	if err := ps.productRepository.RefreshSearchVector(ctx, tx, data.Id); err != nil {
+		return dto.PostProductResponse{}, err
+	}
+
 	if e := tx.Commit(ctx); e != nil {
 		log.Println("failed to commit", e.Error())
 		return dto.PostProductResponse{}, e
@@ -199,6 +236,16 @@ func (ps ProductService) UpdateProduct(ctx context.Context, update dto.UpdatePro
 		}
 	}
 
+	if len(update.CategoryIds) > 0 {
+		if err := ps.productRepository.SetProductCategories(ctx, tx, idProduct, update.CategoryIds); err != nil {
+			return err
+		}
+	}
+
+	if err := ps.productRepository.RefreshSearchVector(ctx, tx, idProduct); err != nil {
+		return err
+	}
+
 	if e := tx.Commit(ctx); e != nil {
 		log.Println("failed to commit", e.Error())
 		return e
@@ -208,6 +255,7 @@ func (ps ProductService) UpdateProduct(ctx context.Context, update dto.UpdatePro
 	ps.invalidateCache(ctx, 
 		fmt.Sprintf("%s:product_admin", os.Getenv("RDB_KEY")),
 	)
+	cache.InvalidateCarts(ctx, ps.redis)
 
 	return nil
 }
@@ -245,6 +293,7 @@ func (ps ProductService) DeleteProductById(ctx context.Context, idProduct int) e
 	ps.invalidateCache(ctx, 
 		fmt.Sprintf("%s:product_admin", os.Getenv("RDB_KEY")),
 	)
+	cache.InvalidateCarts(ctx, ps.redis)
 
 	return nil
 }
@@ -281,6 +330,20 @@ func (ps ProductService) GetProductById(ctx context.Context, idProduct int) (dto
 		Price:       data.Price,
 		IdImages:    data.IdImages,
 		Images:      data.Images,
+		Categories:  []dto.Category{},
+	}
+
+	categories, err := ps.productRepository.GetProductCategories(ctx, ps.db, idProduct)
+	if err != nil {
+		return dto.DetailProduct{}, err
+	}
+	for _, c := range categories {
+		response.Categories = append(response.Categories, dto.Category{ID: c.ID, Name: c.Name})
+	}
+
+	response.Sizes, response.Types, err = ps.getProductOptions(ctx, idProduct)
+	if err != nil {
+		return dto.DetailProduct{}, err
 	}
 
 	return response, nil
@@ -301,9 +364,16 @@ func (ps ProductService) GetDetailProductByUserWithId(ctx context.Context, idMen
 		Price:        data.Price,
 		Description:  data.Description,
 		Discount:     data.Discount,
+		FinalPrice:   pricing.DiscountedPrice(data.Price, float64(data.Discount)),
 		Rating:       data.Rating,
 		Total_Review: data.Total_Review,
 	}
+
+	response.Sizes, response.Types, err = ps.getProductOptions(ctx, data.IdProduct)
+	if err != nil {
+		return dto.DetailProductUser{}, err
+	}
+
 	return response, nil
 }
 
@@ -458,4 +528,132 @@ func (ps *ProductService) GetAllProductByAdmin(ctx context.Context) ([]dto.Produ
 	}
 
 	return response, nil
-}
\ No newline at end of file
+}
+// invalidateOptionCache drops the cached size or type list together with the
+// cached carts, whose totals include size and type prices.
+func (ps *ProductService) invalidateOptionCache(ctx context.Context, key string) {
+	ps.invalidateCache(ctx, fmt.Sprintf("%s:%s", os.Getenv("RDB_KEY"), key))
+	cache.InvalidateCarts(ctx, ps.redis)
+}
+
+func (ps *ProductService) CreateProductSize(ctx context.Context, req dto.ProductOptionRequest) (dto.ProductSize, error) {
+	data, err := ps.productRepository.CreateProductSize(ctx, ps.db, req)
+	if err != nil {
+		return dto.ProductSize{}, err
+	}
+
+	ps.invalidateOptionCache(ctx, "product_size")
+
+	return dto.ProductSize{Id: data.Id, Name: data.Name, Price: data.Price}, nil
+}
+
+func (ps *ProductService) UpdateProductSize(ctx context.Context, req dto.UpdateProductOptionRequest, id int) error {
+	if err := ps.productRepository.UpdateProductSize(ctx, ps.db, req, id); err != nil {
+		return err
+	}
+
+	ps.invalidateOptionCache(ctx, "product_size")
+
+	return nil
+}
+
+func (ps *ProductService) DeleteProductSize(ctx context.Context, id int) error {
+	if err := ps.productRepository.DeleteProductSize(ctx, ps.db, id); err != nil {
+		return err
+	}
+
+	ps.invalidateOptionCache(ctx, "product_size")
+
+	return nil
+}
+
+func (ps *ProductService) CreateProductType(ctx context.Context, req dto.ProductOptionRequest) (dto.ProductType, error) {
+	data, err := ps.productRepository.CreateProductType(ctx, ps.db, req)
+	if err != nil {
+		return dto.ProductType{}, err
+	}
+
+	ps.invalidateOptionCache(ctx, "product_type")
+
+	return dto.ProductType{Id: data.Id, Name: data.Name, Price: data.Price}, nil
+}
+
+func (ps *ProductService) UpdateProductType(ctx context.Context, req dto.UpdateProductOptionRequest, id int) error {
+	if err := ps.productRepository.UpdateProductType(ctx, ps.db, req, id); err != nil {
+		return err
+	}
+
+	ps.invalidateOptionCache(ctx, "product_type")
+
+	return nil
+}
+
+func (ps *ProductService) DeleteProductType(ctx context.Context, id int) error {
+	if err := ps.productRepository.DeleteProductType(ctx, ps.db, id); err != nil {
+		return err
+	}
+
+	ps.invalidateOptionCache(ctx, "product_type")
+
+	return nil
+}
+
+// SetProductOptions replaces the sizes and types the product offers and
+// their surcharge overrides.
+func (ps *ProductService) SetProductOptions(ctx context.Context, req dto.ProductOptionsRequest, idProduct int) error {
+	tx, err := ps.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := ps.productRepository.ProductExists(ctx, tx, idProduct); err != nil {
+		return err
+	}
+
+	if req.Sizes != nil {
+		if err := ps.productRepository.SetProductSizeOptions(ctx, tx, idProduct, req.Sizes); err != nil {
+			return err
+		}
+	}
+
+	if req.Types != nil {
+		if err := ps.productRepository.SetProductTypeOptions(ctx, tx, idProduct, req.Types); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return err
+	}
+
+	cache.InvalidateCarts(ctx, ps.redis)
+
+	return nil
+}
+
+func (ps *ProductService) getProductOptions(ctx context.Context, idProduct int) ([]dto.ProductSize, []dto.ProductType, error) {
+	sizes, err := ps.productRepository.GetProductSizeOptions(ctx, ps.db, idProduct)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	types, err := ps.productRepository.GetProductTypeOptions(ctx, ps.db, idProduct)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sizeResponse := []dto.ProductSize{}
+	for _, v := range sizes {
+		sizeResponse = append(sizeResponse, dto.ProductSize{Id: v.Id, Name: v.Name, Price: v.Price})
+	}
+
+	typeResponse := []dto.ProductType{}
+	for _, v := range types {
+		typeResponse = append(typeResponse, dto.ProductType{Id: v.Id, Name: v.Name, Price: v.Price})
+	}
+
+	return sizeResponse, typeResponse, nil
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type ReviewService struct {
+	reviewRepository  *repository.ReviewRepository
+	productRepository *repository.ProductRepository
+	redis             *redis.Client
+	db                *pgxpool.Pool
+}
+
+func NewReviewService(reviewRepository *repository.ReviewRepository, productRepository *repository.ProductRepository, rdb *redis.Client, db *pgxpool.Pool) *ReviewService {
+	return &ReviewService{reviewRepository: reviewRepository, productRepository: productRepository, redis: rdb, db: db}
+}
+
+// invalidateProductsCache drops the cached product lists, which carry the
+// average rating of every product.
+func (rs *ReviewService) invalidateProductsCache(ctx context.Context) {
+	pattern := fmt.Sprintf("%s:products:*", os.Getenv("RDB_KEY"))
+	iter := rs.redis.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		if err := rs.redis.Del(ctx, iter.Val()).Err(); err != nil {
+			log.Printf("failed to delete cache key %s: %v", iter.Val(), err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		log.Printf("error during cache invalidation: %v", err)
+	}
+}
+
+// AddReview reviews an item of one of the user's orders. The order has to
+// be done and every item can be reviewed once. The product's rating columns
+// are updated in the same transaction.
+func (rs *ReviewService) AddReview(ctx context.Context, req dto.AddReview, photo string, userID int) (dto.Review, error) {
+	item, err := rs.reviewRepository.GetReviewableItem(ctx, rs.db, req.DtOrderId)
+	if err != nil {
+		return dto.Review{}, err
+	}
+
+	// Items of other users' orders are reported as missing, so their ids do
+	// not give away whether an order exists.
+	if item.UserId != userID {
+		return dto.Review{}, apperror.ErrOrderItemNotFound
+	}
+
+	if item.Status != OrderStatusDone {
+		return dto.Review{}, apperror.ErrReviewNotAllowed
+	}
+
+	tx, err := rs.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.Review{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := rs.reviewRepository.CreateReview(ctx, tx, req, userID, photo)
+	if err != nil {
+		return dto.Review{}, err
+	}
+
+	if err := rs.reviewRepository.AdjustProductRating(ctx, tx, item.ProductId, 1, req.Rating); err != nil {
+		return dto.Review{}, err
+	}
+
+	data, err := rs.reviewRepository.GetReview(ctx, tx, id)
+	if err != nil {
+		return dto.Review{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return dto.Review{}, err
+	}
+
+	rs.invalidateProductsCache(ctx)
+	return toReviewDTO(data), nil
+}
+
+// ownReview locks a review written by the user.
+func (rs *ReviewService) ownReview(ctx context.Context, db repository.DBTX, userID, reviewID int) (model.Review, error) {
+	data, err := rs.reviewRepository.LockReview(ctx, db, reviewID)
+	if err != nil {
+		return model.Review{}, err
+	}
+
+	if data.UserId != userID {
+		return model.Review{}, apperror.ErrReviewForbidden
+	}
+
+	return data, nil
+}
+
+// UpdateReview changes the author's own review and returns the path of the
+// photo it replaced, if any.
+func (rs *ReviewService) UpdateReview(ctx context.Context, req dto.UpdateReviewRequest, photo string, userID, reviewID int) (string, error) {
+	tx, err := rs.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	data, err := rs.ownReview(ctx, tx, userID, reviewID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := rs.reviewRepository.UpdateReview(ctx, tx, req, photo, reviewID); err != nil {
+		return "", err
+	}
+
+	// Hidden reviews are not part of the product rating.
+	if req.Rating != 0 && data.HiddenAt == nil {
+		if err := rs.reviewRepository.AdjustProductRating(ctx, tx, data.ProductId, 0, req.Rating-data.Rating); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return "", err
+	}
+
+	rs.invalidateProductsCache(ctx)
+
+	if data.Photo == nil || photo == "" {
+		return "", nil
+	}
+	return *data.Photo, nil
+}
+
+func (rs *ReviewService) DeleteReview(ctx context.Context, userID, reviewID int) error {
+	tx, err := rs.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	data, err := rs.ownReview(ctx, tx, userID, reviewID)
+	if err != nil {
+		return err
+	}
+
+	if err := rs.reviewRepository.DeleteReview(ctx, tx, reviewID); err != nil {
+		return err
+	}
+
+	if data.HiddenAt == nil {
+		if err := rs.reviewRepository.AdjustProductRating(ctx, tx, data.ProductId, -1, -data.Rating); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return err
+	}
+
+	rs.invalidateProductsCache(ctx)
+	return nil
+}
+
+// GetProductReviews returns a page of the product's visible reviews with
+// the rating summary. The summary always lists ratings 5 to 1.
+func (rs *ReviewService) GetProductReviews(ctx context.Context, productID int, req dto.ReviewParams) (dto.ProductReviews, int, error) {
+	if err := rs.productRepository.ProductExists(ctx, rs.db, productID); err != nil {
+		return dto.ProductReviews{}, 0, err
+	}
+
+	counts, err := rs.reviewRepository.GetRatingDistribution(ctx, rs.db, productID)
+	if err != nil {
+		return dto.ProductReviews{}, 0, err
+	}
+
+	totals := map[int]int{}
+	var sum int
+	response := dto.ProductReviews{ProductId: productID}
+	for _, c := range counts {
+		totals[c.Rating] = c.Total
+		response.TotalReview += c.Total
+		sum += c.Rating * c.Total
+	}
+	for rating := 5; rating >= 1; rating-- {
+		response.Distribution = append(response.Distribution, dto.RatingCount{Rating: rating, Total: totals[rating]})
+	}
+	if response.TotalReview > 0 {
+		response.Rating = float64(sum) / float64(response.TotalReview)
+	}
+
+	data, err := rs.reviewRepository.GetProductReviews(ctx, rs.db, productID, req)
+	if err != nil {
+		return dto.ProductReviews{}, 0, err
+	}
+
+	response.Reviews = []dto.Review{}
+	for _, v := range data {
+		response.Reviews = append(response.Reviews, toReviewDTO(v))
+	}
+
+	itemsPerPage := 5
+	totalPage := int(math.Ceil(float64(response.TotalReview) / float64(itemsPerPage)))
+
+	return response, totalPage, nil
+}
+
+func (rs *ReviewService) GetReviews(ctx context.Context, req dto.AdminReviewParams) ([]dto.AdminReview, int, error) {
+	totalPage, err := rs.reviewRepository.GetTotalPage(ctx, rs.db, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := rs.reviewRepository.GetReviews(ctx, rs.db, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response []dto.AdminReview
+	for _, v := range data {
+		response = append(response, toAdminReviewDTO(v))
+	}
+
+	return response, totalPage, nil
+}
+
+// SetReviewHidden hides an abusive review or shows it again. Hidden reviews
+// stay with their author but are left out of product pages and ratings.
+func (rs *ReviewService) SetReviewHidden(ctx context.Context, req dto.HideReviewRequest, userID, reviewID int) (dto.AdminReview, error) {
+	tx, err := rs.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return dto.AdminReview{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := rs.reviewRepository.LockReview(ctx, tx, reviewID)
+	if err != nil {
+		return dto.AdminReview{}, err
+	}
+
+	if err := rs.reviewRepository.SetReviewHidden(ctx, tx, reviewID, *req.Hidden, req.Reason, userID); err != nil {
+		return dto.AdminReview{}, err
+	}
+
+	wasHidden := current.HiddenAt != nil
+	if *req.Hidden && !wasHidden {
+		if err := rs.reviewRepository.AdjustProductRating(ctx, tx, current.ProductId, -1, -current.Rating); err != nil {
+			return dto.AdminReview{}, err
+		}
+	}
+	if !*req.Hidden && wasHidden {
+		if err := rs.reviewRepository.AdjustProductRating(ctx, tx, current.ProductId, 1, current.Rating); err != nil {
+			return dto.AdminReview{}, err
+		}
+	}
+
+	data, err := rs.reviewRepository.GetReview(ctx, tx, reviewID)
+	if err != nil {
+		return dto.AdminReview{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return dto.AdminReview{}, err
+	}
+
+	rs.invalidateProductsCache(ctx)
+	return toAdminReviewDTO(data), nil
+}
+
+func toReviewDTO(r model.Review) dto.Review {
+	review := dto.Review{
+		ID:        r.ID,
+		ProductId: r.ProductId,
+		UserId:    r.UserId,
+		Fullname:  r.Fullname,
+		Rating:    r.Rating,
+		Comment:   r.Comment,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if r.Photo != nil {
+		review.Photo = *r.Photo
+	}
+	return review
+}
+
+func toAdminReviewDTO(r model.Review) dto.AdminReview {
+	review := dto.AdminReview{
+		Review:      toReviewDTO(r),
+		ProductName: r.ProductName,
+		Hidden:      r.HiddenAt != nil,
+		HiddenAt:    r.HiddenAt,
+	}
+	if r.HiddenReason != nil {
+		review.HiddenReason = *r.HiddenReason
+	}
+	return review
+}
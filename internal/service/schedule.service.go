@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type ScheduleService struct {
+	scheduleRepository *repository.ScheduleRepository
+	outletRepository   *repository.OutletRepository
+	redis              *redis.Client
+	db                 *pgxpool.Pool
+}
+
+func NewScheduleService(scheduleRepository *repository.ScheduleRepository, outletRepository *repository.OutletRepository, rdb *redis.Client, db *pgxpool.Pool) *ScheduleService {
+	return &ScheduleService{scheduleRepository: scheduleRepository, outletRepository: outletRepository, redis: rdb, db: db}
+}
+
+// storeLocation is the time zone opening hours and scheduled times are
+// kept in, taken from STORE_TIMEZONE.
+func storeLocation() *time.Location {
+	name := os.Getenv("STORE_TIMEZONE")
+	if name == "" {
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("invalid STORE_TIMEZONE %q: %v", name, err)
+		return time.Local
+	}
+
+	return loc
+}
+
+// inStore reads a timestamp column back as store local time. Scheduled times
+// are stored without a zone, pgx hands them out as UTC.
+func inStore(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), storeLocation())
+}
+
+// formatSchedule formats a stored scheduled time for responses, or returns
+// an empty string for orders that are not scheduled.
+func formatSchedule(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return inStore(*t).Format(time.RFC3339)
+}
+
+type slot struct {
+	Start time.Time
+	End   time.Time
+}
+
+// daySlots splits the opening hours of the day into slots of SlotMinutes.
+// A remainder shorter than a slot before closing time is not offered.
+func daySlots(hours model.OpeningHours, day time.Time) []slot {
+	opens, err := time.Parse("15:04", hours.OpensAt)
+	if err != nil {
+		return nil
+	}
+	closes, err := time.Parse("15:04", hours.ClosesAt)
+	if err != nil {
+		return nil
+	}
+
+	loc := day.Location()
+	start := time.Date(day.Year(), day.Month(), day.Day(), opens.Hour(), opens.Minute(), 0, 0, loc)
+	end := time.Date(day.Year(), day.Month(), day.Day(), closes.Hour(), closes.Minute(), 0, 0, loc)
+	length := time.Duration(hours.SlotMinutes) * time.Minute
+
+	slots := []slot{}
+	if !hours.IsOpen || length <= 0 {
+		return slots
+	}
+
+	for s := start; !s.Add(length).After(end); s = s.Add(length) {
+		slots = append(slots, slot{Start: s, End: s.Add(length)})
+	}
+
+	return slots
+}
+
+// GetSlots lists the outlet's slots of a date with how many places are
+// left. Slots that have already started are never available.
+func (ss *ScheduleService) GetSlots(ctx context.Context, outletID int, date string) ([]dto.OrderSlot, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, storeLocation())
+	if err != nil {
+		return nil, err
+	}
+
+	outlet, err := ss.outletRepository.GetOutlet(ctx, ss.db, outletID)
+	if err != nil {
+		return nil, err
+	}
+	if !outlet.IsActive {
+		return nil, apperror.ErrOutletInactive
+	}
+
+	hours, err := ss.scheduleRepository.GetOpeningHoursByWeekday(ctx, ss.db, outletID, int(day.Weekday()))
+	if err != nil {
+		return nil, err
+	}
+
+	slots := daySlots(hours, day)
+	response := []dto.OrderSlot{}
+	if len(slots) == 0 {
+		return response, nil
+	}
+
+	counts, err := ss.scheduleRepository.GetScheduledCounts(ctx, ss.db, outletID, slots[0].Start, slots[len(slots)-1].End)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, s := range slots {
+		booked := 0
+		for _, c := range counts {
+			at := inStore(c.ScheduledFor)
+			if !at.Before(s.Start) && at.Before(s.End) {
+				booked += c.Count
+			}
+		}
+
+		remaining := max(hours.SlotCapacity-booked, 0)
+		response = append(response, dto.OrderSlot{
+			Start:     s.Start.Format(time.RFC3339),
+			End:       s.End.Format(time.RFC3339),
+			Capacity:  hours.SlotCapacity,
+			Booked:    booked,
+			Remaining: remaining,
+			Available: remaining > 0 && s.Start.After(now),
+		})
+	}
+
+	return response, nil
+}
+
+func (ss *ScheduleService) GetOpeningHours(ctx context.Context, scope dto.OutletScope, outletID int) ([]dto.OpeningHours, error) {
+	if !scope.Allows(outletID) {
+		return nil, apperror.ErrOutletForbidden
+	}
+
+	if _, err := ss.outletRepository.GetOutlet(ctx, ss.db, outletID); err != nil {
+		return nil, err
+	}
+
+	data, err := ss.scheduleRepository.GetOpeningHours(ctx, ss.db, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.OpeningHours{}
+	for _, v := range data {
+		response = append(response, toOpeningHoursDTO(v))
+	}
+
+	return response, nil
+}
+
+func (ss *ScheduleService) UpdateOpeningHours(ctx context.Context, req dto.OpeningHoursRequest, scope dto.OutletScope, outletID, weekday int) (dto.OpeningHours, error) {
+	if !scope.Allows(outletID) {
+		return dto.OpeningHours{}, apperror.ErrOutletForbidden
+	}
+
+	if _, err := ss.outletRepository.GetOutlet(ctx, ss.db, outletID); err != nil {
+		return dto.OpeningHours{}, err
+	}
+
+	if req.ClosesAt <= req.OpensAt {
+		return dto.OpeningHours{}, apperror.ErrOpeningHoursInvalid
+	}
+
+	if err := ss.scheduleRepository.UpdateOpeningHours(ctx, ss.db, req, outletID, weekday); err != nil {
+		return dto.OpeningHours{}, err
+	}
+
+	data, err := ss.scheduleRepository.GetOpeningHoursByWeekday(ctx, ss.db, outletID, weekday)
+	if err != nil {
+		return dto.OpeningHours{}, err
+	}
+
+	return toOpeningHoursDTO(data), nil
+}
+
+func toOpeningHoursDTO(h model.OpeningHours) dto.OpeningHours {
+	return dto.OpeningHours{
+		OutletId:     h.OutletId,
+		Weekday:      h.Weekday,
+		Day:          time.Weekday(h.Weekday).String(),
+		OpensAt:      h.OpensAt,
+		ClosesAt:     h.ClosesAt,
+		SlotMinutes:  h.SlotMinutes,
+		SlotCapacity: h.SlotCapacity,
+		IsOpen:       h.IsOpen,
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/cache"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type TaxService struct {
+	taxRepository *repository.TaxRepository
+	redis         *redis.Client
+	db            *pgxpool.Pool
+}
+
+func NewTaxService(taxRepository *repository.TaxRepository, rdb *redis.Client, db *pgxpool.Pool) *TaxService {
+	return &TaxService{taxRepository: taxRepository, redis: rdb, db: db}
+}
+
+// managesTaxRule reports whether the scope may change a rule for the outlet.
+// Rules without an outlet apply at every outlet, so only admins who manage
+// every outlet may touch them.
+func managesTaxRule(scope dto.OutletScope, outletId *int) bool {
+	if outletId == nil || *outletId == 0 {
+		return scope.All
+	}
+	return scope.Allows(*outletId)
+}
+
+func (ts *TaxService) CreateTaxRule(ctx context.Context, req dto.TaxRuleRequest, scope dto.OutletScope) (dto.TaxRule, error) {
+	if !managesTaxRule(scope, req.OutletID) {
+		return dto.TaxRule{}, apperror.ErrOutletForbidden
+	}
+
+	if req.EffectiveTo != "" && req.EffectiveTo < req.EffectiveFrom {
+		return dto.TaxRule{}, apperror.ErrTaxRuleInvalidPeriod
+	}
+
+	id, err := ts.taxRepository.CreateTaxRule(ctx, ts.db, req)
+	if err != nil {
+		return dto.TaxRule{}, err
+	}
+
+	cache.InvalidateCarts(ctx, ts.redis)
+
+	data, err := ts.taxRepository.GetTaxRule(ctx, ts.db, id)
+	if err != nil {
+		return dto.TaxRule{}, err
+	}
+
+	return toTaxRuleDTO(data), nil
+}
+
+// GetTaxRule returns a rule for one of the admin's outlets or one that
+// applies at every outlet.
+func (ts *TaxService) GetTaxRule(ctx context.Context, scope dto.OutletScope, ruleID int) (dto.TaxRule, error) {
+	data, err := ts.taxRepository.GetTaxRule(ctx, ts.db, ruleID)
+	if err != nil {
+		return dto.TaxRule{}, err
+	}
+
+	if data.OutletID != nil && !scope.Allows(*data.OutletID) {
+		return dto.TaxRule{}, apperror.ErrOutletForbidden
+	}
+
+	return toTaxRuleDTO(data), nil
+}
+
+func (ts *TaxService) GetTaxRules(ctx context.Context, req dto.TaxRuleParams, scope dto.OutletScope) ([]dto.TaxRule, int, error) {
+	if req.OutletID != 0 && !scope.Allows(req.OutletID) {
+		return nil, 0, apperror.ErrOutletForbidden
+	}
+
+	totalPage, err := ts.taxRepository.GetTotalPage(ctx, ts.db, req, scope.Filter())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := ts.taxRepository.GetTaxRules(ctx, ts.db, req, scope.Filter())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response []dto.TaxRule
+	for _, v := range data {
+		response = append(response, toTaxRuleDTO(v))
+	}
+
+	return response, totalPage, nil
+}
+
+// UpdateTaxRule checks the scope against both the outlet the rule is for now
+// and the one it is moved to.
+func (ts *TaxService) UpdateTaxRule(ctx context.Context, req dto.UpdateTaxRuleRequest, scope dto.OutletScope, ruleID int) error {
+	if req.EffectiveFrom != "" && req.EffectiveTo != nil && *req.EffectiveTo != "" && *req.EffectiveTo < req.EffectiveFrom {
+		return apperror.ErrTaxRuleInvalidPeriod
+	}
+
+	current, err := ts.taxRepository.GetTaxRule(ctx, ts.db, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if !managesTaxRule(scope, current.OutletID) {
+		return apperror.ErrOutletForbidden
+	}
+
+	if req.OutletID != nil && !managesTaxRule(scope, req.OutletID) {
+		return apperror.ErrOutletForbidden
+	}
+
+	if err := ts.taxRepository.UpdateTaxRule(ctx, ts.db, req, ruleID); err != nil {
+		return err
+	}
+
+	cache.InvalidateCarts(ctx, ts.redis)
+	return nil
+}
+
+func (ts *TaxService) DeleteTaxRule(ctx context.Context, scope dto.OutletScope, ruleID int) error {
+	current, err := ts.taxRepository.GetTaxRule(ctx, ts.db, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if !managesTaxRule(scope, current.OutletID) {
+		return apperror.ErrOutletForbidden
+	}
+
+	if err := ts.taxRepository.DeleteTaxRule(ctx, ts.db, ruleID); err != nil {
+		return err
+	}
+
+	cache.InvalidateCarts(ctx, ts.redis)
+	return nil
+}
+
+func toTaxRuleDTO(t model.TaxRule) dto.TaxRule {
+	res := dto.TaxRule{
+		ID:            t.ID,
+		Name:          t.Name,
+		Kind:          t.Kind,
+		Rate:          t.Rate,
+		IsInclusive:   t.IsInclusive,
+		EffectiveFrom: t.EffectiveFrom.Format("2006-01-02"),
+		IsActive:      t.IsActive,
+	}
+
+	if t.Shipping != nil {
+		res.Shipping = *t.Shipping
+	}
+	res.OutletID = t.OutletID
+	if t.EffectiveTo != nil {
+		res.EffectiveTo = t.EffectiveTo.Format("2006-01-02")
+	}
+
+	return res
+}
+
+func toOrderTaxDTO(lines []model.OrderTax) []dto.OrderTax {
+	res := []dto.OrderTax{}
+	for _, l := range lines {
+		res = append(res, dto.OrderTax{
+			Name:        l.Name,
+			Kind:        l.Kind,
+			Rate:        l.Rate,
+			IsInclusive: l.IsInclusive,
+			Base:        l.Base,
+			Amount:      l.Amount,
+		})
+	}
+	return res
+}
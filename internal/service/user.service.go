@@ -9,6 +9,7 @@ import (
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
 	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
 	hashutil "github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/hash"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
@@ -23,12 +24,7 @@ func NewUserService(userRepository *repository.UserRepository, rdb *redis.Client
 	return &UserService{userRepository: userRepository, redis: rdb, db: db}
 }
 
-func (us *UserService) UpdateProfile(ctx context.Context, req dto.UpdateProfileRequest, path string, id int, token string) (string, error) {
-	err := cache.CheckToken(ctx, us.redis, id, token)
-	if err != nil {
-		return "", err
-	}
-
+func (us *UserService) UpdateProfile(ctx context.Context, req dto.UpdateProfileRequest, path string, id int) (string, error) {
 	tx, err := us.db.Begin(ctx)
 	if err != nil {
 		log.Println(err.Error())
@@ -53,12 +49,7 @@ func (us *UserService) UpdateProfile(ctx context.Context, req dto.UpdateProfileR
 	return oldPath, nil
 }
 
-func (us *UserService) UpdateProfileAdmin(ctx context.Context, req dto.UpdateProfileRequest, path string, userID, id int, token string) (string, error) {
-	err := cache.CheckToken(ctx, us.redis, id, token)
-	if err != nil {
-		return "", err
-	}
-
+func (us *UserService) UpdateProfileAdmin(ctx context.Context, req dto.UpdateProfileRequest, path string, userID int) (string, error) {
 	tx, err := us.db.Begin(ctx)
 	if err != nil {
 		log.Println(err.Error())
@@ -83,11 +74,7 @@ func (us *UserService) UpdateProfileAdmin(ctx context.Context, req dto.UpdatePro
 	return oldPath, nil
 }
 
-func (us *UserService) UpdatePassword(ctx context.Context, req dto.UpdatePasswordRequest, id int, token string) error {
-	if err := cache.CheckToken(ctx, us.redis, id, token); err != nil {
-		return err
-	}
-
+func (us *UserService) UpdatePassword(ctx context.Context, req dto.UpdatePasswordRequest, id int) error {
 	tx, err := us.db.Begin(ctx)
 	if err != nil {
 		log.Println(err.Error())
@@ -127,12 +114,7 @@ func (us *UserService) UpdatePassword(ctx context.Context, req dto.UpdatePasswor
 	return nil
 }
 
-func (us *UserService) GetProfile(ctx context.Context, id int, token string) (dto.User, error) {
-	err := cache.CheckToken(ctx, us.redis, id, token)
-	if err != nil {
-		return dto.User{}, err
-	}
-
+func (us *UserService) GetProfile(ctx context.Context, id int) (dto.User, error) {
 	data, err := us.userRepository.GetProfile(ctx, us.db, id)
 	if err != nil {
 		return dto.User{}, err
@@ -151,11 +133,7 @@ func (us *UserService) GetProfile(ctx context.Context, id int, token string) (dt
 	return res, nil
 }
 
-func (us *UserService) InsertUser(ctx context.Context, req dto.InsertUserRequest, id int, path, token string) error {
-	if err := cache.CheckToken(ctx, us.redis, id, token); err != nil {
-		return err
-	}
-
+func (us *UserService) InsertUser(ctx context.Context, req dto.InsertUserRequest, path string) error {
 	hasher := hashutil.Default()
 	hashedPassword, err := hasher.Hash(req.Password)
 	if err != nil {
@@ -171,43 +149,40 @@ func (us *UserService) InsertUser(ctx context.Context, req dto.InsertUserRequest
 	return nil
 }
 
-func (us *UserService) DeleteUser(ctx context.Context, id, userID int, token string) error {
-	if err := cache.CheckToken(ctx, us.redis, id, token); err != nil {
+func (us *UserService) DeleteUser(ctx context.Context, userID int) error {
+	if err := us.userRepository.DeleteUser(ctx, us.db, userID); err != nil {
 		return err
 	}
 
-	if err := us.userRepository.DeleteUser(ctx, us.db, userID); err != nil {
-		return err
+	// A deleted user is logged out of every device right away.
+	if err := cache.RevokeSessions(ctx, us.redis, userID); err != nil {
+		log.Println("failed to revoke sessions:", err.Error())
 	}
 
 	return nil
 }
 
-func (us *UserService) GetUsers(ctx context.Context, req dto.UserQueries, id int, token string) ([]dto.User, int, error) {
-	if err := cache.CheckToken(ctx, us.redis, id, token); err != nil {
-		return nil, 0, err
-	}
-
+func (us *UserService) GetUsers(ctx context.Context, page pagination.Page) ([]dto.User, pagination.Result, error) {
 	tx, err := us.db.Begin(ctx)
 	if err != nil {
 		log.Println(err.Error())
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 
-	totalPage, err := us.userRepository.GetUserTotalPages(ctx, tx)
+	total, err := us.userRepository.CountUsers(ctx, tx)
 	if err != nil {
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 	defer tx.Rollback(ctx)
 
-	data, err := us.userRepository.GetUsers(ctx, tx, req)
+	data, next, err := us.userRepository.GetUsers(ctx, tx, page)
 	if err != nil {
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
 		log.Println("failed to commit", err.Error())
-		return nil, 0, err
+		return nil, pagination.Result{}, err
 	}
 
 	var response []dto.User
@@ -222,5 +197,5 @@ func (us *UserService) GetUsers(ctx context.Context, req dto.UserQueries, id int
 		})
 	}
 
-	return response, totalPage, nil
+	return response, pagination.Result{Total: total, NextCursor: next}, nil
 }
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type VoucherService struct {
+	voucherRepository *repository.VoucherRepository
+	redis             *redis.Client
+	db                *pgxpool.Pool
+}
+
+func NewVoucherService(voucherRepository *repository.VoucherRepository, rdb *redis.Client, db *pgxpool.Pool) *VoucherService {
+	return &VoucherService{voucherRepository: voucherRepository, redis: rdb, db: db}
+}
+
+func (vs *VoucherService) CreateVoucher(ctx context.Context, req dto.VoucherRequest) error {
+	if req.StartDate != "" && req.EndDate != "" && req.EndDate < req.StartDate {
+		return apperror.ErrVoucherInvalidPeriod
+	}
+
+	return vs.voucherRepository.CreateVoucher(ctx, vs.db, req)
+}
+
+func (vs *VoucherService) GetVoucher(ctx context.Context, voucherID int) (dto.Voucher, error) {
+	data, err := vs.voucherRepository.GetVoucher(ctx, vs.db, voucherID)
+	if err != nil {
+		return dto.Voucher{}, err
+	}
+
+	return toVoucherDTO(data), nil
+}
+
+func (vs *VoucherService) GetVouchers(ctx context.Context, req dto.VoucherParams) ([]dto.Voucher, int, error) {
+	tx, err := vs.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	totalPage, err := vs.voucherRepository.GetTotalPage(ctx, tx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := vs.voucherRepository.GetVouchers(ctx, tx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return nil, 0, err
+	}
+
+	var response []dto.Voucher
+	for _, v := range data {
+		response = append(response, toVoucherDTO(v))
+	}
+
+	return response, totalPage, nil
+}
+
+// UpdateVoucher checks the period the voucher ends up with, so moving only
+// one of its dates cannot put the end before the start.
+func (vs *VoucherService) UpdateVoucher(ctx context.Context, req dto.UpdateVoucherRequest, voucherID int) error {
+	tx, err := vs.db.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := vs.voucherRepository.GetVoucherForUpdate(ctx, tx, voucherID)
+	if err != nil {
+		return err
+	}
+
+	merged := toVoucherDTO(current)
+	if req.StartDate != nil {
+		merged.StartDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		merged.EndDate = *req.EndDate
+	}
+	if merged.StartDate != "" && merged.EndDate != "" && merged.EndDate < merged.StartDate {
+		return apperror.ErrVoucherInvalidPeriod
+	}
+
+	if err := vs.voucherRepository.UpdateVoucher(ctx, tx, req, voucherID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("failed to commit", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (vs *VoucherService) DeleteVoucher(ctx context.Context, voucherID int) error {
+	return vs.voucherRepository.DeleteVoucher(ctx, vs.db, voucherID)
+}
+
+func toVoucherDTO(v model.Voucher) dto.Voucher {
+	res := dto.Voucher{
+		ID:          v.ID,
+		Code:        v.Code,
+		Name:        v.Name,
+		Description: v.Description,
+		Discount:    v.Discount,
+		MinOrder:    v.MinOrder,
+		UsageLimit:  v.UsageLimit,
+		UsageCount:  v.UsageCount,
+		IsActive:    v.IsActive,
+	}
+
+	if v.StartDate != nil {
+		res.StartDate = v.StartDate.Format("2006-01-02")
+	}
+	if v.EndDate != nil {
+		res.EndDate = v.EndDate.Format("2006-01-02")
+	}
+
+	return res
+}
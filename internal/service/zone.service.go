@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/dto"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/model"
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type ZoneService struct {
+	zoneRepository *repository.ZoneRepository
+	redis          *redis.Client
+	db             *pgxpool.Pool
+}
+
+func NewZoneService(zoneRepository *repository.ZoneRepository, rdb *redis.Client, db *pgxpool.Pool) *ZoneService {
+	return &ZoneService{zoneRepository: zoneRepository, redis: rdb, db: db}
+}
+
+func (zs *ZoneService) GetActiveDeliveryZones(ctx context.Context) ([]dto.DeliveryZone, error) {
+	data, err := zs.zoneRepository.GetActiveDeliveryZones(ctx, zs.db)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.DeliveryZone{}
+	for _, v := range data {
+		response = append(response, toDeliveryZoneDTO(v))
+	}
+
+	return response, nil
+}
+
+func (zs *ZoneService) CreateDeliveryZone(ctx context.Context, req dto.DeliveryZoneRequest) (dto.DeliveryZone, error) {
+	id, err := zs.zoneRepository.CreateDeliveryZone(ctx, zs.db, req)
+	if err != nil {
+		return dto.DeliveryZone{}, err
+	}
+
+	data, err := zs.zoneRepository.GetDeliveryZone(ctx, zs.db, id)
+	if err != nil {
+		return dto.DeliveryZone{}, err
+	}
+
+	return toDeliveryZoneDTO(data), nil
+}
+
+func (zs *ZoneService) GetDeliveryZone(ctx context.Context, zoneID int) (dto.DeliveryZone, error) {
+	data, err := zs.zoneRepository.GetDeliveryZone(ctx, zs.db, zoneID)
+	if err != nil {
+		return dto.DeliveryZone{}, err
+	}
+
+	return toDeliveryZoneDTO(data), nil
+}
+
+func (zs *ZoneService) GetDeliveryZones(ctx context.Context, req dto.DeliveryZoneParams) ([]dto.DeliveryZone, int, error) {
+	totalPage, err := zs.zoneRepository.GetTotalPage(ctx, zs.db, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := zs.zoneRepository.GetDeliveryZones(ctx, zs.db, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response []dto.DeliveryZone
+	for _, v := range data {
+		response = append(response, toDeliveryZoneDTO(v))
+	}
+
+	return response, totalPage, nil
+}
+
+func (zs *ZoneService) UpdateDeliveryZone(ctx context.Context, req dto.UpdateDeliveryZoneRequest, zoneID int) error {
+	if err := zs.zoneRepository.UpdateDeliveryZone(ctx, zs.db, req, zoneID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (zs *ZoneService) DeleteDeliveryZone(ctx context.Context, zoneID int) error {
+	if err := zs.zoneRepository.DeleteDeliveryZone(ctx, zs.db, zoneID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func toDeliveryZoneDTO(z model.DeliveryZone) dto.DeliveryZone {
+	return dto.DeliveryZone{
+		ID:       z.ID,
+		Name:     z.Name,
+		Fee:      z.Fee,
+		IsActive: z.IsActive,
+	}
+}
@@ -0,0 +1,34 @@
+// Package testutil holds helpers shared by the integration tests, which run
+// with the integration build tag against a real database.
+package testutil
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DB connects to TEST_DATABASE_URL, a migrated database the tests may read
+// and write. Without it the test is skipped.
+func DB(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		tb.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(db.Close)
+
+	if err := db.Ping(context.Background()); err != nil {
+		tb.Fatal(err)
+	}
+
+	return db
+}
@@ -1,6 +1,8 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"os"
 	"time"
@@ -10,41 +12,61 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// AccessTokenTTL is how long an access token lasts. Clients keep their
+// session going with the refresh token.
+const AccessTokenTTL = 15 * time.Minute
+
 type JwtClaims struct {
 	*dto.JWTClaims
 }
 
-func NewJWTClaims(id int, role string) *JwtClaims {
+// NewJWTClaims builds the claims of an access token of the session. Every
+// token gets its own id, so two tokens issued in the same second differ.
+func NewJWTClaims(id int, role, sessionID string) *JwtClaims {
+	jti := make([]byte, 16)
+	rand.Read(jti)
+
+	now := time.Now()
 	return &JwtClaims{
 		JWTClaims: &dto.JWTClaims{
-			UserID: id,
-			Role:   role,
+			UserID:    id,
+			Role:      role,
+			SessionID: sessionID,
 			RegisteredClaims: jwt.RegisteredClaims{
-				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)),
+				ID:        hex.EncodeToString(jti),
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
 				Issuer:    os.Getenv("JWT_ISSUER"),
 			},
 		},
 	}
 }
 
+// GenToken signs the claims with the signing key loaded by LoadKeys.
 func (jc *JwtClaims) GenToken() (string, error) {
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		return "", apperror.ErrSecretNotFound
+	key := keys.signing
+	if key == nil {
+		return "", apperror.ErrSigningKeyNotFound
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jc)
-	return token.SignedString([]byte(jwtSecret))
+	token := jwt.NewWithClaims(key.Method, jc)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
 }
 
+// VerifyToken checks the token against the key its kid header names. Tokens
+// of keys that were rotated out no longer verify.
 func (jc *JwtClaims) VerifyToken(token string) (bool, error) {
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		return false, apperror.ErrSecretNotFound
-	}
-
 	jwtToken, err := jwt.ParseWithClaims(token, jc, func(t *jwt.Token) (any, error) {
-		return []byte(jwtSecret), nil
-	})
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keys.byID[kid]
+		if !ok {
+			return nil, apperror.ErrTokenKeyUnknown
+		}
+		if t.Method.Alg() != key.Method.Alg() {
+			return nil, apperror.ErrTokenInvalid
+		}
+		return key.Public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return false, apperror.ErrTokenExpired
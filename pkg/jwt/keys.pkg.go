@@ -0,0 +1,170 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NugrahaPancaWibisana/solid-coffee-be/internal/apperror"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits is the smallest RSA key accepted for signing or verifying.
+const minRSABits = 2048
+
+// Key is a key access tokens are signed or verified with. Its ID is the kid
+// header of the tokens it signs. Keys loaded from a public key file have no
+// Private part and only verify.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+type keySet struct {
+	signing *Key
+	byID    map[string]*Key
+}
+
+// keys is filled by LoadKeys before the server starts.
+var keys keySet
+
+// LoadKeys loads every <kid>.pem file of dir. A private key file signs and
+// verifies, a <kid>.pub.pem public key file only verifies. The key with
+// signingKid signs new tokens, so it has to be a private key. Keeping the
+// previous key next to the signing one lets tokens it signed run out their
+// lifetime after a rotation.
+func LoadKeys(dir, signingKid string) error {
+	if dir == "" {
+		return apperror.ErrKeysDirNotFound
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+
+	set := keySet{byID: map[string]*Key{}}
+	for _, file := range files {
+		key, err := readKey(file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if _, ok := set.byID[key.ID]; ok {
+			return fmt.Errorf("%s: duplicate key id %q", file, key.ID)
+		}
+		set.byID[key.ID] = key
+	}
+
+	signing, ok := set.byID[signingKid]
+	if !ok || signing.Private == nil {
+		return fmt.Errorf("%w: no private key %q in %s", apperror.ErrSigningKeyNotFound, signingKid, dir)
+	}
+	set.signing = signing
+
+	keys = set
+	return nil
+}
+
+func readKey(file string) (*Key, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data")
+	}
+
+	key := &Key{ID: strings.TrimSuffix(strings.TrimSuffix(filepath.Base(file), ".pem"), ".pub")}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.Private = signer
+		key.Public = signer.Public()
+	} else {
+		key.Public = parsed
+	}
+
+	switch pub := key.Public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return nil, apperror.ErrKeyUnsupported
+		}
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, apperror.ErrKeyUnsupported
+	}
+
+	return key, nil
+}
+
+// JWK is the public part of a key as a JSON Web Key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeys returns the keys tokens are verified with, for services that
+// check tokens without being able to sign them.
+func PublicKeys() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, key := range keys.byID {
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].Kid < set.Keys[j].Kid
+	})
+
+	return set
+}
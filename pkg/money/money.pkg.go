@@ -0,0 +1,102 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Amount is an amount of money in whole rupiah. Prices are never quoted
+// below one rupiah, so an integer keeps every sum exact. Fractions only
+// appear when a rate is applied, and Apply resolves them with an explicit
+// rounding rule.
+type Amount int64
+
+// Rate is a percentage expressed in basis points, 1000 is 10%.
+type Rate int64
+
+const basisPoints = 10000
+
+// Rounding decides what happens to the fraction of a rupiah left after a
+// rate is applied.
+type Rounding int
+
+const (
+	// RoundHalfUp rounds to the nearest rupiah, halves away from zero. Tax
+	// uses it, computed once on the order total rather than per line.
+	RoundHalfUp Rounding = iota
+	// RoundDown drops the fraction.
+	RoundDown
+	// RoundUp carries any fraction to the next rupiah.
+	RoundUp
+)
+
+// RateFromPercent converts a percentage such as 10 or 12.5.
+func RateFromPercent(p float64) Rate {
+	return Rate(math.Round(p * 100))
+}
+
+// RateFromFraction converts a fraction such as 0.1.
+func RateFromFraction(f float64) Rate {
+	return Rate(math.Round(f * basisPoints))
+}
+
+// Mul multiplies the amount by a quantity.
+func (a Amount) Mul(qty int) Amount {
+	return a * Amount(qty)
+}
+
+// Apply returns the rate's share of the amount, rounded to whole rupiah.
+func (a Amount) Apply(r Rate, mode Rounding) Amount {
+	return divide(int64(a)*int64(r), basisPoints, mode)
+}
+
+// Included returns the part of an amount that a rate added on top of it,
+// for prices that already include the charge. 11000 with 10% included
+// holds 1000.
+func (a Amount) Included(r Rate, mode Rounding) Amount {
+	return divide(int64(a)*int64(r), basisPoints+int64(r), mode)
+}
+
+func divide(num, den int64, mode Rounding) Amount {
+	neg := num < 0
+	if neg {
+		num = -num
+	}
+
+	q, rem := num/den, num%den
+	switch mode {
+	case RoundHalfUp:
+		if rem*2 >= den {
+			q++
+		}
+	case RoundUp:
+		if rem > 0 {
+			q++
+		}
+	}
+
+	if neg {
+		q = -q
+	}
+	return Amount(q)
+}
+
+// String formats the amount the way receipts show it, e.g. "Rp 12.500".
+func (a Amount) String() string {
+	digits := strconv.FormatInt(int64(a), 10)
+	sign := ""
+	if a < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var out []byte
+	for i := range len(digits) {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, '.')
+		}
+		out = append(out, digits[i])
+	}
+
+	return fmt.Sprintf("%sRp %s", sign, out)
+}
@@ -0,0 +1,100 @@
+package money
+
+import "testing"
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		rate   Rate
+		mode   Rounding
+		want   Amount
+	}{
+		{"exact", 50000, 1000, RoundHalfUp, 5000},
+		{"half rounds up", 15, 1000, RoundHalfUp, 2},
+		{"half of one rupiah rounds up", 5, 1000, RoundHalfUp, 1},
+		{"below half rounds down", 14, 1000, RoundHalfUp, 1},
+		{"above half rounds up", 16, 1000, RoundHalfUp, 2},
+		{"half on a real price", 12345, 1000, RoundHalfUp, 1235},
+		{"negative half rounds away from zero", -15, 1000, RoundHalfUp, -2},
+		{"round down drops the fraction", 19, 1000, RoundDown, 1},
+		{"round up carries any fraction", 11, 1000, RoundUp, 2},
+		{"round up keeps exact amounts", 10, 1000, RoundUp, 1},
+		{"zero rate", 12345, 0, RoundHalfUp, 0},
+		{"full rate", 12345, 10000, RoundHalfUp, 12345},
+		{"fractional percent", 10000, 1250, RoundHalfUp, 1250},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Apply(tt.rate, tt.mode); got != tt.want {
+				t.Errorf("%d.Apply(%d) = %d, want %d", tt.amount, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncluded(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		rate   Rate
+		mode   Rounding
+		want   Amount
+	}{
+		{"exact", 11000, 1000, RoundHalfUp, 1000},
+		{"exact eleven percent", 55500, 1100, RoundHalfUp, 5500},
+		{"fraction rounds to nearest", 1000, 1000, RoundHalfUp, 91},
+		{"half rounds up", 3, 10000, RoundHalfUp, 2},
+		{"half of one rupiah rounds up", 1, 10000, RoundHalfUp, 1},
+		{"round down drops the fraction", 1000, 1000, RoundDown, 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Included(tt.rate, tt.mode); got != tt.want {
+				t.Errorf("%d.Included(%d) = %d, want %d", tt.amount, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRates(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Rate
+		want Rate
+	}{
+		{"whole percent", RateFromPercent(10), 1000},
+		{"fractional percent", RateFromPercent(12.5), 1250},
+		{"float noise", RateFromPercent(0.1 + 0.2), 30},
+		{"fraction", RateFromFraction(0.11), 1100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("rate = %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		want   string
+	}{
+		{0, "Rp 0"},
+		{999, "Rp 999"},
+		{12500, "Rp 12.500"},
+		{1234567, "Rp 1.234.567"},
+		{-1234567, "-Rp 1.234.567"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.String(); got != tt.want {
+			t.Errorf("Amount(%d).String() = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxLimit is the largest page size a client can ask for.
+const MaxLimit = 50
+
+var (
+	ErrInvalidLimit  = fmt.Errorf("Limit must be between 1 and %d", MaxLimit)
+	ErrInvalidCursor = errors.New("Invalid cursor")
+)
+
+// Page is the slice of a list a request asks for. Without a cursor the page
+// is picked by its number. With a cursor the page starts right after the
+// last item of the page the cursor was issued with, so rows added in the
+// meantime never shift it.
+type Page struct {
+	Number int
+	Limit  int
+	Cursor *Cursor
+}
+
+// New resolves the page, limit and cursor query params. A missing or
+// invalid page number falls back to the first page and a zero limit to
+// defaultLimit.
+func New(page string, limit int, cursor string, defaultLimit int) (Page, error) {
+	p := Page{Number: 1, Limit: defaultLimit}
+
+	if n, err := strconv.Atoi(page); err == nil && n > 1 {
+		p.Number = n
+	}
+
+	if limit != 0 {
+		if limit < 1 || limit > MaxLimit {
+			return Page{}, ErrInvalidLimit
+		}
+		p.Limit = limit
+	}
+
+	if cursor != "" {
+		c, err := Decode(cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		p.Cursor = &c
+		p.Number = 0
+	}
+
+	return p, nil
+}
+
+// Offset is the number of rows to skip. Cursor pages never skip rows, the
+// cursor condition already starts them at the right row.
+func (p Page) Offset() int {
+	if p.Cursor != nil {
+		return 0
+	}
+	return (p.Number - 1) * p.Limit
+}
+
+// Fetch is the number of rows to query. The one extra row tells whether
+// another page follows.
+func (p Page) Fetch() int {
+	return p.Limit + 1
+}
+
+// Cursor points after a row of a list. It holds the row's sort keys as text
+// and the name of the order they belong to.
+type Cursor struct {
+	Order  string   `json:"o"`
+	Values []string `json:"v"`
+}
+
+// Encode turns the cursor into the opaque string handed to clients.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode reads a cursor made by Encode.
+func Decode(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Order == "" || len(c.Values) == 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// Key is one sort key of an order. Expr is evaluated per row and must never
+// be NULL, Type is the SQL type its text form is cast back to.
+type Key struct {
+	Expr string
+	Type string
+	Desc bool
+}
+
+// Order is the sort of a list. The keys together have to be unique per
+// row, so the last key is usually the primary key.
+type Order struct {
+	Name string
+	Keys []Key
+}
+
+// OrderBy is the ORDER BY list of the order.
+func (o Order) OrderBy() string {
+	parts := make([]string, len(o.Keys))
+	for i, k := range o.Keys {
+		dir := "ASC"
+		if k.Desc {
+			dir = "DESC"
+		}
+		parts[i] = k.Expr + " " + dir
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Select is a column holding the sort keys of a row as a text array, which
+// is what cursors are made of.
+func (o Order) Select() string {
+	parts := make([]string, len(o.Keys))
+	for i, k := range o.Keys {
+		parts[i] = "(" + k.Expr + ")::text"
+	}
+	return "ARRAY[" + strings.Join(parts, ", ") + "]"
+}
+
+// Seek returns the condition matching the rows after the page's cursor,
+// with placeholders numbered from first, and the values they stand for.
+// It returns an empty condition when the page has no cursor.
+func (o Order) Seek(p Page, first int) (string, []any, error) {
+	if p.Cursor == nil {
+		return "", nil, nil
+	}
+	if p.Cursor.Order != o.Name || len(p.Cursor.Values) != len(o.Keys) {
+		return "", nil, ErrInvalidCursor
+	}
+
+	args := make([]any, len(o.Keys))
+	values := make([]string, len(o.Keys))
+	for i, k := range o.Keys {
+		args[i] = p.Cursor.Values[i]
+		values[i] = fmt.Sprintf("$%d::%s", first+i, k.Type)
+	}
+
+	// A row comes later when it ties on every key before some key and
+	// passes the cursor on that key.
+	var ors []string
+	for i, k := range o.Keys {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, fmt.Sprintf("%s = %s", o.Keys[j].Expr, values[j]))
+		}
+		op := ">"
+		if k.Desc {
+			op = "<"
+		}
+		ands = append(ands, fmt.Sprintf("%s %s %s", k.Expr, op, values[i]))
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+
+	return "(" + strings.Join(ors, " OR ") + ")", args, nil
+}
+
+// Trim drops the extra row queried through Page.Fetch. When there was one,
+// it also returns the cursor continuing after the last row kept. keys holds
+// the Select column of every row.
+func Trim[T any](o Order, p Page, rows []T, keys [][]string) ([]T, string) {
+	if len(rows) <= p.Limit {
+		return rows, ""
+	}
+
+	rows = rows[:p.Limit]
+	return rows, Cursor{Order: o.Name, Values: keys[p.Limit-1]}.Encode()
+}
+
+// Result is what a list query found besides its rows.
+type Result struct {
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// TotalPages is the number of pages of the given size the total fills.
+func (r Result) TotalPages(limit int) int {
+	if limit < 1 {
+		return 0
+	}
+	return int(math.Ceil(float64(r.Total) / float64(limit)))
+}